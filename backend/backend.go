@@ -17,7 +17,9 @@ package backend
 
 import (
 	"crypto/sha256"
+	"fmt"
 	"hash"
+	"strings"
 
 	"github.com/consensys/gnark/constraint/solver"
 )
@@ -197,3 +199,79 @@ func WithVerifierKZGFoldingHashFunction(hFunc hash.Hash) VerifierOption {
 		return nil
 	}
 }
+
+// BatchVerifyError is returned by the batch verifiers when some of the proofs
+// are invalid.
+type BatchVerifyError struct {
+	// Indexes of the invalid proofs, in increasing order.
+	Indexes []int
+	// Errs are the verification errors of the invalid proofs, in the same order
+	// as Indexes.
+	Errs []error
+}
+
+// NewBatchVerifyError returns a [BatchVerifyError] for the non-nil errors in
+// errs, where errs[i] is the verification error of the i-th proof. It returns
+// nil if all errors are nil.
+func NewBatchVerifyError(errs []error) error {
+	var res BatchVerifyError
+	for i := range errs {
+		if errs[i] != nil {
+			res.Indexes = append(res.Indexes, i)
+			res.Errs = append(res.Errs, errs[i])
+		}
+	}
+	if len(res.Indexes) == 0 {
+		return nil
+	}
+	return &res
+}
+
+func (e *BatchVerifyError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("batch verification failed")
+	for i := range e.Indexes {
+		fmt.Fprintf(&sb, "; proof %d: %v", e.Indexes[i], e.Errs[i])
+	}
+	return sb.String()
+}
+
+// Unwrap returns the verification errors of the invalid proofs.
+func (e *BatchVerifyError) Unwrap() []error {
+	return e.Errs
+}
+
+// SetupOption defines option for altering the behavior of the setup. See the
+// descriptions of functions returning instances of this type for implemented
+// options.
+type SetupOption func(*SetupConfig) error
+
+// SetupConfig is the configuration for the setup with the options applied.
+type SetupConfig struct {
+	IOPPHash hash.Hash
+}
+
+// NewSetupConfig returns a default [SetupConfig] with given setup options
+// applied.
+func NewSetupConfig(opts ...SetupOption) (SetupConfig, error) {
+	opt := SetupConfig{
+		IOPPHash: sha256.New(),
+	}
+	for _, option := range opts {
+		if err := option(&opt); err != nil {
+			return SetupConfig{}, err
+		}
+	}
+	return opt, nil
+}
+
+// WithSetupIOPPHashFunction sets the hash function used for committing to the
+// polynomials and computing the challenges in the interactive oracle proofs of
+// proximity. If not set then by default SHA2-256 is used. Used mainly for
+// compatibility between different systems and efficient recursion.
+func WithSetupIOPPHashFunction(hFunc hash.Hash) SetupOption {
+	return func(sc *SetupConfig) error {
+		sc.IOPPHash = hFunc
+		return nil
+	}
+}
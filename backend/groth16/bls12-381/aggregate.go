@@ -0,0 +1,779 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package groth16
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/internal/utils"
+	"github.com/consensys/gnark/logger"
+)
+
+var (
+	errAggregationCommitments = errors.New("aggregation of proofs with commitments is not supported")
+	errAggregationSRSTooSmall = errors.New("aggregation SRS is too small for the number of proofs")
+)
+
+// AggregationSRS is the structured reference string used to aggregate Groth16
+// proofs (SnarkPack). It contains the powers of two independent secrets a and b
+//
+//	G1.A = [aⁱ]₁, G1.B = [bⁱ]₁ for i < 2n
+//	G2.A = [aⁱ]₂, G2.B = [bⁱ]₂ for i < n
+//
+// where n is the maximal number of proofs which can be aggregated. The secrets
+// must come from two independent ceremonies (for example two powers of tau
+// transcripts) and must not be known by anyone.
+//
+// The verifier only uses the first two powers of each vector, so it can be
+// given a truncated SRS.
+//
+// See https://eprint.iacr.org/2021/529
+type AggregationSRS struct {
+	G1 struct {
+		A, B []curve.G1Affine
+	}
+	G2 struct {
+		A, B []curve.G2Affine
+	}
+}
+
+// NewAggregationSRS returns an aggregation SRS for aggregating up to size
+// proofs, computed from the secrets a and b.
+//
+// This is for testing purposes only, as the secrets are known to the caller.
+// In production, fill the fields of [AggregationSRS] from the transcripts of
+// two independent powers-of-tau ceremonies, or decode a previously serialized
+// SRS with [AggregationSRS.ReadFrom].
+func NewAggregationSRS(size uint64, a, b *big.Int) (*AggregationSRS, error) {
+	if size < 1 {
+		return nil, errors.New("aggregation SRS size must be positive")
+	}
+	n := ecc.NextPowerOfTwo(size)
+	var aFr, bFr fr.Element
+	aFr.SetBigInt(a)
+	bFr.SetBigInt(b)
+	if aFr.IsZero() || bFr.IsZero() || aFr.Equal(&bFr) {
+		return nil, errors.New("aggregation SRS secrets must be distinct and non-zero")
+	}
+
+	_, _, g1, g2 := curve.Generators()
+	powersA := powers(aFr, int(2*n))
+	powersB := powers(bFr, int(2*n))
+
+	var srs AggregationSRS
+	srs.G1.A = curve.BatchScalarMultiplicationG1(&g1, powersA)
+	srs.G1.B = curve.BatchScalarMultiplicationG1(&g1, powersB)
+	srs.G2.A = curve.BatchScalarMultiplicationG2(&g2, powersA[:n])
+	srs.G2.B = curve.BatchScalarMultiplicationG2(&g2, powersB[:n])
+
+	return &srs, nil
+}
+
+// AggregationRound holds the cross terms sent by the prover in one round of
+// the TIPP and MIPP arguments. The terms at index 0 are obtained by
+// combining the right half of the proof elements with the left half of the
+// keys, the terms at index 1 the other way around.
+type AggregationRound struct {
+	CommitmentAB [2][2]curve.GT
+	CommitmentC  [2][2]curve.GT
+	IPAB         [2]curve.GT
+	AggC         [2]curve.G1Affine
+}
+
+// AggregatedProof is an aggregation of Groth16 proofs for the same verifying
+// key. Its size is logarithmic in the number of aggregated proofs.
+type AggregatedProof struct {
+	// pair commitment (T, U) to the Ar and Bs vectors and commitment (T, U) to
+	// the Krs vector
+	CommitmentAB, CommitmentC [2]curve.GT
+
+	// IPAB = ∏ e(Arᵢ, Bsᵢ)^{rⁱ} and AggC = ∑ rⁱ Krsᵢ for a random r
+	IPAB curve.GT
+	AggC curve.G1Affine
+
+	// cross terms of the TIPP and MIPP arguments
+	Rounds []AggregationRound
+
+	// Ar, Bs, Krs and the commitment keys folded down to a single element
+	FinalA, FinalC curve.G1Affine
+	FinalB         curve.G2Affine
+	FinalV         [2]curve.G2Affine
+	FinalW         [2]curve.G1Affine
+
+	// KZG opening proofs of the folded commitment keys
+	OpeningV [2]curve.G2Affine
+	OpeningW [2]curve.G1Affine
+}
+
+// CurveID returns the curveID
+func (proof *AggregatedProof) CurveID() ecc.ID {
+	return curve.ID
+}
+
+// CurveID returns the curveID
+func (srs *AggregationSRS) CurveID() ecc.ID {
+	return curve.ID
+}
+
+// isValid ensures the aggregated proof elements are in the correct subgroup
+func (proof *AggregatedProof) isValid() bool {
+	for i := range proof.Rounds {
+		if !proof.Rounds[i].AggC[0].IsInSubGroup() || !proof.Rounds[i].AggC[1].IsInSubGroup() {
+			return false
+		}
+	}
+	return proof.AggC.IsInSubGroup() && proof.FinalA.IsInSubGroup() && proof.FinalC.IsInSubGroup() &&
+		proof.FinalB.IsInSubGroup() &&
+		proof.FinalV[0].IsInSubGroup() && proof.FinalV[1].IsInSubGroup() &&
+		proof.FinalW[0].IsInSubGroup() && proof.FinalW[1].IsInSubGroup() &&
+		proof.OpeningV[0].IsInSubGroup() && proof.OpeningV[1].IsInSubGroup() &&
+		proof.OpeningW[0].IsInSubGroup() && proof.OpeningW[1].IsInSubGroup()
+}
+
+// Aggregate aggregates Groth16 proofs for the same verifying key into a proof
+// of logarithmic size, using the SnarkPack TIPP and MIPP arguments.
+//
+// If the number of proofs is not a power of two, the last proof is repeated.
+// Proofs with commitments are not supported.
+func Aggregate(vk *VerifyingKey, proofs []*Proof, publicWitnesses []fr.Vector, srs *AggregationSRS, opts ...backend.ProverOption) (*AggregatedProof, error) {
+	opt, err := backend.NewProverConfig(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new prover config: %w", err)
+	}
+	if len(vk.PublicAndCommitmentCommitted) != 0 {
+		return nil, errAggregationCommitments
+	}
+	if len(proofs) == 0 || len(proofs) != len(publicWitnesses) {
+		return nil, fmt.Errorf("invalid number of proofs, got %d proofs and %d public witnesses", len(proofs), len(publicWitnesses))
+	}
+	for i := range proofs {
+		if len(proofs[i].Commitments) != 0 {
+			return nil, errAggregationCommitments
+		}
+	}
+	n := int(ecc.NextPowerOfTwo(uint64(len(proofs))))
+	if len(srs.G2.A) < n || len(srs.G2.B) < n || len(srs.G1.A) < 2*n || len(srs.G1.B) < 2*n {
+		return nil, errAggregationSRSTooSmall
+	}
+	log := logger.Logger().With().Str("curve", curve.ID.String()).Int("nbProofs", len(proofs)).Str("backend", "groth16").Logger()
+	start := time.Now()
+
+	// pad the proofs by repeating the last one
+	a := make([]curve.G1Affine, n)
+	b := make([]curve.G2Affine, n)
+	c := make([]curve.G1Affine, n)
+	publicWitnesses = padPublicWitnesses(publicWitnesses, n)
+	for i := 0; i < n; i++ {
+		p := proofs[len(proofs)-1]
+		if i < len(proofs) {
+			p = proofs[i]
+		}
+		a[i], b[i], c[i] = p.Ar, p.Bs, p.Krs
+	}
+
+	// the commitment keys. The keys paired with a are v = ([aⁱ]₂, [bⁱ]₂) and
+	// the keys paired with b are w = ([aⁿ⁺ⁱ]₁, [bⁿ⁺ⁱ]₁).
+	v := [2][]curve.G2Affine{
+		append([]curve.G2Affine{}, srs.G2.A[:n]...),
+		append([]curve.G2Affine{}, srs.G2.B[:n]...),
+	}
+	w := [2][]curve.G1Affine{
+		append([]curve.G1Affine{}, srs.G1.A[n:2*n]...),
+		append([]curve.G1Affine{}, srs.G1.B[n:2*n]...),
+	}
+
+	var proof AggregatedProof
+	for k := 0; k < 2; k++ {
+		if proof.CommitmentAB[k], err = curve.Pair(concat(a, w[k]), concat(v[k], b)); err != nil {
+			return nil, err
+		}
+		if proof.CommitmentC[k], err = curve.Pair(c, v[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	nbRounds := bits.TrailingZeros(uint(n))
+	fs := newAggregationTranscript(opt.ChallengeHash, nbRounds)
+	r, err := deriveAggregationChallenge(fs, "r", aggregationInputs(vk, publicWitnesses, &proof)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// we prove that IPAB = ∏ e(Aᵢ, Bᵢ^{rⁱ}). The commitment to (A, B) is also a
+	// commitment to (A, B^{rⁱ}) for the keys (v, w^{r⁻ⁱ}).
+	rPowers := powers(r, n)
+	var rInv fr.Element
+	rInv.Inverse(&r)
+	rInvPowers := powers(rInv, n)
+	scaleG2(b, rPowers)
+	scaleG1(w[0], rInvPowers)
+	scaleG1(w[1], rInvPowers)
+	if proof.IPAB, err = curve.Pair(a, b); err != nil {
+		return nil, err
+	}
+	if _, err = proof.AggC.MultiExp(c, rPowers, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+
+	// run the TIPP and MIPP arguments together, folding the vectors in half at
+	// each round with the same challenge.
+	proof.Rounds = make([]AggregationRound, nbRounds)
+	x := make([]fr.Element, nbRounds)
+	for j := range proof.Rounds {
+		h := len(a) / 2
+		round := &proof.Rounds[j]
+		for k := 0; k < 2; k++ {
+			if round.CommitmentAB[0][k], err = curve.Pair(concat(a[h:], w[k][h:]), concat(v[k][:h], b[:h])); err != nil {
+				return nil, err
+			}
+			if round.CommitmentAB[1][k], err = curve.Pair(concat(a[:h], w[k][:h]), concat(v[k][h:], b[h:])); err != nil {
+				return nil, err
+			}
+			if round.CommitmentC[0][k], err = curve.Pair(c[h:], v[k][:h]); err != nil {
+				return nil, err
+			}
+			if round.CommitmentC[1][k], err = curve.Pair(c[:h], v[k][h:]); err != nil {
+				return nil, err
+			}
+		}
+		if round.IPAB[0], err = curve.Pair(a[h:], b[:h]); err != nil {
+			return nil, err
+		}
+		if round.IPAB[1], err = curve.Pair(a[:h], b[h:]); err != nil {
+			return nil, err
+		}
+		if _, err = round.AggC[0].MultiExp(c[h:], rPowers[:h], ecc.MultiExpConfig{}); err != nil {
+			return nil, err
+		}
+		if _, err = round.AggC[1].MultiExp(c[:h], rPowers[h:], ecc.MultiExpConfig{}); err != nil {
+			return nil, err
+		}
+
+		data := aggregationRoundData(round)
+		if j == 0 {
+			data = append(aggregationIPData(&proof), data...)
+		}
+		if x[j], err = deriveAggregationChallenge(fs, "x"+strconv.Itoa(j), data...); err != nil {
+			return nil, err
+		}
+		var xInv fr.Element
+		xInv.Inverse(&x[j])
+
+		// A ← A_L + x·A_R, B ← B_L + x⁻¹·B_R, C ← C_L + x·C_R, r ← r_L + x⁻¹·r_R
+		// v ← v_L + x⁻¹·v_R, w ← w_L + x·w_R
+		a = foldG1(a, x[j])
+		b = foldG2(b, xInv)
+		c = foldG1(c, x[j])
+		rPowers = foldFr(rPowers, xInv)
+		for k := 0; k < 2; k++ {
+			v[k] = foldG2(v[k], xInv)
+			w[k] = foldG1(w[k], x[j])
+		}
+	}
+	proof.FinalA, proof.FinalB, proof.FinalC = a[0], b[0], c[0]
+	proof.FinalV = [2]curve.G2Affine{v[0][0], v[1][0]}
+	proof.FinalW = [2]curve.G1Affine{w[0][0], w[1][0]}
+
+	// prove that the folded keys are correctly computed from the SRS, by
+	// opening the polynomials defining them at a random point z.
+	z, err := deriveAggregationChallenge(fs, "z", aggregationFinalData(&proof)...)
+	if err != nil {
+		return nil, err
+	}
+	fv, fw := keyPolynomials(x, r, n)
+	qv, qw := quotient(fv, z), quotient(fw, z)
+	if _, err = proof.OpeningV[0].MultiExp(srs.G2.A[:len(qv)], qv, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	if _, err = proof.OpeningV[1].MultiExp(srs.G2.B[:len(qv)], qv, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	if _, err = proof.OpeningW[0].MultiExp(srs.G1.A[:len(qw)], qw, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	if _, err = proof.OpeningW[1].MultiExp(srs.G1.B[:len(qw)], qw, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+
+	log.Debug().Dur("took", time.Since(start)).Msg("aggregation done")
+
+	return &proof, nil
+}
+
+// VerifyAggregated verifies an aggregated proof with given VerifyingKey and
+// public witnesses of the aggregated proofs.
+func VerifyAggregated(proof *AggregatedProof, vk *VerifyingKey, publicWitnesses []fr.Vector, srs *AggregationSRS, opts ...backend.VerifierOption) error {
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new verifier config: %w", err)
+	}
+	if len(vk.PublicAndCommitmentCommitted) != 0 {
+		return errAggregationCommitments
+	}
+	if len(publicWitnesses) == 0 {
+		return errors.New("no public witness to verify the aggregated proof against")
+	}
+	for i := range publicWitnesses {
+		if len(publicWitnesses[i]) != len(vk.G1.K)-1 {
+			return fmt.Errorf("invalid witness size, got %d, expected %d (public - ONE_WIRE)", len(publicWitnesses[i]), len(vk.G1.K)-1)
+		}
+	}
+	n := int(ecc.NextPowerOfTwo(uint64(len(publicWitnesses))))
+	nbRounds := bits.TrailingZeros(uint(n))
+	if len(proof.Rounds) != nbRounds {
+		return fmt.Errorf("invalid number of rounds, got %d, expected %d", len(proof.Rounds), nbRounds)
+	}
+	if len(srs.G1.A) < 2 || len(srs.G1.B) < 2 || len(srs.G2.A) < 2 || len(srs.G2.B) < 2 {
+		return errAggregationSRSTooSmall
+	}
+	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Int("nbProofs", len(publicWitnesses)).Str("backend", "groth16").Logger()
+	start := time.Now()
+
+	if !proof.isValid() {
+		return errCorrectSubgroupCheckFailed
+	}
+
+	// derive the challenges
+	publicWitnesses = padPublicWitnesses(publicWitnesses, n)
+	fs := newAggregationTranscript(opt.ChallengeHash, nbRounds)
+	r, err := deriveAggregationChallenge(fs, "r", aggregationInputs(vk, publicWitnesses, proof)...)
+	if err != nil {
+		return err
+	}
+	x := make([]fr.Element, nbRounds)
+	for j := range proof.Rounds {
+		data := aggregationRoundData(&proof.Rounds[j])
+		if j == 0 {
+			data = append(aggregationIPData(proof), data...)
+		}
+		if x[j], err = deriveAggregationChallenge(fs, "x"+strconv.Itoa(j), data...); err != nil {
+			return err
+		}
+	}
+	z, err := deriveAggregationChallenge(fs, "z", aggregationFinalData(proof)...)
+	if err != nil {
+		return err
+	}
+
+	// fold the commitments and the inner products with the cross terms
+	// T ← T_L^x · T · T_R^{x⁻¹}
+	commitmentAB, commitmentC, ipAB := proof.CommitmentAB, proof.CommitmentC, proof.IPAB
+	var aggC curve.G1Jac
+	aggC.FromAffine(&proof.AggC)
+	for j := range proof.Rounds {
+		round := &proof.Rounds[j]
+		var xBig, xInvBig big.Int
+		var xInv fr.Element
+		xInv.Inverse(&x[j])
+		x[j].BigInt(&xBig)
+		xInv.BigInt(&xInvBig)
+		for k := 0; k < 2; k++ {
+			foldGT(&commitmentAB[k], &round.CommitmentAB[0][k], &round.CommitmentAB[1][k], &xBig, &xInvBig)
+			foldGT(&commitmentC[k], &round.CommitmentC[0][k], &round.CommitmentC[1][k], &xBig, &xInvBig)
+		}
+		foldGT(&ipAB, &round.IPAB[0], &round.IPAB[1], &xBig, &xInvBig)
+		var tmp curve.G1Jac
+		tmp.ScalarMultiplication(new(curve.G1Jac).FromAffine(&round.AggC[0]), &xBig)
+		aggC.AddAssign(&tmp)
+		tmp.ScalarMultiplication(new(curve.G1Jac).FromAffine(&round.AggC[1]), &xInvBig)
+		aggC.AddAssign(&tmp)
+	}
+
+	// check the folded values against the final elements
+	ok := true
+	var check curve.GT
+	if check, err = curve.Pair([]curve.G1Affine{proof.FinalA}, []curve.G2Affine{proof.FinalB}); err != nil {
+		return err
+	}
+	ok = ok && check.Equal(&ipAB)
+	for k := 0; k < 2; k++ {
+		if check, err = curve.Pair([]curve.G1Affine{proof.FinalA, proof.FinalW[k]}, []curve.G2Affine{proof.FinalV[k], proof.FinalB}); err != nil {
+			return err
+		}
+		ok = ok && check.Equal(&commitmentAB[k])
+		if check, err = curve.Pair([]curve.G1Affine{proof.FinalC}, []curve.G2Affine{proof.FinalV[k]}); err != nil {
+			return err
+		}
+		ok = ok && check.Equal(&commitmentC[k])
+	}
+	var rFinal fr.Element
+	rFinal.SetOne()
+	rPower := r
+	for j := nbRounds - 1; j >= 0; j-- {
+		// rFinal = ∏ (1 + x_j⁻¹·r^{n/2^{j+1}})
+		var tmp, xInv fr.Element
+		xInv.Inverse(&x[j])
+		tmp.Mul(&xInv, &rPower).Add(&tmp, new(fr.Element).SetOne())
+		rFinal.Mul(&rFinal, &tmp)
+		rPower.Square(&rPower)
+	}
+	var rFinalBig big.Int
+	rFinal.BigInt(&rFinalBig)
+	var finalC curve.G1Jac
+	finalC.FromAffine(&proof.FinalC)
+	finalC.ScalarMultiplication(&finalC, &rFinalBig)
+	ok = ok && finalC.Equal(&aggC)
+	if !ok {
+		return errPairingCheckFailed
+	}
+
+	// check the openings of the folded keys at z
+	fvz, fwz := evaluateKeyPolynomials(x, r, z, n)
+	if err = verifyKeyOpening(&proof.FinalV[0], &proof.OpeningV[0], &proof.FinalW[0], &proof.OpeningW[0], srs.G1.A[:2], srs.G2.A[:2], &z, &fvz, &fwz); err != nil {
+		return err
+	}
+	if err = verifyKeyOpening(&proof.FinalV[1], &proof.OpeningV[1], &proof.FinalW[1], &proof.OpeningW[1], srs.G1.B[:2], srs.G2.B[:2], &z, &fvz, &fwz); err != nil {
+		return err
+	}
+
+	// check the aggregated Groth16 equation
+	// IPAB · e(∑ rⁱ Sᵢ, -[γ]₂) · e(AggC, -[δ]₂) == e(α, β)^{∑ rⁱ}
+	// where Sᵢ = [Kvk(t)]₁ for the i-th public witness
+	rPowers := powers(r, n)
+	var rSum fr.Element
+	for i := range rPowers {
+		rSum.Add(&rSum, &rPowers[i])
+	}
+	scalars := make([]fr.Element, len(vk.G1.K))
+	scalars[0] = rSum
+	for i := range publicWitnesses {
+		for j := range publicWitnesses[i] {
+			var tmp fr.Element
+			tmp.Mul(&rPowers[i], &publicWitnesses[i][j])
+			scalars[j+1].Add(&scalars[j+1], &tmp)
+		}
+	}
+	var kSum curve.G1Affine
+	if _, err = kSum.MultiExp(vk.G1.K, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	right, err := curve.MillerLoop([]curve.G1Affine{kSum, proof.AggC}, []curve.G2Affine{vk.G2.gammaNeg, vk.G2.deltaNeg})
+	if err != nil {
+		return err
+	}
+	right = curve.FinalExponentiation(&right)
+	right.Mul(&right, &proof.IPAB)
+	var rSumBig big.Int
+	rSum.BigInt(&rSumBig)
+	var left curve.GT
+	left.Exp(vk.e, &rSumBig)
+	if !left.Equal(&right) {
+		return errPairingCheckFailed
+	}
+
+	log.Debug().Dur("took", time.Since(start)).Msg("aggregated proof verifier done")
+	return nil
+}
+
+// verifyKeyOpening checks the KZG openings of the folded keys v and w at z,
+// where srsG1 and srsG2 are the first two powers of the same secret.
+func verifyKeyOpening(v, openingV *curve.G2Affine, w, openingW *curve.G1Affine, srsG1 []curve.G1Affine, srsG2 []curve.G2Affine, z, fvz, fwz *fr.Element) error {
+	var zBig, fvzBig, fwzBig big.Int
+	z.BigInt(&zBig)
+	fvz.BigInt(&fvzBig)
+	fwz.BigInt(&fwzBig)
+
+	// e([s-z]₁, π_v) == e([1]₁, v - [f_v(z)]₂)
+	var sMinusZ1, g1Neg, wMinusFwz curve.G1Affine
+	sMinusZ1.ScalarMultiplication(&srsG1[0], &zBig)
+	sMinusZ1.Sub(&srsG1[1], &sMinusZ1)
+	g1Neg.Neg(&srsG1[0])
+	var vMinusFvz curve.G2Affine
+	vMinusFvz.ScalarMultiplication(&srsG2[0], &fvzBig)
+	vMinusFvz.Sub(v, &vMinusFvz)
+
+	// e(π_w, [s-z]₂) == e(w - [f_w(z)]₁, [1]₂)
+	var sMinusZ2 curve.G2Affine
+	sMinusZ2.ScalarMultiplication(&srsG2[0], &zBig)
+	sMinusZ2.Sub(&srsG2[1], &sMinusZ2)
+	wMinusFwz.ScalarMultiplication(&srsG1[0], &fwzBig)
+	wMinusFwz.Sub(w, &wMinusFwz)
+	wMinusFwz.Neg(&wMinusFwz)
+
+	ok, err := curve.PairingCheck(
+		[]curve.G1Affine{sMinusZ1, g1Neg, *openingW, wMinusFwz},
+		[]curve.G2Affine{*openingV, vMinusFvz, sMinusZ2, srsG2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPairingCheckFailed
+	}
+	return nil
+}
+
+// keyPolynomials returns the coefficients of the polynomials fᵥ and f_w such
+// that the folded keys are v = ([fᵥ(a)]₂, [fᵥ(b)]₂) and w = ([f_w(a)]₁, [f_w(b)]₁):
+//
+//	fᵥ(X) = ∏ (1 + x_j⁻¹·X^{n/2^{j+1}})
+//	f_w(X) = Xⁿ·∏ (1 + x_j·(X/r)^{n/2^{j+1}})
+func keyPolynomials(x []fr.Element, r fr.Element, n int) (fv, fw []fr.Element) {
+	fv = make([]fr.Element, 1, n)
+	fv[0].SetOne()
+	gw := make([]fr.Element, 1, n)
+	gw[0].SetOne()
+	for j := len(x) - 1; j >= 0; j-- {
+		var xInv fr.Element
+		xInv.Inverse(&x[j])
+		m := len(fv)
+		for i := 0; i < m; i++ {
+			var tv, tw fr.Element
+			tv.Mul(&fv[i], &xInv)
+			tw.Mul(&gw[i], &x[j])
+			fv = append(fv, tv)
+			gw = append(gw, tw)
+		}
+	}
+	var rInv fr.Element
+	rInv.Inverse(&r)
+	rInvPowers := powers(rInv, n)
+	fw = make([]fr.Element, 2*n)
+	for i := 0; i < n; i++ {
+		fw[n+i].Mul(&gw[i], &rInvPowers[i])
+	}
+	return fv, fw
+}
+
+// evaluateKeyPolynomials returns fᵥ(z) and f_w(z), see [keyPolynomials].
+func evaluateKeyPolynomials(x []fr.Element, r, z fr.Element, n int) (fvz, fwz fr.Element) {
+	var rInv, one fr.Element
+	rInv.Inverse(&r)
+	one.SetOne()
+	fvz.SetOne()
+	fwz.Exp(z, big.NewInt(int64(n)))
+	zPower := z
+	zrPower := z
+	zrPower.Mul(&zrPower, &rInv)
+	for j := len(x) - 1; j >= 0; j-- {
+		var xInv, tmp fr.Element
+		xInv.Inverse(&x[j])
+		tmp.Mul(&xInv, &zPower).Add(&tmp, &one)
+		fvz.Mul(&fvz, &tmp)
+		tmp.Mul(&x[j], &zrPower).Add(&tmp, &one)
+		fwz.Mul(&fwz, &tmp)
+		zPower.Square(&zPower)
+		zrPower.Square(&zrPower)
+	}
+	return
+}
+
+// quotient returns the coefficients of (f(X) - f(z)) / (X - z).
+func quotient(f []fr.Element, z fr.Element) []fr.Element {
+	if len(f) < 2 {
+		return make([]fr.Element, 1)
+	}
+	q := make([]fr.Element, len(f)-1)
+	q[len(q)-1] = f[len(f)-1]
+	for i := len(q) - 1; i > 0; i-- {
+		var tmp fr.Element
+		tmp.Mul(&q[i], &z)
+		q[i-1].Add(&f[i], &tmp)
+	}
+	return q
+}
+
+// newAggregationTranscript returns the Fiat-Shamir transcript of the
+// aggregation argument with nbRounds rounds.
+func newAggregationTranscript(h hash.Hash, nbRounds int) *fiatshamir.Transcript {
+	challenges := make([]string, 0, nbRounds+2)
+	challenges = append(challenges, "r")
+	for j := 0; j < nbRounds; j++ {
+		challenges = append(challenges, "x"+strconv.Itoa(j))
+	}
+	challenges = append(challenges, "z")
+	h.Reset()
+	return fiatshamir.NewTranscript(h, challenges...)
+}
+
+// deriveAggregationChallenge binds data to the challenge and computes it.
+func deriveAggregationChallenge(fs *fiatshamir.Transcript, challenge string, data ...[]byte) (fr.Element, error) {
+	var res fr.Element
+	for i := range data {
+		if err := fs.Bind(challenge, data[i]); err != nil {
+			return res, err
+		}
+	}
+	b, err := fs.ComputeChallenge(challenge)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(b)
+	return res, nil
+}
+
+// aggregationInputs returns the data bound to the challenge r: the verifying
+// key, the public witnesses and the commitments.
+func aggregationInputs(vk *VerifyingKey, publicWitnesses []fr.Vector, proof *AggregatedProof) [][]byte {
+	var buf bytes.Buffer
+	// writing to a bytes.Buffer doesn't fail
+	_, _ = vk.WriteRawTo(&buf)
+	data := [][]byte{buf.Bytes()}
+	for i := range publicWitnesses {
+		for j := range publicWitnesses[i] {
+			data = append(data, publicWitnesses[i][j].Marshal())
+		}
+	}
+	for k := 0; k < 2; k++ {
+		data = append(data, proof.CommitmentAB[k].Marshal(), proof.CommitmentC[k].Marshal())
+	}
+	return data
+}
+
+// aggregationIPData returns the inner products bound to the first challenge
+// after r.
+func aggregationIPData(proof *AggregatedProof) [][]byte {
+	aggC := proof.AggC.RawBytes()
+	return [][]byte{proof.IPAB.Marshal(), aggC[:]}
+}
+
+// aggregationRoundData returns the cross terms of a round bound to the round
+// challenge.
+func aggregationRoundData(round *AggregationRound) [][]byte {
+	data := make([][]byte, 0, 12)
+	for l := 0; l < 2; l++ {
+		for k := 0; k < 2; k++ {
+			data = append(data, round.CommitmentAB[l][k].Marshal(), round.CommitmentC[l][k].Marshal())
+		}
+		aggC := round.AggC[l].RawBytes()
+		data = append(data, round.IPAB[l].Marshal(), aggC[:])
+	}
+	return data
+}
+
+// aggregationFinalData returns the folded elements bound to the challenge z.
+func aggregationFinalData(proof *AggregatedProof) [][]byte {
+	finalA, finalB, finalC := proof.FinalA.RawBytes(), proof.FinalB.RawBytes(), proof.FinalC.RawBytes()
+	data := [][]byte{finalA[:], finalB[:], finalC[:]}
+	for k := 0; k < 2; k++ {
+		v, w := proof.FinalV[k].RawBytes(), proof.FinalW[k].RawBytes()
+		data = append(data, v[:], w[:])
+	}
+	return data
+}
+
+// padPublicWitnesses repeats the last public witness up to n witnesses.
+func padPublicWitnesses(publicWitnesses []fr.Vector, n int) []fr.Vector {
+	res := make([]fr.Vector, n)
+	copy(res, publicWitnesses)
+	for i := len(publicWitnesses); i < n; i++ {
+		res[i] = publicWitnesses[len(publicWitnesses)-1]
+	}
+	return res
+}
+
+// powers returns [1, x, x², ..., xⁿ⁻¹]
+func powers(x fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &x)
+	}
+	return res
+}
+
+func concat[T any](a, b []T) []T {
+	res := make([]T, 0, len(a)+len(b))
+	res = append(res, a...)
+	return append(res, b...)
+}
+
+// scaleG1 sets p[i] = s[i]·p[i]
+func scaleG1(p []curve.G1Affine, s []fr.Element) {
+	utils.Parallelize(len(p), func(start, end int) {
+		var sBig big.Int
+		for i := start; i < end; i++ {
+			s[i].BigInt(&sBig)
+			p[i].ScalarMultiplication(&p[i], &sBig)
+		}
+	})
+}
+
+// scaleG2 sets p[i] = s[i]·p[i]
+func scaleG2(p []curve.G2Affine, s []fr.Element) {
+	utils.Parallelize(len(p), func(start, end int) {
+		var sBig big.Int
+		for i := start; i < end; i++ {
+			s[i].BigInt(&sBig)
+			p[i].ScalarMultiplication(&p[i], &sBig)
+		}
+	})
+}
+
+// foldG1 returns p_L + s·p_R, reusing the memory of p
+func foldG1(p []curve.G1Affine, s fr.Element) []curve.G1Affine {
+	h := len(p) / 2
+	var sBig big.Int
+	s.BigInt(&sBig)
+	utils.Parallelize(h, func(start, end int) {
+		var tmp curve.G1Affine
+		for i := start; i < end; i++ {
+			tmp.ScalarMultiplication(&p[h+i], &sBig)
+			p[i].Add(&p[i], &tmp)
+		}
+	})
+	return p[:h]
+}
+
+// foldG2 returns p_L + s·p_R, reusing the memory of p
+func foldG2(p []curve.G2Affine, s fr.Element) []curve.G2Affine {
+	h := len(p) / 2
+	var sBig big.Int
+	s.BigInt(&sBig)
+	utils.Parallelize(h, func(start, end int) {
+		var tmp curve.G2Affine
+		for i := start; i < end; i++ {
+			tmp.ScalarMultiplication(&p[h+i], &sBig)
+			p[i].Add(&p[i], &tmp)
+		}
+	})
+	return p[:h]
+}
+
+// foldFr returns p_L + s·p_R, reusing the memory of p
+func foldFr(p []fr.Element, s fr.Element) []fr.Element {
+	h := len(p) / 2
+	for i := 0; i < h; i++ {
+		var tmp fr.Element
+		tmp.Mul(&p[h+i], &s)
+		p[i].Add(&p[i], &tmp)
+	}
+	return p[:h]
+}
+
+// foldGT sets t = l^x · t · r^{xInv}
+func foldGT(t, l, r *curve.GT, x, xInv *big.Int) {
+	var tmp curve.GT
+	tmp.Exp(*l, x)
+	t.Mul(t, &tmp)
+	tmp.Exp(*r, xInv)
+	t.Mul(t, &tmp)
+}
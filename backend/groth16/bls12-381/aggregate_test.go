@@ -0,0 +1,109 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package groth16
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	cs "github.com/consensys/gnark/constraint/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/io"
+	"github.com/stretchr/testify/require"
+)
+
+type aggregationCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *aggregationCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X, c.X), c.Y)
+	return nil
+}
+
+func aggregationProofs(t *testing.T, nbProofs int) (*VerifyingKey, []*Proof, []fr.Vector) {
+	ccs, err := frontend.Compile(ecc.BLS12_381.ScalarField(), r1cs.NewBuilder, &aggregationCircuit{})
+	require.NoError(t, err)
+	var pk ProvingKey
+	var vk VerifyingKey
+	require.NoError(t, Setup(ccs.(*cs.R1CS), &pk, &vk))
+
+	proofs := make([]*Proof, nbProofs)
+	publicWitnesses := make([]fr.Vector, nbProofs)
+	for i := range proofs {
+		x := big.NewInt(int64(i + 2))
+		assignment := &aggregationCircuit{X: x, Y: new(big.Int).Exp(x, big.NewInt(3), nil)}
+		w, err := frontend.NewWitness(assignment, ecc.BLS12_381.ScalarField())
+		require.NoError(t, err)
+		proofs[i], err = Prove(ccs.(*cs.R1CS), &pk, w)
+		require.NoError(t, err)
+		pw, err := w.Public()
+		require.NoError(t, err)
+		publicWitnesses[i] = pw.Vector().(fr.Vector)
+		require.NoError(t, Verify(proofs[i], &vk, publicWitnesses[i]))
+	}
+	return &vk, proofs, publicWitnesses
+}
+
+func TestAggregate(t *testing.T) {
+	srs, err := NewAggregationSRS(8, big.NewInt(42), big.NewInt(43))
+	require.NoError(t, err)
+
+	for _, nbProofs := range []int{1, 5, 8} {
+		vk, proofs, publicWitnesses := aggregationProofs(t, nbProofs)
+		aggregated, err := Aggregate(vk, proofs, publicWitnesses, srs)
+		require.NoError(t, err)
+		require.NoError(t, VerifyAggregated(aggregated, vk, publicWitnesses, srs), "nbProofs=%d", nbProofs)
+
+		// wrong public witness
+		publicWitnesses[0][0].SetUint64(1)
+		require.Error(t, VerifyAggregated(aggregated, vk, publicWitnesses, srs), "nbProofs=%d", nbProofs)
+	}
+}
+
+func TestAggregateInvalidProof(t *testing.T) {
+	srs, err := NewAggregationSRS(4, big.NewInt(42), big.NewInt(43))
+	require.NoError(t, err)
+
+	vk, proofs, publicWitnesses := aggregationProofs(t, 4)
+	proofs[1], proofs[2] = proofs[2], proofs[1]
+	aggregated, err := Aggregate(vk, proofs, publicWitnesses, srs)
+	require.NoError(t, err)
+	require.Error(t, VerifyAggregated(aggregated, vk, publicWitnesses, srs))
+
+	// the SRS must be large enough
+	vk, proofs, publicWitnesses = aggregationProofs(t, 5)
+	_, err = Aggregate(vk, proofs, publicWitnesses, srs)
+	require.Error(t, err)
+}
+
+func TestAggregationSerialization(t *testing.T) {
+	srs, err := NewAggregationSRS(4, big.NewInt(42), big.NewInt(43))
+	require.NoError(t, err)
+	require.NoError(t, io.RoundTripCheck(srs, func() any { return new(AggregationSRS) }))
+
+	vk, proofs, publicWitnesses := aggregationProofs(t, 3)
+	aggregated, err := Aggregate(vk, proofs, publicWitnesses, srs)
+	require.NoError(t, err)
+	require.NoError(t, io.RoundTripCheck(aggregated, func() any { return new(AggregatedProof) }))
+}
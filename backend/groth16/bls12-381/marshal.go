@@ -372,3 +372,180 @@ func (pk *ProvingKey) readFrom(r io.Reader, decOptions ...func(*curve.Decoder))
 
 	return n + dec.BytesRead(), nil
 }
+
+// WriteTo writes binary encoding of the AggregatedProof elements to writer
+// points are stored in compressed form, GT elements are not compressed
+// use WriteRawTo(...) to encode the proof without point compression
+func (proof *AggregatedProof) WriteTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the AggregatedProof elements to writer
+// points are stored in uncompressed form
+// use WriteTo(...) to encode the proof with point compression
+func (proof *AggregatedProof) WriteRawTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, true)
+}
+
+// serialization format:
+// uint32(len(Rounds)) | [GT elements] | [G1 and G2 points]
+// see AggregatedProof.gtElements and AggregatedProof.points for the order
+func (proof *AggregatedProof) writeTo(w io.Writer, raw bool) (int64, error) {
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	if err := enc.Encode(uint32(len(proof.Rounds))); err != nil {
+		return enc.BytesWritten(), err
+	}
+	for _, e := range proof.gtElements() {
+		buf := e.Bytes()
+		if err := enc.Encode(&buf); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	for _, p := range proof.points() {
+		if err := enc.Encode(p); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode an AggregatedProof from reader
+// AggregatedProof must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (proof *AggregatedProof) ReadFrom(r io.Reader) (n int64, err error) {
+	dec := curve.NewDecoder(r)
+
+	var nbRounds uint32
+	if err := dec.Decode(&nbRounds); err != nil {
+		return dec.BytesRead(), err
+	}
+	proof.Rounds = make([]AggregationRound, nbRounds)
+	for _, e := range proof.gtElements() {
+		var buf [curve.SizeOfGT]byte
+		if err := dec.Decode(&buf); err != nil {
+			return dec.BytesRead(), err
+		}
+		if err := e.SetBytes(buf[:]); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	for _, p := range proof.points() {
+		if err := dec.Decode(p); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// gtElements returns the GT elements of the proof in serialization order
+func (proof *AggregatedProof) gtElements() []*curve.GT {
+	res := []*curve.GT{
+		&proof.CommitmentAB[0], &proof.CommitmentAB[1],
+		&proof.CommitmentC[0], &proof.CommitmentC[1],
+		&proof.IPAB,
+	}
+	for i := range proof.Rounds {
+		round := &proof.Rounds[i]
+		for l := 0; l < 2; l++ {
+			res = append(res,
+				&round.CommitmentAB[l][0], &round.CommitmentAB[l][1],
+				&round.CommitmentC[l][0], &round.CommitmentC[l][1],
+				&round.IPAB[l],
+			)
+		}
+	}
+	return res
+}
+
+// points returns the G1 and G2 points of the proof in serialization order
+func (proof *AggregatedProof) points() []interface{} {
+	res := []interface{}{&proof.AggC}
+	for i := range proof.Rounds {
+		res = append(res, &proof.Rounds[i].AggC[0], &proof.Rounds[i].AggC[1])
+	}
+	return append(res,
+		&proof.FinalA, &proof.FinalB, &proof.FinalC,
+		&proof.FinalV[0], &proof.FinalV[1],
+		&proof.FinalW[0], &proof.FinalW[1],
+		&proof.OpeningV[0], &proof.OpeningV[1],
+		&proof.OpeningW[0], &proof.OpeningW[1],
+	)
+}
+
+// WriteTo writes binary encoding of the AggregationSRS to writer
+// points are compressed
+// use WriteRawTo(...) to encode the SRS without point compression
+func (srs *AggregationSRS) WriteTo(w io.Writer) (n int64, err error) {
+	return srs.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the AggregationSRS to writer
+// points are not compressed
+// use WriteTo(...) to encode the SRS with point compression
+func (srs *AggregationSRS) WriteRawTo(w io.Writer) (n int64, err error) {
+	return srs.writeTo(w, true)
+}
+
+// serialization format:
+// uint32(len(G1.A)),[G1.A]1 | uint32(len(G1.B)),[G1.B]1 | uint32(len(G2.A)),[G2.A]2 | uint32(len(G2.B)),[G2.B]2
+func (srs *AggregationSRS) writeTo(w io.Writer, raw bool) (int64, error) {
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	toEncode := []interface{}{
+		srs.G1.A,
+		srs.G1.B,
+		srs.G2.A,
+		srs.G2.B,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode an AggregationSRS from reader
+// AggregationSRS must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (srs *AggregationSRS) ReadFrom(r io.Reader) (int64, error) {
+	return srs.readFrom(r)
+}
+
+// UnsafeReadFrom behaves like ReadFrom excepts it doesn't check if the decoded points are on the curve
+// or in the correct subgroup
+func (srs *AggregationSRS) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return srs.readFrom(r, curve.NoSubgroupChecks())
+}
+
+func (srs *AggregationSRS) readFrom(r io.Reader, decOptions ...func(*curve.Decoder)) (int64, error) {
+	dec := curve.NewDecoder(r, decOptions...)
+
+	toDecode := []interface{}{
+		&srs.G1.A,
+		&srs.G1.B,
+		&srs.G2.A,
+		&srs.G2.B,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
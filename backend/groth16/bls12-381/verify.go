@@ -19,7 +19,9 @@ package groth16
 import (
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"math/big"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -30,6 +32,7 @@ import (
 	"github.com/consensys/gnark-crypto/utils"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/constraint"
+	internalutils "github.com/consensys/gnark/internal/utils"
 	"github.com/consensys/gnark/logger"
 )
 
@@ -72,6 +75,130 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		close(chDone)
 	}()
 
+	kSumAff, err := vk.publicInputsCommitment(proof, publicWitness, opt.HashToFieldFn)
+	if err != nil {
+		return err
+	}
+
+	right, err := curve.MillerLoop([]curve.G1Affine{kSumAff}, []curve.G2Affine{vk.G2.gammaNeg})
+	if err != nil {
+		return err
+	}
+
+	// wait for (eKrsδ, eArBs)
+	if err := <-chDone; err != nil {
+		return err
+	}
+
+	right = curve.FinalExponentiation(&right, &doubleML)
+	if !vk.e.Equal(&right) {
+		return errPairingCheckFailed
+	}
+
+	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
+	return nil
+}
+
+// BatchVerify verifies the proofs with given VerifyingKey and public witnesses.
+// publicWitnesses[i] is the public witness of proofs[i].
+//
+// The pairing checks of the proofs are combined with a random linear
+// combination into a single multi-pairing. If it fails, then the proofs are
+// bisected to find the invalid ones, which are reported in a
+// [backend.BatchVerifyError].
+func BatchVerify(proofs []*Proof, vk *VerifyingKey, publicWitnesses []fr.Vector, opts ...backend.VerifierOption) error {
+	if len(proofs) != len(publicWitnesses) {
+		return fmt.Errorf("number of proofs (%d) and public witnesses (%d) mismatch", len(proofs), len(publicWitnesses))
+	}
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new verifier config: %w", err)
+	}
+	if opt.HashToFieldFn == nil {
+		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
+	}
+	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Int("nbProofs", len(proofs)).Logger()
+	start := time.Now()
+
+	nbPublicVars := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
+
+	// the pairing check of the i-th proof is
+	// e(Arᵢ, Bsᵢ) * e(kSumᵢ, -[γ]2) * e(Krsᵢ, -[δ]2) == e(α, β)
+	// we raise it to a random power ρᵢ and store ρᵢArᵢ, ρᵢkSumᵢ and ρᵢKrsᵢ.
+	errs := make([]error, len(proofs))
+	rho := make([]fr.Element, len(proofs))
+	ar := make([]curve.G1Affine, len(proofs))
+	kSum := make([]curve.G1Affine, len(proofs))
+	krs := make([]curve.G1Affine, len(proofs))
+	indexes := make([]int, 0, len(proofs))
+	for i := range proofs {
+		if len(publicWitnesses[i]) != nbPublicVars-1 {
+			errs[i] = fmt.Errorf("invalid witness size, got %d, expected %d (public - ONE_WIRE)", len(publicWitnesses[i]), len(vk.G1.K)-1)
+			continue
+		}
+		if !proofs[i].isValid() {
+			errs[i] = errCorrectSubgroupCheckFailed
+			continue
+		}
+		if kSum[i], err = vk.publicInputsCommitment(proofs[i], publicWitnesses[i], opt.HashToFieldFn); err != nil {
+			errs[i] = err
+			continue
+		}
+		if _, err = rho[i].SetRandom(); err != nil {
+			return err
+		}
+		var rhoBigInt big.Int
+		rho[i].BigInt(&rhoBigInt)
+		ar[i].ScalarMultiplication(&proofs[i].Ar, &rhoBigInt)
+		kSum[i].ScalarMultiplication(&kSum[i], &rhoBigInt)
+		krs[i].ScalarMultiplication(&proofs[i].Krs, &rhoBigInt)
+		indexes = append(indexes, i)
+	}
+
+	// check Πᵢe(ρᵢArᵢ, Bsᵢ) * e(ΣᵢρᵢkSumᵢ, -[γ]2) * e(ΣᵢρᵢKrsᵢ, -[δ]2) == e(α, β)^(Σᵢρᵢ)
+	check := func(indexes []int) error {
+		P := make([]curve.G1Affine, 0, len(indexes)+2)
+		Q := make([]curve.G2Affine, 0, len(indexes)+2)
+		var kSumFolded, krsFolded curve.G1Jac
+		var rhoSum fr.Element
+		for _, i := range indexes {
+			P = append(P, ar[i])
+			Q = append(Q, proofs[i].Bs)
+			kSumFolded.AddMixed(&kSum[i])
+			krsFolded.AddMixed(&krs[i])
+			rhoSum.Add(&rhoSum, &rho[i])
+		}
+		var kSumFoldedAff, krsFoldedAff curve.G1Affine
+		kSumFoldedAff.FromJacobian(&kSumFolded)
+		krsFoldedAff.FromJacobian(&krsFolded)
+		P = append(P, kSumFoldedAff, krsFoldedAff)
+		Q = append(Q, vk.G2.gammaNeg, vk.G2.deltaNeg)
+
+		ml, err := curve.MillerLoop(P, Q)
+		if err != nil {
+			return err
+		}
+		ml = curve.FinalExponentiation(&ml)
+
+		var rhoSumBigInt big.Int
+		rhoSum.BigInt(&rhoSumBigInt)
+		var expected curve.GT
+		expected.Exp(vk.e, &rhoSumBigInt)
+		if !expected.Equal(&ml) {
+			return errPairingCheckFailed
+		}
+		return nil
+	}
+	internalutils.Bisect(indexes, check, errs)
+
+	log.Debug().Dur("took", time.Since(start)).Msg("batch verifier done")
+	return backend.NewBatchVerifyError(errs)
+}
+
+// publicInputsCommitment checks the proof of knowledge of the Pedersen
+// commitments and returns Σxᵢ.[Kvk(t)ᵢ]1 + Σ[Commitmentᵢ]1, where x is the
+// public witness completed with the hashed commitments.
+func (vk *VerifyingKey) publicInputsCommitment(proof *Proof, publicWitness fr.Vector, hashToField hash.Hash) (curve.G1Affine, error) {
 	maxNbPublicCommitted := 0
 	for _, s := range vk.PublicAndCommitmentCommitted { // iterate over commitments
 		maxNbPublicCommitted = utils.Max(maxNbPublicCommitted, len(s))
@@ -85,12 +212,12 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 			copy(commitmentPrehashSerialized[offset:], publicWitness[vk.PublicAndCommitmentCommitted[i][j]-1].Marshal())
 			offset += fr.Bytes
 		}
-		opt.HashToFieldFn.Write(commitmentPrehashSerialized[:offset])
-		hashBts := opt.HashToFieldFn.Sum(nil)
-		opt.HashToFieldFn.Reset()
+		hashToField.Write(commitmentPrehashSerialized[:offset])
+		hashBts := hashToField.Sum(nil)
+		hashToField.Reset()
 		nbBuf := fr.Bytes
-		if opt.HashToFieldFn.Size() < fr.Bytes {
-			nbBuf = opt.HashToFieldFn.Size()
+		if hashToField.Size() < fr.Bytes {
+			nbBuf = hashToField.Size()
 		}
 		var res fr.Element
 		res.SetBytes(hashBts[:nbBuf])
@@ -98,18 +225,19 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		copy(commitmentsSerialized[i*fr.Bytes:], res.Marshal())
 	}
 
+	var kSumAff curve.G1Affine
 	if folded, err := pedersen.FoldCommitments(proof.Commitments, commitmentsSerialized); err != nil {
-		return err
+		return kSumAff, err
 	} else {
 		if err = vk.CommitmentKey.Verify(folded, proof.CommitmentPok); err != nil {
-			return err
+			return kSumAff, err
 		}
 	}
 
 	// compute e(Σx.[Kvk(t)]1, -[γ]2)
 	var kSum curve.G1Jac
 	if _, err := kSum.MultiExp(vk.G1.K[1:], publicWitness, ecc.MultiExpConfig{}); err != nil {
-		return err
+		return kSumAff, err
 	}
 	kSum.AddMixed(&vk.G1.K[0])
 
@@ -117,26 +245,8 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		kSum.AddMixed(&proof.Commitments[i])
 	}
 
-	var kSumAff curve.G1Affine
 	kSumAff.FromJacobian(&kSum)
-
-	right, err := curve.MillerLoop([]curve.G1Affine{kSumAff}, []curve.G2Affine{vk.G2.gammaNeg})
-	if err != nil {
-		return err
-	}
-
-	// wait for (eKrsδ, eArBs)
-	if err := <-chDone; err != nil {
-		return err
-	}
-
-	right = curve.FinalExponentiation(&right, &doubleML)
-	if !vk.e.Equal(&right) {
-		return errPairingCheckFailed
-	}
-
-	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
-	return nil
+	return kSumAff, nil
 }
 
 // ExportSolidity not implemented for BLS12-381
@@ -20,6 +20,7 @@
 package groth16
 
 import (
+	"errors"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -109,6 +110,29 @@ type VerifyingKey interface {
 	IsDifferent(interface{}) bool
 }
 
+// AggregatedProof represents an aggregation of Groth16 proofs generated by groth16.Aggregate
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type AggregatedProof interface {
+	groth16Object
+}
+
+// AggregationSRS represents the structured reference string used by
+// groth16.Aggregate and groth16.VerifyAggregated
+//
+// In production, the SRS must be assembled from the outputs of two independent
+// powers-of-tau ceremonies over the same curve, so that neither secret is
+// known to anyone (for BLS12-381, SnarkPack uses the Filecoin and Zcash
+// ceremonies). It is then loaded with ReadFrom on an instance returned by
+// NewEmptyAggregationSRS. The per-curve NewAggregationSRS constructors derive
+// the SRS from known secrets and are only suitable for testing.
+//
+// it's underlying implementation is strongly typed with the curve (see gnark/internal/backend)
+type AggregationSRS interface {
+	groth16Object
+	gnarkio.UnsafeReaderFrom
+}
+
 // Verify runs the groth16.Verify algorithm on provided proof with given witness
 func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ...backend.VerifierOption) error {
 
@@ -160,6 +184,148 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
+// BatchVerify verifies Groth16 proofs generated for the same VerifyingKey.
+// publicWitnesses[i] is the public witness of proofs[i].
+//
+// The proofs are combined with a random linear combination into a single
+// multi-pairing check. If some proofs are invalid, then the returned error is a
+// [backend.BatchVerifyError] which reports them.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitnesses []witness.Witness, opts ...backend.VerifierOption) error {
+	switch _vk := vk.(type) {
+	case *groth16_bls12377.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bls12377.BatchVerify, opts...)
+	case *groth16_bls12381.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bls12381.BatchVerify, opts...)
+	case *groth16_bn254.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bn254.BatchVerify, opts...)
+	case *groth16_bw6761.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bw6761.BatchVerify, opts...)
+	case *groth16_bls24317.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bls24317.BatchVerify, opts...)
+	case *groth16_bls24315.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bls24315.BatchVerify, opts...)
+	case *groth16_bw6633.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, groth16_bw6633.BatchVerify, opts...)
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+func batchVerify[P Proof, V VerifyingKey, W any](proofs []Proof, vk V, publicWitnesses []witness.Witness, verify func([]P, V, []W, ...backend.VerifierOption) error, opts ...backend.VerifierOption) error {
+	_proofs, err := castProofs[P](proofs)
+	if err != nil {
+		return err
+	}
+	w, err := castWitnesses[W](publicWitnesses)
+	if err != nil {
+		return err
+	}
+	return verify(_proofs, vk, w, opts...)
+}
+
+// Aggregate aggregates Groth16 proofs generated for the same VerifyingKey into
+// a single proof of logarithmic size (SnarkPack).
+//
+// It is implemented for BN254 and BLS12-381 and will return an error with other
+// curves. Proofs of circuits with commitments are not supported.
+func Aggregate(vk VerifyingKey, proofs []Proof, publicWitnesses []witness.Witness, srs AggregationSRS, opts ...backend.ProverOption) (AggregatedProof, error) {
+	switch _vk := vk.(type) {
+	case *groth16_bls12381.VerifyingKey:
+		_proofs, err := castProofs[*groth16_bls12381.Proof](proofs)
+		if err != nil {
+			return nil, err
+		}
+		w, err := castWitnesses[fr_bls12381.Vector](publicWitnesses)
+		if err != nil {
+			return nil, err
+		}
+		_srs, ok := srs.(*groth16_bls12381.AggregationSRS)
+		if !ok {
+			return nil, errAggregationSRSCurve
+		}
+		return groth16_bls12381.Aggregate(_vk, _proofs, w, _srs, opts...)
+	case *groth16_bn254.VerifyingKey:
+		_proofs, err := castProofs[*groth16_bn254.Proof](proofs)
+		if err != nil {
+			return nil, err
+		}
+		w, err := castWitnesses[fr_bn254.Vector](publicWitnesses)
+		if err != nil {
+			return nil, err
+		}
+		_srs, ok := srs.(*groth16_bn254.AggregationSRS)
+		if !ok {
+			return nil, errAggregationSRSCurve
+		}
+		return groth16_bn254.Aggregate(_vk, _proofs, w, _srs, opts...)
+	default:
+		return nil, errors.New("proof aggregation is not supported on this curve")
+	}
+}
+
+// VerifyAggregated verifies a proof generated by groth16.Aggregate with given
+// witnesses of the aggregated proofs
+func VerifyAggregated(proof AggregatedProof, vk VerifyingKey, publicWitnesses []witness.Witness, srs AggregationSRS, opts ...backend.VerifierOption) error {
+	switch _proof := proof.(type) {
+	case *groth16_bls12381.AggregatedProof:
+		w, err := castWitnesses[fr_bls12381.Vector](publicWitnesses)
+		if err != nil {
+			return err
+		}
+		_vk, ok := vk.(*groth16_bls12381.VerifyingKey)
+		if !ok {
+			return errors.New("proof and verifying key are not defined over the same curve")
+		}
+		_srs, ok := srs.(*groth16_bls12381.AggregationSRS)
+		if !ok {
+			return errAggregationSRSCurve
+		}
+		return groth16_bls12381.VerifyAggregated(_proof, _vk, w, _srs, opts...)
+	case *groth16_bn254.AggregatedProof:
+		w, err := castWitnesses[fr_bn254.Vector](publicWitnesses)
+		if err != nil {
+			return err
+		}
+		_vk, ok := vk.(*groth16_bn254.VerifyingKey)
+		if !ok {
+			return errors.New("proof and verifying key are not defined over the same curve")
+		}
+		_srs, ok := srs.(*groth16_bn254.AggregationSRS)
+		if !ok {
+			return errAggregationSRSCurve
+		}
+		return groth16_bn254.VerifyAggregated(_proof, _vk, w, _srs, opts...)
+	default:
+		return errors.New("proof aggregation is not supported on this curve")
+	}
+}
+
+var errAggregationSRSCurve = errors.New("aggregation SRS is not defined over the same curve")
+
+func castProofs[T Proof](proofs []Proof) ([]T, error) {
+	res := make([]T, len(proofs))
+	for i := range proofs {
+		p, ok := proofs[i].(T)
+		if !ok {
+			return nil, errors.New("proofs are not defined over the same curve")
+		}
+		res[i] = p
+	}
+	return res, nil
+}
+
+func castWitnesses[T any](publicWitnesses []witness.Witness) ([]T, error) {
+	res := make([]T, len(publicWitnesses))
+	for i := range publicWitnesses {
+		w, ok := publicWitnesses[i].Vector().(T)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		res[i] = w
+	}
+	return res, nil
+}
+
 // Prove runs the groth16.Prove algorithm.
 //
 // if the force flag is set:
@@ -408,6 +574,39 @@ func NewProof(curveID ecc.ID) Proof {
 	return proof
 }
 
+// NewAggregatedProof instantiates a curve-typed AggregatedProof and returns an interface
+// This function exists for serialization purposes
+func NewAggregatedProof(curveID ecc.ID) AggregatedProof {
+	var proof AggregatedProof
+	switch curveID {
+	case ecc.BN254:
+		proof = &groth16_bn254.AggregatedProof{}
+	case ecc.BLS12_381:
+		proof = &groth16_bls12381.AggregatedProof{}
+	default:
+		panic("not implemented")
+	}
+
+	return proof
+}
+
+// NewEmptyAggregationSRS instantiates an empty curve-typed AggregationSRS and
+// returns an interface. This function exists for deserialization purposes, see
+// [AggregationSRS] for the expected origin of the SRS.
+func NewEmptyAggregationSRS(curveID ecc.ID) AggregationSRS {
+	var srs AggregationSRS
+	switch curveID {
+	case ecc.BN254:
+		srs = &groth16_bn254.AggregationSRS{}
+	case ecc.BLS12_381:
+		srs = &groth16_bls12381.AggregationSRS{}
+	default:
+		panic("not implemented")
+	}
+
+	return srs
+}
+
 // NewCS instantiate a concrete curved-typed R1CS and return a R1CS interface
 // This method exists for (de)serialization purposes
 func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
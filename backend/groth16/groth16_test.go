@@ -1,6 +1,8 @@
 package groth16_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -9,6 +11,9 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
@@ -51,6 +56,98 @@ func TestCustomHashToField(t *testing.T) {
 	}
 }
 
+func TestAggregate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbProofs = 3
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		assert.Run(func(assert *test.Assert) {
+			var srs groth16.AggregationSRS
+			var err error
+			switch curve {
+			case ecc.BN254:
+				srs, err = groth16_bn254.NewAggregationSRS(nbProofs, big.NewInt(42), big.NewInt(43))
+			case ecc.BLS12_381:
+				srs, err = groth16_bls12381.NewAggregationSRS(nbProofs, big.NewInt(42), big.NewInt(43))
+			}
+			assert.NoError(err)
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+
+			proofs := make([]groth16.Proof, nbProofs)
+			publicWitnesses := make([]witness.Witness, nbProofs)
+			for i := range proofs {
+				assignment := &refCircuit{X: i + 2, Y: (i + 2) * (i + 2) * (i + 2) * (i + 2)}
+				w, err := frontend.NewWitness(assignment, curve.ScalarField())
+				assert.NoError(err)
+				proofs[i], err = groth16.Prove(ccs, pk, w)
+				assert.NoError(err)
+				publicWitnesses[i], err = w.Public()
+				assert.NoError(err)
+			}
+
+			aggregated, err := groth16.Aggregate(vk, proofs, publicWitnesses, srs)
+			assert.NoError(err)
+			assert.NoError(groth16.VerifyAggregated(aggregated, vk, publicWitnesses, srs))
+			assert.Error(groth16.VerifyAggregated(aggregated, vk, publicWitnesses[1:], srs))
+
+			// the SRS can be loaded from its serialized form
+			var buf bytes.Buffer
+			_, err = srs.WriteTo(&buf)
+			assert.NoError(err)
+			loaded := groth16.NewEmptyAggregationSRS(curve)
+			_, err = loaded.ReadFrom(&buf)
+			assert.NoError(err)
+			assert.NoError(groth16.VerifyAggregated(aggregated, vk, publicWitnesses, loaded))
+
+			// mismatching curves must be reported as errors
+			other := ecc.BN254
+			if curve == ecc.BN254 {
+				other = ecc.BLS12_381
+			}
+			_, err = groth16.Aggregate(vk, proofs, publicWitnesses, groth16.NewEmptyAggregationSRS(other))
+			assert.Error(err)
+			assert.Error(groth16.VerifyAggregated(aggregated, vk, publicWitnesses, groth16.NewEmptyAggregationSRS(other)))
+			assert.Error(groth16.VerifyAggregated(aggregated, groth16.NewVerifyingKey(other), publicWitnesses, srs))
+		}, curve.String())
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbProofs = 4
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &refCircuit{nbConstraints: 2})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+
+			proofs := make([]groth16.Proof, nbProofs)
+			publicWitnesses := make([]witness.Witness, nbProofs)
+			for i := range proofs {
+				assignment := &refCircuit{X: i + 2, Y: (i + 2) * (i + 2) * (i + 2) * (i + 2)}
+				w, err := frontend.NewWitness(assignment, curve.ScalarField())
+				assert.NoError(err)
+				proofs[i], err = groth16.Prove(ccs, pk, w)
+				assert.NoError(err)
+				publicWitnesses[i], err = w.Public()
+				assert.NoError(err)
+			}
+			assert.NoError(groth16.BatchVerify(proofs, vk, publicWitnesses))
+
+			// the invalid proofs must be reported
+			publicWitnesses[1], publicWitnesses[3] = publicWitnesses[3], publicWitnesses[1]
+			err = groth16.BatchVerify(proofs, vk, publicWitnesses)
+			var batchErr *backend.BatchVerifyError
+			assert.True(errors.As(err, &batchErr))
+			assert.Equal([]int{1, 3}, batchErr.Indexes)
+		}, curve.String())
+	}
+}
+
 //--------------------//
 //     benches		  //
 //--------------------//
@@ -20,6 +20,7 @@
 package plonk
 
 import (
+	"errors"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -216,6 +217,62 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
+// BatchVerify verifies PLONK proofs generated for the same VerifyingKey.
+// publicWitnesses[i] is the public witness of proofs[i].
+//
+// The KZG openings of all the proofs are folded into a single pairing check. If
+// some proofs are invalid, then the returned error is a
+// [backend.BatchVerifyError] which reports them.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitnesses []witness.Witness, opts ...backend.VerifierOption) error {
+
+	switch _vk := vk.(type) {
+
+	case *plonk_bn254.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bn254.BatchVerify, opts...)
+
+	case *plonk_bls12381.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bls12381.BatchVerify, opts...)
+
+	case *plonk_bls12377.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bls12377.BatchVerify, opts...)
+
+	case *plonk_bw6761.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bw6761.BatchVerify, opts...)
+
+	case *plonk_bw6633.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bw6633.BatchVerify, opts...)
+
+	case *plonk_bls24317.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bls24317.BatchVerify, opts...)
+
+	case *plonk_bls24315.VerifyingKey:
+		return batchVerify(proofs, _vk, publicWitnesses, plonk_bls24315.BatchVerify, opts...)
+
+	default:
+		panic("unrecognized verifying key type")
+	}
+}
+
+func batchVerify[P Proof, V VerifyingKey, W any](proofs []Proof, vk V, publicWitnesses []witness.Witness, verify func([]P, V, []W, ...backend.VerifierOption) error, opts ...backend.VerifierOption) error {
+	_proofs := make([]P, len(proofs))
+	for i := range proofs {
+		p, ok := proofs[i].(P)
+		if !ok {
+			return errors.New("proofs are not defined over the same curve")
+		}
+		_proofs[i] = p
+	}
+	w := make([]W, len(publicWitnesses))
+	for i := range publicWitnesses {
+		v, ok := publicWitnesses[i].Vector().(W)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		w[i] = v
+	}
+	return verify(_proofs, vk, w, opts...)
+}
+
 // NewCS instantiate a concrete curved-typed SparseR1CS and return a ConstraintSystem interface
 // This method exists for (de)serialization purposes
 func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
@@ -2,6 +2,7 @@ package plonk_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/consensys/gnark-crypto/kzg"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
@@ -68,6 +70,42 @@ func TestProver(t *testing.T) {
 	}
 }
 
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbProofs = 4
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: 2})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+
+			proofs := make([]plonk.Proof, nbProofs)
+			publicWitnesses := make([]witness.Witness, nbProofs)
+			for i := range proofs {
+				assignment := &refCircuit{X: i + 2, Y: (i + 2) * (i + 2) * (i + 2) * (i + 2)}
+				w, err := frontend.NewWitness(assignment, curve.ScalarField())
+				assert.NoError(err)
+				proofs[i], err = plonk.Prove(ccs, pk, w)
+				assert.NoError(err)
+				publicWitnesses[i], err = w.Public()
+				assert.NoError(err)
+			}
+			assert.NoError(plonk.BatchVerify(proofs, vk, publicWitnesses))
+
+			// the invalid proofs must be reported
+			publicWitnesses[1], publicWitnesses[3] = publicWitnesses[3], publicWitnesses[1]
+			err = plonk.BatchVerify(proofs, vk, publicWitnesses)
+			var batchErr *backend.BatchVerifyError
+			assert.True(errors.As(err, &batchErr))
+			assert.Equal([]int{1, 3}, batchErr.Indexes)
+		}, curve.String())
+	}
+}
+
 func TestCustomHashToField(t *testing.T) {
 	assert := test.NewAssert(t)
 	assignment := &commitmentCircuit{X: 1}
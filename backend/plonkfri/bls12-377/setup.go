@@ -17,10 +17,11 @@
 package plonkfri
 
 import (
-	"crypto/sha256"
+	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fri"
+	"github.com/consensys/gnark/backend"
 	cs "github.com/consensys/gnark/constraint/bls12-377"
 )
 
@@ -98,7 +99,12 @@ type VerifyingKey struct {
 }
 
 // Setup sets proving and verifying keys
-func Setup(spr *cs.SparseR1CS) (*ProvingKey, *VerifyingKey, error) {
+func Setup(spr *cs.SparseR1CS, opts ...backend.SetupOption) (*ProvingKey, *VerifyingKey, error) {
+
+	opt, err := backend.NewSetupConfig(opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create backend config: %w", err)
+	}
 
 	var pk ProvingKey
 	var vk VerifyingKey
@@ -130,7 +136,7 @@ func Setup(spr *cs.SparseR1CS) (*ProvingKey, *VerifyingKey, error) {
 	// IOP schemess
 	// The +2 is to handle the blinding.
 	sizeIopp := pk.Domain[0].Cardinality + 2
-	vk.Iopp = fri.RADIX_2_FRI.New(sizeIopp, sha256.New())
+	vk.Iopp = fri.RADIX_2_FRI.New(sizeIopp, opt.IOPPHash)
 	// only there to access the group used in FRI...
 	rho := uint64(fri.GetRho())
 	// we multiply by 2 because the IOP is created with size pk.Domain[0].Cardinality + 2 (because
@@ -190,7 +196,6 @@ func Setup(spr *cs.SparseR1CS) (*ProvingKey, *VerifyingKey, error) {
 	copy(pk.CQr, pk.EvaluationQrDomainBigBitReversed)
 	copy(pk.CQm, pk.EvaluationQmDomainBigBitReversed)
 	copy(pk.CQo, pk.EvaluationQoDomainBigBitReversed)
-	var err error
 	vk.Qpp[0], err = vk.Iopp.BuildProofOfProximity(pk.CQl)
 	if err != nil {
 		return &pk, &vk, err
@@ -41,9 +41,13 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	for i := 0; i < len(publicWitness); i++ {
 		copy(dataFiatShamir[i][:], publicWitness[i].Marshal())
 	}
-	copy(dataFiatShamir[len(publicWitness)][:], proof.LROpp[0].ID)
-	copy(dataFiatShamir[len(publicWitness)+1][:], proof.LROpp[1].ID)
-	copy(dataFiatShamir[len(publicWitness)+2][:], proof.LROpp[2].ID)
+	for i := range proof.LROpp {
+		root, err := merkleRoot(proof.LROpp[i])
+		if err != nil {
+			return err
+		}
+		copy(dataFiatShamir[len(publicWitness)+i][:], root)
+	}
 
 	beta, err := deriveRandomnessFixedSize(fs, "gamma", dataFiatShamir...)
 	if err != nil {
@@ -55,7 +59,11 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		return err
 	}
 
-	alpha, err := deriveRandomness(fs, "alpha", proof.Zpp.ID)
+	zRoot, err := merkleRoot(proof.Zpp)
+	if err != nil {
+		return err
+	}
+	alpha, err := deriveRandomness(fs, "alpha", zRoot)
 	if err != nil {
 		return err
 	}
@@ -67,7 +75,13 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	friSize := 2 * rho * vk.Size
 	var bFriSize big.Int
 	bFriSize.SetInt64(int64(friSize))
-	frOpeningPosition, err := deriveRandomness(fs, "zeta", proof.Hpp[0].ID, proof.Hpp[1].ID, proof.Hpp[2].ID)
+	var hRoots [3][]byte
+	for i := range proof.Hpp {
+		if hRoots[i], err = merkleRoot(proof.Hpp[i]); err != nil {
+			return err
+		}
+	}
+	frOpeningPosition, err := deriveRandomness(fs, "zeta", hRoots[0], hRoots[1], hRoots[2])
 	if err != nil {
 		return err
 	}
@@ -396,3 +410,13 @@ func completeQk(publicWitness []fr.Element, vk *VerifyingKey, zeta fr.Element) f
 
 	return res
 }
+
+// merkleRoot returns the Merkle root of the first interaction of the first
+// round of pp, which is the commitment to the evaluations of the polynomial.
+// It returns an error if pp has no round or no interaction.
+func merkleRoot(pp fri.ProofOfProximity) ([]byte, error) {
+	if len(pp.Rounds) == 0 || len(pp.Rounds[0].Interactions) == 0 {
+		return nil, errors.New("invalid proof of proximity: missing first interaction")
+	}
+	return pp.Rounds[0].Interactions[0][0].MerkleRoot, nil
+}
@@ -120,9 +120,13 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	for i := 0; i < len(spr.Public); i++ {
 		copy(dataFiatShamir[i][:], fw[i].Marshal())
 	}
-	copy(dataFiatShamir[len(spr.Public)][:], proof.LROpp[0].ID)
-	copy(dataFiatShamir[len(spr.Public)+1][:], proof.LROpp[1].ID)
-	copy(dataFiatShamir[len(spr.Public)+2][:], proof.LROpp[2].ID)
+	for i := range proof.LROpp {
+		root, err := merkleRoot(proof.LROpp[i])
+		if err != nil {
+			return nil, err
+		}
+		copy(dataFiatShamir[len(spr.Public)+i][:], root)
+	}
 
 	beta, err := deriveRandomnessFixedSize(fs, "gamma", dataFiatShamir...)
 	if err != nil {
@@ -154,7 +158,11 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 
 	// 5 - compute H
 	// var alpha fr.Element
-	alpha, err := deriveRandomness(fs, "alpha", proof.Zpp.ID)
+	zRoot, err := merkleRoot(proof.Zpp)
+	if err != nil {
+		return nil, err
+	}
+	alpha, err := deriveRandomness(fs, "alpha", zRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +226,13 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	friSize := 2 * rho * pk.Vk.Size
 	var bFriSize big.Int
 	bFriSize.SetInt64(int64(friSize))
-	frOpeningPosition, err := deriveRandomness(fs, "zeta", proof.Hpp[0].ID, proof.Hpp[1].ID, proof.Hpp[2].ID)
+	var hRoots [3][]byte
+	for i := range proof.Hpp {
+		if hRoots[i], err = merkleRoot(proof.Hpp[i]); err != nil {
+			return nil, err
+		}
+	}
+	frOpeningPosition, err := deriveRandomness(fs, "zeta", hRoots[0], hRoots[1], hRoots[2])
 	if err != nil {
 		return nil, err
 	}
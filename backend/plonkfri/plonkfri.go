@@ -74,23 +74,23 @@ type VerifyingKey interface {
 }
 
 // Setup prepares the public data associated to a circuit + public inputs.
-func Setup(ccs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
+func Setup(ccs constraint.ConstraintSystem, opts ...backend.SetupOption) (ProvingKey, VerifyingKey, error) {
 
 	switch tccs := ccs.(type) {
 	case *cs_bn254.SparseR1CS:
-		return plonk_bn254.Setup(tccs)
+		return plonk_bn254.Setup(tccs, opts...)
 	case *cs_bls12381.SparseR1CS:
-		return plonk_bls12381.Setup(tccs)
+		return plonk_bls12381.Setup(tccs, opts...)
 	case *cs_bls12377.SparseR1CS:
-		return plonk_bls12377.Setup(tccs)
+		return plonk_bls12377.Setup(tccs, opts...)
 	case *cs_bw6761.SparseR1CS:
-		return plonk_bw6761.Setup(tccs)
+		return plonk_bw6761.Setup(tccs, opts...)
 	case *cs_bls24315.SparseR1CS:
-		return plonk_bls24315.Setup(tccs)
+		return plonk_bls24315.Setup(tccs, opts...)
 	case *cs_bw6633.SparseR1CS:
-		return plonk_bw6633.Setup(tccs)
+		return plonk_bw6633.Setup(tccs, opts...)
 	case *cs_bls24317.SparseR1CS:
-		return plonk_bls24317.Setup(tccs)
+		return plonk_bls24317.Setup(tccs, opts...)
 	default:
 		panic("unrecognized SparseR1CS curve type")
 	}
@@ -175,6 +175,17 @@ func main() {
 				panic(err) // TODO handle
 			}
 
+			// groth16 aggregation (SnarkPack)
+			if d.Curve == "BN254" || d.Curve == "BLS12-381" {
+				entries = []bavard.Entry{
+					{File: filepath.Join(groth16Dir, "aggregate.go"), Templates: []string{"groth16/groth16.aggregate.go.tmpl", importCurve}},
+					{File: filepath.Join(groth16Dir, "aggregate_test.go"), Templates: []string{"groth16/tests/groth16.aggregate.go.tmpl", importCurve}},
+				}
+				if err := bgen.Generate(d, "groth16", "./template/zkpschemes/", entries...); err != nil {
+					panic(err) // TODO handle
+				}
+			}
+
 			// groth16 mpcsetup
 			entries = []bavard.Entry{
 				{File: filepath.Join(groth16MpcSetupDir, "lagrange.go"), Templates: []string{"groth16/mpcsetup/lagrange.go.tmpl", importCurve}},
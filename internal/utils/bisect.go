@@ -0,0 +1,24 @@
+package utils
+
+// Bisect runs check on indexes. If it fails, then it runs recursively on both
+// halves of indexes until the failing single indexes are found, and stores the
+// corresponding error of check in errs.
+//
+// It is used by batch verifiers to find the invalid proofs when the batched
+// check fails.
+func Bisect(indexes []int, check func([]int) error, errs []error) {
+	if len(indexes) == 0 {
+		return
+	}
+	err := check(indexes)
+	if err == nil {
+		return
+	}
+	if len(indexes) == 1 {
+		errs[indexes[0]] = err
+		return
+	}
+	m := len(indexes) / 2
+	Bisect(indexes[:m], check, errs)
+	Bisect(indexes[m:], check, errs)
+}
@@ -0,0 +1,121 @@
+// Package mpt provides ZKP-circuit functions to verify Ethereum
+// Merkle-Patricia trie proofs.
+//
+// The proofs are in the format returned by the eth_getProof RPC method: a list
+// of RLP-encoded trie nodes from the root node to the node where the lookup
+// terminates. The trie path is the 32-byte key, which for the state and
+// storage tries is the Keccak-256 hash of the account address or storage slot
+// respectively. The caller is responsible for computing the key.
+//
+// The number of nodes and the length of the nodes are variable, but bounded at
+// compile time, see [PlaceholderProof]. The RLP-encoded nodes are decoded
+// in-circuit using the [github.com/consensys/gnark/std/encoding/rlp] package,
+// supporting branch, extension and leaf nodes.
+//
+// The child nodes are referenced in the parent node by their hash, except when
+// the RLP-encoding of the child node is shorter than 32 bytes, in which case
+// the child node is embedded in the parent. This happens in the storage trie
+// for slots with very short values deep in the trie. The proof must contain
+// all the nodes on the path including the embedded ones. NB! eth_getProof
+// does not return the embedded nodes separately, they have to be extracted
+// from the parent node.
+package mpt
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// KeyLength is the length of the trie key in bytes.
+	KeyLength = 32
+	// HashLength is the length of the node hashes in bytes.
+	HashLength = 32
+	// MaxBranchNodeLength is the maximum length of an RLP-encoded branch node
+	// where all children are referenced by their hash. It can be used as the
+	// maximum node length when compiling the circuit.
+	MaxBranchNodeLength = 532
+)
+
+// Proof is a Merkle-Patricia trie proof.
+type Proof struct {
+	// Nodes are the RLP-encoded trie nodes starting from the root node, padded
+	// with zeros to the maximum node length. The nodes after Depth are not
+	// checked against the trie, but they must be valid nodes. [ValueOfProof]
+	// repeats the last node.
+	Nodes [][]uints.U8
+	// NodeLengths are the lengths of the nodes without the padding.
+	NodeLengths []frontend.Variable
+	// Depth is the number of nodes in the proof.
+	Depth frontend.Variable
+}
+
+// PlaceholderProof returns a proof placeholder for compiling the circuit.
+// The proof can contain at most maxDepth nodes, each at most maxNodeLen bytes
+// long.
+func PlaceholderProof(maxDepth, maxNodeLen int) Proof {
+	p := Proof{
+		Nodes:       make([][]uints.U8, maxDepth),
+		NodeLengths: make([]frontend.Variable, maxDepth),
+	}
+	for i := range p.Nodes {
+		p.Nodes[i] = make([]uints.U8, maxNodeLen)
+	}
+	return p
+}
+
+// ValueOfProof returns the witness assignment for the proof consisting of the
+// RLP-encoded nodes. The proof is padded to the bounds maxDepth and
+// maxNodeLen, which must match the bounds in [PlaceholderProof].
+func ValueOfProof(nodes [][]byte, maxDepth, maxNodeLen int) (Proof, error) {
+	if len(nodes) == 0 {
+		return Proof{}, fmt.Errorf("empty proof")
+	}
+	if len(nodes) > maxDepth {
+		return Proof{}, fmt.Errorf("proof has %d nodes, expected at most %d", len(nodes), maxDepth)
+	}
+	p := Proof{
+		Nodes:       make([][]uints.U8, maxDepth),
+		NodeLengths: make([]frontend.Variable, maxDepth),
+		Depth:       len(nodes),
+	}
+	for i := range p.Nodes {
+		node := nodes[len(nodes)-1]
+		if i < len(nodes) {
+			node = nodes[i]
+		}
+		if len(node) > maxNodeLen {
+			return Proof{}, fmt.Errorf("node %d has length %d, expected at most %d", i, len(node), maxNodeLen)
+		}
+		buf := make([]byte, maxNodeLen)
+		copy(buf, node)
+		p.Nodes[i] = uints.NewU8Array(buf)
+		p.NodeLengths[i] = len(node)
+	}
+	return p, nil
+}
+
+// VerifyInclusion asserts that the value stored at key in the trie defined by
+// root is value. The value is the payload of the RLP-string in the leaf, i.e.
+// the RLP-encoded account in the state trie and the RLP-encoded slot value in
+// the storage trie. The value is given padded to the maximum length, where
+// valueLen is the actual length.
+func (p *Proof) VerifyInclusion(api frontend.API, root, key, value []uints.U8, valueLen frontend.Variable) {
+	v, err := newVerifier(api, p)
+	if err != nil {
+		panic(err)
+	}
+	v.verify(root, key, value, valueLen, true)
+}
+
+// VerifyExclusion asserts that there is no value stored at key in the trie
+// defined by root.
+func (p *Proof) VerifyExclusion(api frontend.API, root, key []uints.U8) {
+	v, err := newVerifier(api, p)
+	if err != nil {
+		panic(err)
+	}
+	v.verify(root, key, nil, 0, false)
+}
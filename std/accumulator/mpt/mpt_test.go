@@ -0,0 +1,491 @@
+package mpt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/sha3"
+)
+
+// the following is a minimal implementation of the Ethereum Merkle-Patricia
+// trie for generating test proofs.
+
+func rlpHeader(length int, offset byte) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+	var lb []byte
+	for l := length; l > 0; l >>= 8 {
+		lb = append([]byte{byte(l)}, lb...)
+	}
+	return append([]byte{offset + 55 + byte(len(lb))}, lb...)
+}
+
+func rlpString(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(rlpHeader(len(b), 0x80), b...)
+}
+
+func rlpList(items ...[]byte) []byte {
+	payload := bytes.Join(items, nil)
+	return append(rlpHeader(len(payload), 0xc0), payload...)
+}
+
+func keccak(b []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+func toNibbles(key []byte) []byte {
+	res := make([]byte, 2*len(key))
+	for i := range key {
+		res[2*i] = key[i] >> 4
+		res[2*i+1] = key[i] & 0x0f
+	}
+	return res
+}
+
+func compactPath(nibbles []byte, isLeaf bool) []byte {
+	var flag byte
+	if isLeaf {
+		flag = 2
+	}
+	var res []byte
+	if len(nibbles)%2 == 1 {
+		res = append(res, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		res = append(res, flag<<4)
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		res = append(res, nibbles[i]<<4|nibbles[i+1])
+	}
+	return res
+}
+
+type testEntry struct {
+	nibbles []byte
+	value   []byte
+}
+
+type testTrie struct {
+	entries []testEntry
+}
+
+func newTestTrie(kvs map[string][]byte) *testTrie {
+	t := &testTrie{}
+	for k, v := range kvs {
+		t.entries = append(t.entries, testEntry{nibbles: toNibbles([]byte(k)), value: v})
+	}
+	sort.Slice(t.entries, func(i, j int) bool { return bytes.Compare(t.entries[i].nibbles, t.entries[j].nibbles) < 0 })
+	return t
+}
+
+// encode returns the RLP encoding of the node at depth containing the
+// entries.
+func encode(entries []testEntry, depth int) []byte {
+	if len(entries) == 1 {
+		return rlpList(rlpString(compactPath(entries[0].nibbles[depth:], true)), rlpString(entries[0].value))
+	}
+	prefix := 0
+	for ; ; prefix++ {
+		nibble := entries[0].nibbles[depth+prefix]
+		same := true
+		for _, e := range entries {
+			same = same && e.nibbles[depth+prefix] == nibble
+		}
+		if !same {
+			break
+		}
+	}
+	if prefix > 0 {
+		return rlpList(rlpString(compactPath(entries[0].nibbles[depth:depth+prefix], false)), reference(encode(entries, depth+prefix)))
+	}
+	items := make([][]byte, 17)
+	for i := range items {
+		items[i] = rlpString(nil)
+		if children := childEntries(entries, depth, byte(i)); len(children) > 0 {
+			items[i] = reference(encode(children, depth+1))
+		}
+	}
+	return rlpList(items...)
+}
+
+func reference(node []byte) []byte {
+	if len(node) < 32 {
+		return node
+	}
+	return rlpString(keccak(node))
+}
+
+func childEntries(entries []testEntry, depth int, nibble byte) []testEntry {
+	var res []testEntry
+	for _, e := range entries {
+		if e.nibbles[depth] == nibble {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func (t *testTrie) root() []byte {
+	return keccak(encode(t.entries, 0))
+}
+
+// prove returns the nodes on the path of the key.
+func (t *testTrie) prove(key []byte) [][]byte {
+	nibbles := toNibbles(key)
+	entries := t.entries
+	depth := 0
+	var proof [][]byte
+	for {
+		proof = append(proof, encode(entries, depth))
+		if len(entries) == 1 {
+			return proof
+		}
+		prefix := 0
+		for ; ; prefix++ {
+			nibble := entries[0].nibbles[depth+prefix]
+			same := true
+			for _, e := range entries {
+				same = same && e.nibbles[depth+prefix] == nibble
+			}
+			if !same {
+				break
+			}
+		}
+		if prefix > 0 {
+			if !bytes.Equal(nibbles[depth:depth+prefix], entries[0].nibbles[depth:depth+prefix]) {
+				return proof
+			}
+			depth += prefix
+			proof = append(proof, encode(entries, depth))
+		}
+		entries = childEntries(entries, depth, nibbles[depth])
+		if len(entries) == 0 {
+			return proof
+		}
+		depth++
+	}
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+const (
+	testMaxDepth    = 6
+	testMaxValueLen = 80
+)
+
+type inclusionCircuit struct {
+	Proof    Proof
+	Root     [HashLength]uints.U8
+	Key      [KeyLength]uints.U8
+	Value    []uints.U8
+	ValueLen frontend.Variable
+}
+
+func (c *inclusionCircuit) Define(api frontend.API) error {
+	c.Proof.VerifyInclusion(api, c.Root[:], c.Key[:], c.Value[:], c.ValueLen)
+	return nil
+}
+
+type exclusionCircuit struct {
+	Proof Proof
+	Root  [HashLength]uints.U8
+	Key   [KeyLength]uints.U8
+}
+
+func (c *exclusionCircuit) Define(api frontend.API) error {
+	c.Proof.VerifyExclusion(api, c.Root[:], c.Key[:])
+	return nil
+}
+
+func newTestData(t *testing.T) (*testTrie, [][]byte) {
+	kvs := make(map[string][]byte)
+	var keys [][]byte
+	for i := 0; i < 40; i++ {
+		key := randomBytes(KeyLength)
+		keys = append(keys, key)
+		// values both short and long strings
+		kvs[string(key)] = randomBytes(20 + i*60/40)
+	}
+	// keys sharing a long prefix for extension nodes
+	key := append([]byte{}, keys[0]...)
+	key[10] ^= 0x01
+	keys = append(keys, key)
+	kvs[string(key)] = randomBytes(testMaxValueLen)
+	// keys differing in the last nibble with short values for embedded
+	// branch and leaf nodes
+	key = randomBytes(KeyLength)
+	keys = append(keys, key)
+	kvs[string(key)] = randomBytes(3)
+	key = append([]byte{}, key...)
+	key[KeyLength-1] ^= 0x01
+	keys = append(keys, key)
+	kvs[string(key)] = randomBytes(3)
+	return newTestTrie(kvs), keys
+}
+
+func TestInclusion(t *testing.T) {
+	assert := test.NewAssert(t)
+	trie, keys := newTestData(t)
+	root := trie.root()
+	for _, i := range []int{0, 1, 39, 40, 41, 42} {
+		assert.Run(func(assert *test.Assert) {
+			key := keys[i]
+			var value []byte
+			for _, e := range trie.entries {
+				if bytes.Equal(e.nibbles, toNibbles(key)) {
+					value = e.value
+				}
+			}
+			nodes := trie.prove(key)
+			if i >= 41 {
+				// the leaf node is embedded in its parent
+				assert.Less(len(nodes[len(nodes)-1]), HashLength)
+			}
+			proof, err := ValueOfProof(nodes, testMaxDepth, MaxBranchNodeLength)
+			assert.NoError(err)
+			circuit := inclusionCircuit{Proof: PlaceholderProof(testMaxDepth, MaxBranchNodeLength), Value: make([]uints.U8, testMaxValueLen)}
+			witness := inclusionCircuit{Proof: proof, Value: uints.NewU8Array(append(value, make([]byte, testMaxValueLen-len(value))...)), ValueLen: len(value)}
+			copy(witness.Root[:], uints.NewU8Array(root))
+			copy(witness.Key[:], uints.NewU8Array(key))
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+			// wrong value
+			witness.Value[0] = uints.NewU8(value[0] ^ 0x01)
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.Error(err)
+		}, fmt.Sprintf("key=%d", i))
+	}
+}
+
+func TestExclusion(t *testing.T) {
+	assert := test.NewAssert(t)
+	trie, keys := newTestData(t)
+	root := trie.root()
+	// random key, diverging key inside the extension node and diverging key
+	// in the leaf.
+	extKey := append([]byte{}, keys[0]...)
+	extKey[5] ^= 0x01
+	leafKey := append([]byte{}, keys[1]...)
+	leafKey[31] ^= 0x01
+	embeddedKey := append([]byte{}, keys[41]...)
+	embeddedKey[31] ^= 0x02
+	for name, key := range map[string][]byte{"random": randomBytes(KeyLength), "extension": extKey, "leaf": leafKey, "embedded": embeddedKey} {
+		assert.Run(func(assert *test.Assert) {
+			proof, err := ValueOfProof(trie.prove(key), testMaxDepth, MaxBranchNodeLength)
+			assert.NoError(err)
+			circuit := exclusionCircuit{Proof: PlaceholderProof(testMaxDepth, MaxBranchNodeLength)}
+			witness := exclusionCircuit{Proof: proof}
+			copy(witness.Root[:], uints.NewU8Array(root))
+			copy(witness.Key[:], uints.NewU8Array(key))
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, name)
+	}
+	// existing key
+	key := keys[2]
+	proof, err := ValueOfProof(trie.prove(key), testMaxDepth, MaxBranchNodeLength)
+	assert.NoError(err)
+	circuit := exclusionCircuit{Proof: PlaceholderProof(testMaxDepth, MaxBranchNodeLength)}
+	witness := exclusionCircuit{Proof: proof}
+	copy(witness.Root[:], uints.NewU8Array(root))
+	copy(witness.Key[:], uints.NewU8Array(key))
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// The fixtures in testdata are eth_getProof responses:
+//   - mainnet.json: the deposit contract account at mainnet block 14474377,
+//     taken from the op-node tests in the Optimism monorepo.
+//   - goerli.json: the SystemConfig proxy account and one of its storage slots
+//     at Goerli block 8481106, taken from the op-service tests in the Optimism
+//     monorepo.
+//   - geth_simulated.json: generated with go-ethereum v1.17.7 on a simulated
+//     chain. The contract storage has two slots whose keys share the first 8
+//     nibbles and which store short values, so their leaves are embedded in
+//     the parent branch node. It also contains proofs for a missing slot and a
+//     missing account.
+
+type getProofStorage struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+type getProofResult struct {
+	Address      string            `json:"address"`
+	AccountProof []string          `json:"accountProof"`
+	Balance      string            `json:"balance"`
+	CodeHash     string            `json:"codeHash"`
+	Nonce        string            `json:"nonce"`
+	StorageHash  string            `json:"storageHash"`
+	StorageProof []getProofStorage `json:"storageProof"`
+}
+
+type getProofFixture struct {
+	BlockNumber string           `json:"blockNumber"`
+	StateRoot   string           `json:"stateRoot"`
+	Proofs      []getProofResult `json:"proofs"`
+}
+
+// fixtureMaxValueLen is the maximal length of the values in the fixtures, the
+// account RLP encoding being the longest.
+const fixtureMaxValueLen = 112
+
+// fromHex decodes hex-encoded data.
+func fromHex(s string) []byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// quantity returns the minimal big-endian encoding of the hex-encoded
+// quantity, as used in RLP.
+func quantity(s string) []byte {
+	v, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok {
+		panic("invalid quantity " + s)
+	}
+	return v.Bytes()
+}
+
+// rlpSplit returns the payload of the first RLP item in b and the rest.
+func rlpSplit(b []byte) (isList bool, payload, rest []byte) {
+	length := func(lb []byte) int { return int(new(big.Int).SetBytes(lb).Int64()) }
+	switch {
+	case b[0] < 0x80:
+		return false, b[:1], b[1:]
+	case b[0] <= 0xb7:
+		l := int(b[0] - 0x80)
+		return false, b[1 : 1+l], b[1+l:]
+	case b[0] < 0xc0:
+		ll := int(b[0] - 0xb7)
+		l := length(b[1 : 1+ll])
+		return false, b[1+ll : 1+ll+l], b[1+ll+l:]
+	case b[0] <= 0xf7:
+		l := int(b[0] - 0xc0)
+		return true, b[1 : 1+l], b[1+l:]
+	default:
+		ll := int(b[0] - 0xf7)
+		l := length(b[1 : 1+ll])
+		return true, b[1+ll : 1+ll+l], b[1+ll+l:]
+	}
+}
+
+// rlpItems returns the encodings of the items in the RLP list b.
+func rlpItems(b []byte) [][]byte {
+	_, payload, _ := rlpSplit(b)
+	var items [][]byte
+	for len(payload) > 0 {
+		_, _, rest := rlpSplit(payload)
+		items = append(items, payload[:len(payload)-len(rest)])
+		payload = rest
+	}
+	return items
+}
+
+// withEmbedded appends the nodes embedded in the last node on the path of the
+// key. eth_getProof only returns the nodes referenced by their hash.
+func withEmbedded(nodes [][]byte, key []byte) [][]byte {
+	nibbles := toNibbles(key)
+	depth := 0
+	for i := 0; i < len(nodes); i++ {
+		items := rlpItems(nodes[i])
+		var child []byte
+		if len(items) == 17 {
+			child = items[nibbles[depth]]
+			depth++
+		} else {
+			_, path, _ := rlpSplit(items[0])
+			isLeaf := path[0]>>4 >= 2
+			pathNibbles := toNibbles(path)[2-(path[0]>>4)%2:]
+			if isLeaf || depth+len(pathNibbles) > len(nibbles) || !bytes.Equal(pathNibbles, nibbles[depth:depth+len(pathNibbles)]) {
+				break
+			}
+			depth += len(pathNibbles)
+			child = items[1]
+		}
+		if i == len(nodes)-1 && child[0] >= 0xc0 {
+			nodes = append(nodes, child)
+		}
+	}
+	return nodes
+}
+
+func checkFixtureProof(assert *test.Assert, root, key []byte, proofNodes []string, value []byte, inclusion bool) {
+	var nodes [][]byte
+	for _, n := range proofNodes {
+		nodes = append(nodes, fromHex(n))
+	}
+	nodes = withEmbedded(nodes, key)
+	// leave one padding node to also check the unused part of the proof.
+	maxDepth := len(nodes) + 1
+	proof, err := ValueOfProof(nodes, maxDepth, MaxBranchNodeLength)
+	assert.NoError(err)
+	if inclusion {
+		circuit := inclusionCircuit{Proof: PlaceholderProof(maxDepth, MaxBranchNodeLength), Value: make([]uints.U8, fixtureMaxValueLen)}
+		witness := inclusionCircuit{Proof: proof, Value: uints.NewU8Array(append(value, make([]byte, fixtureMaxValueLen-len(value))...)), ValueLen: len(value)}
+		copy(witness.Root[:], uints.NewU8Array(root))
+		copy(witness.Key[:], uints.NewU8Array(key))
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		return
+	}
+	circuit := exclusionCircuit{Proof: PlaceholderProof(maxDepth, MaxBranchNodeLength)}
+	witness := exclusionCircuit{Proof: proof}
+	copy(witness.Root[:], uints.NewU8Array(root))
+	copy(witness.Key[:], uints.NewU8Array(key))
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestGetProofFixtures(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, name := range []string{"mainnet", "goerli", "geth_simulated"} {
+		b, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+		assert.NoError(err)
+		var fixture getProofFixture
+		assert.NoError(json.Unmarshal(b, &fixture))
+		for _, res := range fixture.Proofs {
+			// go-ethereum returns zero hashes for a missing account.
+			exists := new(big.Int).SetBytes(fromHex(res.StorageHash)).Sign() != 0
+			assert.Run(func(assert *test.Assert) {
+				account := rlpList(rlpString(quantity(res.Nonce)), rlpString(quantity(res.Balance)), rlpString(fromHex(res.StorageHash)), rlpString(fromHex(res.CodeHash)))
+				checkFixtureProof(assert, fromHex(fixture.StateRoot), keccak(fromHex(res.Address)), res.AccountProof, account, exists)
+			}, name, "account="+res.Address)
+			for _, st := range res.StorageProof {
+				// zero slots are removed from the storage trie.
+				value := quantity(st.Value)
+				assert.Run(func(assert *test.Assert) {
+					checkFixtureProof(assert, fromHex(res.StorageHash), keccak(fromHex(st.Key)), st.Proof, rlpString(value), len(value) > 0)
+				}, name, "slot="+st.Key)
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package mpt
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/encoding/rlp"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/sha3"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/selector"
+)
+
+const (
+	// nbBranchChildren is the number of children in a branch node.
+	nbBranchChildren = 16
+	// nbBranchItems is the number of items in a branch node. The branch node
+	// contains additionally the value, which is not used for fixed-length
+	// keys.
+	nbBranchItems = nbBranchChildren + 1
+	// keyNibbles is the number of nibbles in the key.
+	keyNibbles = 2 * KeyLength
+	// maxRefLength is the maximum length of the reference to a child node,
+	// which is either the RLP-encoded hash of the child node or the embedded
+	// child node shorter than the hash.
+	maxRefLength = HashLength + 1
+	// maxPathLength is the maximum length of the compact encoded path.
+	maxPathLength = KeyLength + 1
+)
+
+type verifier struct {
+	api   frontend.API
+	uapi  *uints.BinaryField[uints.U64]
+	codec *rlp.Codec
+	proof *Proof
+	// nibbles maps a byte to its high nibble.
+	nibbles *logderivlookup.Table
+}
+
+func newVerifier(api frontend.API, p *Proof) (*verifier, error) {
+	if len(p.Nodes) == 0 || len(p.Nodes) != len(p.NodeLengths) {
+		return nil, fmt.Errorf("invalid proof dimensions")
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, fmt.Errorf("new uints: %w", err)
+	}
+	nibbles := logderivlookup.New(api)
+	for i := 0; i < 256; i++ {
+		nibbles.Insert(i >> 4)
+	}
+	return &verifier{api: api, uapi: uapi, codec: rlp.New(api), proof: p, nibbles: nibbles}, nil
+}
+
+// encodedLength returns the maximum length of the RLP encoding of a string of
+// length n.
+func encodedLength(n int) int {
+	res := n + 1
+	if n > 55 {
+		for ; n > 0; n >>= 8 {
+			res++
+		}
+	}
+	return res
+}
+
+// splitByte returns the high and low nibble of the byte b.
+func (v *verifier) splitByte(b frontend.Variable) (hi, lo frontend.Variable) {
+	hi = v.nibbles.Lookup(b)[0]
+	lo = v.api.Sub(b, v.api.Mul(hi, 16))
+	return hi, lo
+}
+
+// lengthIndicators returns indicators eq where eq[k] == 1 iff length == k and
+// asserts that exactly one of them is set.
+func (v *verifier) lengthIndicators(length frontend.Variable, maxLen int) []frontend.Variable {
+	api := v.api
+	eq := make([]frontend.Variable, maxLen+1)
+	var eqSum frontend.Variable = 0
+	for k := range eq {
+		eq[k] = api.IsZero(api.Sub(length, k))
+		eqSum = api.Add(eqSum, eq[k])
+	}
+	api.AssertIsEqual(eqSum, 1)
+	return eq
+}
+
+// verify walks the proof along the key. If inclusion is set, then asserts that
+// the walk ends in a leaf storing value. Otherwise, asserts that the walk
+// ends in a node proving that the key is not in the trie.
+func (v *verifier) verify(root, key, value []uints.U8, valueLen frontend.Variable, inclusion bool) {
+	api := v.api
+	p := v.proof
+	if len(root) != HashLength {
+		panic(fmt.Sprintf("root must be %d bytes", HashLength))
+	}
+	if len(key) != KeyLength {
+		panic(fmt.Sprintf("key must be %d bytes", KeyLength))
+	}
+	maxDepth := len(p.Nodes)
+	maxNodeLen := len(p.Nodes[0])
+	// the items of the intermediate nodes are references and compact paths.
+	// For inclusion proofs the value in the leaf is at most len(value) bytes,
+	// but for exclusion proofs the walk can end in a leaf storing a value of
+	// any length.
+	maxItemLen := encodedLength(maxPathLength)
+	if inclusion && encodedLength(len(value)) > maxItemLen {
+		maxItemLen = encodedLength(len(value))
+	}
+	if !inclusion {
+		maxItemLen = maxNodeLen
+	}
+	maxValueLen := len(value)
+	if maxValueLen < HashLength {
+		// the second item of the extension and branch nodes is a reference
+		maxValueLen = HashLength
+	}
+
+	// the key nibbles, padded for reading after the end of the key.
+	keyTable := logderivlookup.New(api)
+	for i := range key {
+		hi, lo := v.splitByte(v.uapi.ByteValueOf(key[i].Val).Val)
+		keyTable.Insert(hi)
+		keyTable.Insert(lo)
+	}
+	for i := 0; i < keyNibbles; i++ {
+		keyTable.Insert(0)
+	}
+	// isLast[i] == 1 iff i is the last node. Exactly one of them is set, which
+	// ensures that the depth is in [1, maxDepth].
+	isLast := v.lengthIndicators(p.Depth, maxDepth)[1:]
+	api.AssertIsEqual(api.IsZero(p.Depth), 0)
+	var valueEq []frontend.Variable
+	if inclusion {
+		valueEq = v.lengthIndicators(valueLen, len(value))
+	}
+
+	// the reference to the current node. The node is referenced either by its
+	// hash or, if the node is shorter than the hash, embedded in the parent.
+	expected := make([]frontend.Variable, HashLength)
+	for i := range expected {
+		expected[i] = root[i].Val
+	}
+	var isEmbedded frontend.Variable = 0
+	var embedded rlp.Bytes
+	var isActive frontend.Variable = 1
+	var pos frontend.Variable = 0
+	for i := 0; i < maxDepth; i++ {
+		if len(p.Nodes[i]) != maxNodeLen {
+			panic("nodes must have equal length")
+		}
+		node := rlp.Bytes{Data: make([]uints.U8, maxNodeLen), Length: p.NodeLengths[i]}
+		for j := range node.Data {
+			// the node bytes are used as lookup queries, ensure they are bytes.
+			node.Data[j] = v.uapi.ByteValueOf(p.Nodes[i][j].Val)
+		}
+		// 1- check that the node matches the reference. For nodes referenced
+		// by hash we check the hash of the node and for embedded nodes that
+		// the node is the reference itself.
+		h, err := sha3.NewLegacyKeccak256(api)
+		if err != nil {
+			panic(fmt.Sprintf("new keccak: %v", err))
+		}
+		fh, ok := h.(hash.BinaryFixedLengthHasher)
+		if !ok {
+			panic("keccak does not support fixed length sum")
+		}
+		fh.Write(node.Data)
+		digest := fh.FixedLengthSum(node.Length)
+		isHashed := api.Mul(isActive, api.Sub(1, isEmbedded))
+		for j := range digest {
+			api.AssertIsEqual(api.Mul(isHashed, api.Sub(digest[j].Val, expected[j])), 0)
+		}
+		if i > 0 {
+			// the embedded reference is zero after its length, as is the
+			// node padding.
+			isInlined := api.Mul(isActive, isEmbedded)
+			api.AssertIsEqual(api.Mul(isInlined, api.Sub(node.Length, embedded.Length)), 0)
+			for j := 0; j < maxRefLength && j < maxNodeLen; j++ {
+				api.AssertIsEqual(api.Mul(isInlined, api.Sub(node.Data[j].Val, embedded.Data[j].Val)), 0)
+			}
+		}
+
+		// 2- decode the node. The node is a list of 17 items (branch) or 2
+		// items (extension or leaf).
+		items, nbItems := v.codec.DecodeList(node, nbBranchItems, maxItemLen)
+		isBranch := api.IsZero(api.Sub(nbItems, nbBranchItems))
+		isTwo := api.IsZero(api.Sub(nbItems, 2))
+		api.AssertIsEqual(api.Mul(isActive, api.Sub(api.Add(isBranch, isTwo), 1)), 0)
+
+		// 3a- extension or leaf node. The first item is the compact encoded
+		// path, where the high nibble of the first byte is the flag indicating
+		// if the node is a leaf and if the path length is odd. If the path
+		// length is odd, then the low nibble of the first byte is the first
+		// nibble of the path.
+		pathIsList, path := v.codec.Decode(items[0], maxPathLength)
+		api.AssertIsEqual(api.Mul(isActive, api.Mul(isTwo, pathIsList)), 0)
+		his := make([]frontend.Variable, maxPathLength)
+		los := make([]frontend.Variable, maxPathLength)
+		for j := range his {
+			his[j], los[j] = v.splitByte(path.Data[j].Val)
+		}
+		flagBits := api.ToBinary(his[0], 4)
+		isOdd := flagBits[0]
+		isLeaf := api.Mul(isTwo, flagBits[1])
+		pathLen := api.Add(api.Mul(api.Sub(path.Length, 1), 2), isOdd)
+		pathEq := v.lengthIndicators(api.Mul(isTwo, pathLen), keyNibbles)
+		var isPathActive frontend.Variable = 1
+		var pathMatches frontend.Variable = 1
+		for j := 0; j < keyNibbles; j++ {
+			isPathActive = api.Sub(isPathActive, pathEq[j])
+			// the j-th path nibble is the ((j+1) mod 2)-th nibble of the
+			// ((j+1)/2)-th byte if the path length is odd and the (j mod 2)-th
+			// nibble of the (1+j/2)-th byte otherwise.
+			oddNibble, evenNibble := his[(j+1)/2], his[1+j/2]
+			if (j+1)%2 == 1 {
+				oddNibble = los[(j+1)/2]
+			}
+			if j%2 == 1 {
+				evenNibble = los[1+j/2]
+			}
+			pathNibble := api.Select(isOdd, oddNibble, evenNibble)
+			keyNibble := keyTable.Lookup(api.Add(pos, j))[0]
+			isEqual := api.IsZero(api.Sub(pathNibble, keyNibble))
+			pathMatches = api.Mul(pathMatches, api.Sub(1, api.Mul(isPathActive, api.Sub(1, isEqual))))
+		}
+		extensionContinues := api.Mul(isTwo, api.Mul(api.Sub(1, isLeaf), pathMatches))
+		isFound := api.Mul(isLeaf, pathMatches)
+
+		// 3b- the reference to the next node is the child at the key nibble
+		// for branch nodes and the second item for extension nodes. The leaf
+		// node does not reference a node, we use the empty string instead.
+		nibble := keyTable.Lookup(pos)[0]
+		children := logderivlookup.New(api)
+		childLengths := make([]frontend.Variable, nbBranchChildren)
+		for k := 0; k < nbBranchChildren; k++ {
+			for j := 0; j < maxRefLength; j++ {
+				children.Insert(items[k].Data[j].Val)
+			}
+			childLengths[k] = items[k].Length
+		}
+		queries := make([]frontend.Variable, maxRefLength)
+		for j := range queries {
+			queries[j] = api.Add(api.Mul(nibble, maxRefLength), j)
+		}
+		child := children.Lookup(queries...)
+		ref := rlp.Bytes{Data: make([]uints.U8, maxRefLength)}
+		ref.Length = api.Select(isBranch, selector.Mux(api, nibble, childLengths...), items[1].Length)
+		ref.Length = api.Select(isLeaf, 1, ref.Length)
+		for j := range ref.Data {
+			var empty frontend.Variable = 0
+			if j == 0 {
+				empty = 0x80
+			}
+			b := api.Select(isBranch, child[j], items[1].Data[j].Val)
+			ref.Data[j] = uints.U8{Val: api.Select(isLeaf, empty, b)}
+		}
+		refIsList, refPayload := v.codec.Decode(ref, HashLength)
+		refIsEmpty := api.Mul(api.Sub(1, refIsList), api.IsZero(refPayload.Length))
+		branchContinues := api.Mul(isBranch, api.Sub(1, refIsEmpty))
+
+		// 4- check that the walk continues for all nodes except the last and
+		// terminates at the last node.
+		continues := api.Add(branchContinues, extensionContinues)
+		isLastNode := isLast[i]
+		isMiddleNode := api.Sub(isActive, isLastNode)
+		api.AssertIsEqual(api.Mul(isMiddleNode, api.Sub(1, continues)), 0)
+		api.AssertIsEqual(api.Mul(isLastNode, continues), 0)
+		if inclusion {
+			api.AssertIsEqual(api.Mul(isLastNode, api.Sub(1, isFound)), 0)
+			// a leaf consumes the remaining key
+			api.AssertIsEqual(api.Mul(isLastNode, api.Sub(api.Add(pos, pathLen), keyNibbles)), 0)
+			// the value is the payload of the second item of the leaf
+			valueIsList, payload := v.codec.Decode(items[1], maxValueLen)
+			api.AssertIsEqual(api.Mul(isLastNode, valueIsList), 0)
+			api.AssertIsEqual(api.Mul(isLastNode, api.Sub(payload.Length, valueLen)), 0)
+			var isValueActive frontend.Variable = 1
+			for j := range value {
+				isValueActive = api.Sub(isValueActive, valueEq[j])
+				api.AssertIsEqual(api.Mul(isLastNode, api.Mul(isValueActive, api.Sub(payload.Data[j].Val, value[j].Val))), 0)
+			}
+		} else {
+			api.AssertIsEqual(api.Mul(isLastNode, isFound), 0)
+		}
+
+		// 5- the next node is referenced by the hash if the reference is a
+		// string and embedded otherwise.
+		api.AssertIsEqual(api.Mul(isMiddleNode, api.Mul(api.Sub(1, refIsList), api.Sub(refPayload.Length, HashLength))), 0)
+		for j := range expected {
+			expected[j] = refPayload.Data[j].Val
+		}
+		isEmbedded = refIsList
+		embedded = ref
+		pos = api.Add(pos, api.Mul(isMiddleNode, api.Add(isBranch, api.Mul(isTwo, pathLen))))
+		isActive = isMiddleNode
+	}
+}
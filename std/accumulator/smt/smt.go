@@ -0,0 +1,94 @@
+// Package smt provides ZKP-circuit functions to verify membership and
+// non-membership proofs in a sparse Merkle tree and to update and insert
+// leaves.
+//
+// The tree has a fixed depth and a leaf position is given by the key, the i-th
+// bit (little-endian) of the key defining if the node at height i is the left
+// (bit is 0) or the right (bit is 1) child. Empty leaves are zero and
+// non-empty leaves are hashes H(key, value). Internal nodes are H(left,
+// right). As the keys are decomposed into exactly depth bits, then the keys
+// must be less than 2^depth.
+//
+// The package also provides an out-of-circuit implementation [Tree] for
+// building the witnesses.
+package smt
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// Proof is a path in the sparse Merkle tree from a leaf to the root. The same
+// proof is used for membership, non-membership, update and insertion.
+type Proof struct {
+	// Siblings are the siblings of the nodes on the path starting from the
+	// leaf level.
+	Siblings []frontend.Variable
+}
+
+// PlaceholderProof returns an empty proof for a tree of the given depth for
+// compiling the circuit.
+func PlaceholderProof(depth int) Proof {
+	return Proof{Siblings: make([]frontend.Variable, depth)}
+}
+
+// leafSum returns the hash of a non-empty leaf.
+func leafSum(h hash.FieldHasher, key, value frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(key, value)
+	return h.Sum()
+}
+
+// nodeSum returns the hash of an internal node.
+func nodeSum(h hash.FieldHasher, left, right frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(left, right)
+	return h.Sum()
+}
+
+// computeRoot computes the root of the tree given the leaf and the bits of the
+// key.
+func (p *Proof) computeRoot(api frontend.API, h hash.FieldHasher, keyBits []frontend.Variable, leaf frontend.Variable) frontend.Variable {
+	sum := leaf
+	for i := range p.Siblings {
+		left := api.Select(keyBits[i], p.Siblings[i], sum)
+		right := api.Select(keyBits[i], sum, p.Siblings[i])
+		sum = nodeSum(h, left, right)
+	}
+	return sum
+}
+
+// VerifyMembership asserts that the leaf at key stores value in the tree
+// defined by root.
+func (p *Proof) VerifyMembership(api frontend.API, h hash.FieldHasher, root, key, value frontend.Variable) {
+	keyBits := api.ToBinary(key, len(p.Siblings))
+	res := p.computeRoot(api, h, keyBits, leafSum(h, key, value))
+	api.AssertIsEqual(res, root)
+}
+
+// VerifyNonMembership asserts that the leaf at key is empty in the tree defined
+// by root.
+func (p *Proof) VerifyNonMembership(api frontend.API, h hash.FieldHasher, root, key frontend.Variable) {
+	keyBits := api.ToBinary(key, len(p.Siblings))
+	res := p.computeRoot(api, h, keyBits, 0)
+	api.AssertIsEqual(res, root)
+}
+
+// Update asserts that the leaf at key stores oldValue in the tree defined by
+// oldRoot and returns the root of the tree where the leaf stores newValue
+// instead.
+func (p *Proof) Update(api frontend.API, h hash.FieldHasher, oldRoot, key, oldValue, newValue frontend.Variable) (newRoot frontend.Variable) {
+	keyBits := api.ToBinary(key, len(p.Siblings))
+	res := p.computeRoot(api, h, keyBits, leafSum(h, key, oldValue))
+	api.AssertIsEqual(res, oldRoot)
+	return p.computeRoot(api, h, keyBits, leafSum(h, key, newValue))
+}
+
+// Insert asserts that the leaf at key is empty in the tree defined by oldRoot
+// and returns the root of the tree where the leaf stores value.
+func (p *Proof) Insert(api frontend.API, h hash.FieldHasher, oldRoot, key, value frontend.Variable) (newRoot frontend.Variable) {
+	keyBits := api.ToBinary(key, len(p.Siblings))
+	res := p.computeRoot(api, h, keyBits, 0)
+	api.AssertIsEqual(res, oldRoot)
+	return p.computeRoot(api, h, keyBits, leafSum(h, key, value))
+}
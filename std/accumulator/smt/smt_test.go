@@ -0,0 +1,177 @@
+package smt
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+const testDepth = 16
+
+type membershipCircuit struct {
+	Proof Proof
+	Root  frontend.Variable
+	Key   frontend.Variable
+	Value frontend.Variable
+}
+
+func (c *membershipCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.Proof.VerifyMembership(api, &h, c.Root, c.Key, c.Value)
+	return nil
+}
+
+type nonMembershipCircuit struct {
+	Proof Proof
+	Root  frontend.Variable
+	Key   frontend.Variable
+}
+
+func (c *nonMembershipCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.Proof.VerifyNonMembership(api, &h, c.Root, c.Key)
+	return nil
+}
+
+type updateCircuit struct {
+	Proof    Proof
+	OldRoot  frontend.Variable
+	NewRoot  frontend.Variable
+	Key      frontend.Variable
+	OldValue frontend.Variable
+	NewValue frontend.Variable
+}
+
+func (c *updateCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	res := c.Proof.Update(api, &h, c.OldRoot, c.Key, c.OldValue, c.NewValue)
+	api.AssertIsEqual(res, c.NewRoot)
+	return nil
+}
+
+type insertCircuit struct {
+	Proof   Proof
+	OldRoot frontend.Variable
+	NewRoot frontend.Variable
+	Key     frontend.Variable
+	Value   frontend.Variable
+}
+
+func (c *insertCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	res := c.Proof.Insert(api, &h, c.OldRoot, c.Key, c.Value)
+	api.AssertIsEqual(res, c.NewRoot)
+	return nil
+}
+
+func newTestTree(t *testing.T) *Tree {
+	tree, err := NewTree(hash.MIMC_BN254.New(), testDepth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := int64(1); i < 10; i++ {
+		if err := tree.Set(big.NewInt(i*i*37), big.NewInt(i*1000+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tree
+}
+
+func TestMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(t)
+	key := big.NewInt(4 * 37)
+	value, ok := tree.Get(key)
+	assert.True(ok)
+	proof, err := tree.Prove(key)
+	assert.NoError(err)
+	circuit := membershipCircuit{Proof: PlaceholderProof(testDepth)}
+	witness := membershipCircuit{Proof: proof, Root: tree.Root(), Key: key, Value: value}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	// wrong value
+	witness.Value = 1
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestNonMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(t)
+	key := big.NewInt(5)
+	_, ok := tree.Get(key)
+	assert.False(ok)
+	proof, err := tree.Prove(key)
+	assert.NoError(err)
+	circuit := nonMembershipCircuit{Proof: PlaceholderProof(testDepth)}
+	witness := nonMembershipCircuit{Proof: proof, Root: tree.Root(), Key: key}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	// existing key
+	key = big.NewInt(37)
+	proof, err = tree.Prove(key)
+	assert.NoError(err)
+	witness = nonMembershipCircuit{Proof: proof, Root: tree.Root(), Key: key}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestUpdate(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(t)
+	key := big.NewInt(9 * 37)
+	oldValue, ok := tree.Get(key)
+	assert.True(ok)
+	oldRoot := tree.Root()
+	proof, err := tree.Prove(key)
+	assert.NoError(err)
+	newValue := big.NewInt(42)
+	assert.NoError(tree.Set(key, newValue))
+	circuit := updateCircuit{Proof: PlaceholderProof(testDepth)}
+	witness := updateCircuit{Proof: proof, OldRoot: oldRoot, NewRoot: tree.Root(), Key: key, OldValue: oldValue, NewValue: newValue}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+}
+
+func TestInsert(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(t)
+	key := big.NewInt(12345)
+	oldRoot := tree.Root()
+	proof, err := tree.Prove(key)
+	assert.NoError(err)
+	value := big.NewInt(42)
+	assert.NoError(tree.Set(key, value))
+	circuit := insertCircuit{Proof: PlaceholderProof(testDepth)}
+	witness := insertCircuit{Proof: proof, OldRoot: oldRoot, NewRoot: tree.Root(), Key: key, Value: value}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	// inserting again fails as the leaf is not empty
+	proof, err = tree.Prove(key)
+	assert.NoError(err)
+	witness = insertCircuit{Proof: proof, OldRoot: tree.Root(), NewRoot: tree.Root(), Key: key, Value: value}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestKeyOutOfRange(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(t)
+	key := new(big.Int).Lsh(big.NewInt(1), testDepth)
+	assert.Error(tree.Set(key, big.NewInt(1)))
+	_, err := tree.Prove(key)
+	assert.Error(err)
+}
@@ -0,0 +1,160 @@
+package smt
+
+import (
+	"errors"
+	"fmt"
+	stdhash "hash"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Tree is an out-of-circuit sparse Merkle tree for computing the roots and
+// proofs used as witnesses for [Proof]. It uses the same leaf and node
+// hashing as the in-circuit methods. The hash function must be the native
+// counterpart of the in-circuit hash function (for example gnark-crypto MiMC
+// for std/hash/mimc), where the field elements are written as big-endian
+// byte slices of length h.BlockSize().
+type Tree struct {
+	h     stdhash.Hash
+	depth int
+	// nodes are the non-empty nodes per level, indexed by position. Level 0
+	// contains the leaves.
+	nodes []map[string]*big.Int
+	// values are the values of the non-empty leaves.
+	values map[string]*big.Int
+	// empty are the hashes of the empty subtrees per level.
+	empty []*big.Int
+}
+
+// NewTree returns a new empty tree of the given depth using the hash function
+// h.
+func NewTree(h stdhash.Hash, depth int) (*Tree, error) {
+	t := &Tree{
+		h:      h,
+		depth:  depth,
+		nodes:  make([]map[string]*big.Int, depth+1),
+		values: make(map[string]*big.Int),
+		empty:  make([]*big.Int, depth+1),
+	}
+	for i := range t.nodes {
+		t.nodes[i] = make(map[string]*big.Int)
+	}
+	t.empty[0] = new(big.Int)
+	for i := 1; i <= depth; i++ {
+		e, err := t.hash(t.empty[i-1], t.empty[i-1])
+		if err != nil {
+			return nil, fmt.Errorf("empty subtree: %w", err)
+		}
+		t.empty[i] = e
+	}
+	return t, nil
+}
+
+// Depth returns the depth of the tree.
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// Root returns the current root of the tree.
+func (t *Tree) Root() *big.Int {
+	return t.node(t.depth, new(big.Int))
+}
+
+// Get returns the value stored at key and a boolean indicating if the leaf is
+// non-empty.
+func (t *Tree) Get(key *big.Int) (*big.Int, bool) {
+	v, ok := t.values[key.String()]
+	if !ok {
+		return nil, false
+	}
+	return new(big.Int).Set(v), true
+}
+
+// Set inserts or updates the value stored at key.
+func (t *Tree) Set(key, value *big.Int) error {
+	if err := t.checkKey(key); err != nil {
+		return err
+	}
+	leaf, err := t.hash(key, value)
+	if err != nil {
+		return fmt.Errorf("leaf: %w", err)
+	}
+	// compute the new path before modifying the tree to keep it consistent on
+	// errors.
+	path := make([]*big.Int, t.depth+1)
+	path[0] = leaf
+	idx := new(big.Int).Set(key)
+	sibling := new(big.Int)
+	for i := 0; i < t.depth; i++ {
+		sibling.Xor(idx, big.NewInt(1))
+		left, right := path[i], t.node(i, sibling)
+		if idx.Bit(0) == 1 {
+			left, right = right, left
+		}
+		if path[i+1], err = t.hash(left, right); err != nil {
+			return fmt.Errorf("node: %w", err)
+		}
+		idx.Rsh(idx, 1)
+	}
+	idx.Set(key)
+	for i := range path {
+		t.nodes[i][idx.String()] = path[i]
+		idx.Rsh(idx, 1)
+	}
+	t.values[key.String()] = new(big.Int).Set(value)
+	return nil
+}
+
+// Prove returns the proof for the leaf at key. The proof is valid for
+// membership if the leaf is non-empty and for non-membership otherwise. It is
+// also used for updating and inserting the leaf.
+func (t *Tree) Prove(key *big.Int) (Proof, error) {
+	if err := t.checkKey(key); err != nil {
+		return Proof{}, err
+	}
+	proof := Proof{Siblings: make([]frontend.Variable, t.depth)}
+	idx := new(big.Int).Set(key)
+	sibling := new(big.Int)
+	for i := 0; i < t.depth; i++ {
+		sibling.Xor(idx, big.NewInt(1))
+		proof.Siblings[i] = new(big.Int).Set(t.node(i, sibling))
+		idx.Rsh(idx, 1)
+	}
+	return proof, nil
+}
+
+func (t *Tree) checkKey(key *big.Int) error {
+	if key.Sign() < 0 {
+		return errors.New("negative key")
+	}
+	if key.BitLen() > t.depth {
+		return fmt.Errorf("key does not fit into %d bits", t.depth)
+	}
+	return nil
+}
+
+// node returns the node at the given level and position.
+func (t *Tree) node(level int, idx *big.Int) *big.Int {
+	if n, ok := t.nodes[level][idx.String()]; ok {
+		return n
+	}
+	return t.empty[level]
+}
+
+// hash returns H(a, b) with the inputs encoded as big-endian byte slices of
+// length h.BlockSize().
+func (t *Tree) hash(a, b *big.Int) (*big.Int, error) {
+	bs := t.h.BlockSize()
+	if a.BitLen() > 8*bs || b.BitLen() > 8*bs {
+		return nil, errors.New("input does not fit into block")
+	}
+	buf := make([]byte, 2*bs)
+	a.FillBytes(buf[:bs])
+	b.FillBytes(buf[bs:])
+	t.h.Reset()
+	if _, err := t.h.Write(buf); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(t.h.Sum(nil)), nil
+}
@@ -8,8 +8,9 @@ package algopts
 import "fmt"
 
 type algebraCfg struct {
-	NbScalarBits int
-	FoldMulti    bool
+	NbScalarBits       int
+	FoldMulti          bool
+	CompleteArithmetic bool
 }
 
 // AlgebraOption allows modifying algebraic operation behaviour.
@@ -44,6 +45,21 @@ func WithFoldingScalarMul() AlgebraOption {
 	}
 }
 
+// WithCompleteArithmetic forces the use of complete formulas in scalar
+// multiplication. Then the input point may be the point at infinity (0,0) and
+// the scalar may be zero. It is currently only taken into account by the
+// emulated curves. When given to the BLS12-381 pairing constructor, the G1
+// inputs to the pairing may be the point at infinity (0,0).
+func WithCompleteArithmetic() AlgebraOption {
+	return func(ac *algebraCfg) error {
+		if ac.CompleteArithmetic {
+			return fmt.Errorf("WithCompleteArithmetic already set")
+		}
+		ac.CompleteArithmetic = true
+		return nil
+	}
+}
+
 // NewConfig applies all given options and returns a configuration to be used.
 func NewConfig(opts ...AlgebraOption) (*algebraCfg, error) {
 	ret := new(algebraCfg)
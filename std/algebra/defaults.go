@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -60,7 +61,10 @@ func GetCurve[FR emulated.FieldParams, G1El G1ElementT](api frontend.API) (Curve
 // GetPairing returns the [Pairing] implementation corresponding to the groups
 // type parameters. The method allows to have a fully generic implementation
 // without taking into consideration the initialization differences.
-func GetPairing[G1El G1ElementT, G2El G2ElementT, GtEl GtElementT](api frontend.API) (Pairing[G1El, G2El, GtEl], error) {
+//
+// The options are currently only taken into account by the BLS12-381 pairing,
+// see [sw_bls12381.NewPairing].
+func GetPairing[G1El G1ElementT, G2El G2ElementT, GtEl GtElementT](api frontend.API, opts ...algopts.AlgebraOption) (Pairing[G1El, G2El, GtEl], error) {
 	var ret Pairing[G1El, G2El, GtEl]
 	switch s := any(&ret).(type) {
 	case *Pairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]:
@@ -76,7 +80,7 @@ func GetPairing[G1El G1ElementT, G2El G2ElementT, GtEl GtElementT](api frontend.
 		}
 		*s = p
 	case *Pairing[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]:
-		p, err := sw_bls12381.NewPairing(api)
+		p, err := sw_bls12381.NewPairing(api, opts...)
 		if err != nil {
 			return ret, fmt.Errorf("new pairing: %w", err)
 		}
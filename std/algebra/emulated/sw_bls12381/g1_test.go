@@ -0,0 +1,90 @@
+package sw_bls12381
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type scalarMulG1BySeedCircuit struct {
+	In1 G1Affine
+	Res G1Affine
+}
+
+func (c *scalarMulG1BySeedCircuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return fmt.Errorf("new G1 struct: %w", err)
+	}
+	res := g1.scalarMulBySeed(&c.In1)
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestScalarMulG1BySeedTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	in1, _ := randomG1G2Affines()
+	var res bls12381.G1Affine
+	x0, _ := new(big.Int).SetString("15132376222941642752", 10)
+	res.ScalarMultiplication(&in1, x0).Neg(&res)
+	witness := scalarMulG1BySeedCircuit{
+		In1: NewG1Affine(in1),
+		Res: NewG1Affine(res),
+	}
+	err := test.IsSolved(&scalarMulG1BySeedCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type mapToG1Circuit struct {
+	U   emulated.Element[BaseField]
+	Res G1Affine
+}
+
+func (c *mapToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return fmt.Errorf("new G1 struct: %w", err)
+	}
+	res := g1.MapToG1(&c.U)
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestMapToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var rnd fp.Element
+	rnd.SetRandom()
+	for _, u := range []fp.Element{rnd, fp.NewElement(0), fp.NewElement(1)} {
+		res := bls12381.MapToG1(u)
+		witness := mapToG1Circuit{
+			U:   emulated.ValueOf[BaseField](u),
+			Res: NewG1Affine(res),
+		}
+		err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+func TestMapToG1WrongSign(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u fp.Element
+	u.SetRandom()
+	res := bls12381.MapToG1(u)
+	// the map of -u is the negation of the map of u
+	res.Neg(&res)
+	witness := mapToG1Circuit{
+		U:   emulated.ValueOf[BaseField](u),
+		Res: NewG1Affine(res),
+	}
+	err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -10,6 +10,9 @@ import (
 )
 
 type G2 struct {
+	api frontend.API
+	fp  *emulated.Field[BaseField]
+	fr  *emulated.Field[ScalarField]
 	*fields_bls12381.Ext2
 	u1, w *emulated.Element[BaseField]
 	v     *fields_bls12381.E2
@@ -39,6 +42,14 @@ func newG2AffP(v bls12381.G2Affine) g2AffP {
 }
 
 func NewG2(api frontend.API) *G2 {
+	fp, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		panic(err)
+	}
+	fr, err := emulated.NewField[ScalarField](api)
+	if err != nil {
+		panic(err)
+	}
 	w := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436")
 	u1 := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939437")
 	v := fields_bls12381.E2{
@@ -46,6 +57,9 @@ func NewG2(api frontend.API) *G2 {
 		A1: emulated.ValueOf[BaseField]("1028732146235106349975324479215795277384839936929757896155643118032610843298655225875571310552543014690878354869257"),
 	}
 	return &G2{
+		api:  api,
+		fp:   fp,
+		fr:   fr,
 		Ext2: fields_bls12381.NewExt2(api),
 		w:    &w,
 		u1:   &u1,
@@ -265,3 +279,213 @@ func (g2 *G2) AssertIsEqual(p, q *G2Affine) {
 	g2.Ext2.AssertIsEqual(&p.P.X, &q.P.X)
 	g2.Ext2.AssertIsEqual(&p.P.Y, &q.P.Y)
 }
+
+// AddUnified adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q, and either or both can be (0,0).
+// (0,0) is not on the twist but we conventionally take it as the
+// neutral/infinity point as per the [EIP-2537].
+//
+// It uses the unified formulas of Brier and Joye ([[BriJoy02]] (Corollary 1)).
+//
+// [BriJoy02]: https://link.springer.com/content/pdf/10.1007/3-540-45664-3_24.pdf
+// [EIP-2537]: https://eips.ethereum.org/EIPS/eip-2537
+func (g2 *G2) AddUnified(p, q *G2Affine) *G2Affine {
+
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := g2.api.And(g2.Ext2.IsZero(&p.P.X), g2.Ext2.IsZero(&p.P.Y))
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := g2.api.And(g2.Ext2.IsZero(&q.P.X), g2.Ext2.IsZero(&q.P.Y))
+
+	// λ = ((p.x+q.x)² - p.x*q.x)/(p.y + q.y)
+	pxqx := g2.Ext2.Mul(&p.P.X, &q.P.X)
+	pxplusqx := g2.Ext2.Add(&p.P.X, &q.P.X)
+	num := g2.Ext2.Square(pxplusqx)
+	num = g2.Ext2.Sub(num, pxqx)
+	denum := g2.Ext2.Add(&p.P.Y, &q.P.Y)
+	// if p.y + q.y = 0, assign dummy 1 to denum and continue
+	selector3 := g2.Ext2.IsZero(denum)
+	denum = g2.Ext2.Select(selector3, g2.Ext2.One(), denum)
+	λ := g2.Ext2.DivUnchecked(num, denum)
+
+	// x = λ^2 - p.x - q.x
+	xr := g2.Ext2.Square(λ)
+	xr = g2.Ext2.Sub(xr, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	yr := g2.Ext2.Sub(&p.P.X, xr)
+	yr = g2.Ext2.Mul(yr, λ)
+	yr = g2.Ext2.Sub(yr, &p.P.Y)
+	result := &G2Affine{
+		P: g2AffP{
+			X: *g2.reduce(xr),
+			Y: *g2.reduce(yr),
+		},
+	}
+
+	infinity := &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.Zero(),
+			Y: *g2.Ext2.Zero(),
+		},
+	}
+	// if p=(0,0) return q
+	result = g2.Select(selector1, q, result)
+	// if q=(0,0) return p
+	result = g2.Select(selector2, p, result)
+	// if p.y + q.y = 0, return (0, 0)
+	result = g2.Select(selector3, infinity, result)
+
+	return result
+}
+
+// Select selects between p and q given the selector b. If b == 1, then returns
+// p and q otherwise. The line precomputations are not kept.
+func (g2 *G2) Select(b frontend.Variable, p, q *G2Affine) *G2Affine {
+	x := g2.Ext2.Select(b, &p.P.X, &q.P.X)
+	y := g2.Ext2.Select(b, &p.P.Y, &q.P.Y)
+	return &G2Affine{
+		P: g2AffP{
+			X: *x,
+			Y: *y,
+		},
+	}
+}
+
+// ScalarMul computes [s]p and returns it. It doesn't modify p nor s.
+// This function doesn't check that the p is on the twist. See
+// [Pairing.AssertIsOnTwist].
+//
+// ✅ p can be (0,0) and s can be 0.
+//
+// It computes the right-to-left variable-base double-and-add algorithm
+// ([Joye07], Alg.1) similarly to [sw_emulated.Curve.ScalarMulGeneric].
+//
+// [Joye07]: https://www.iacr.org/archive/ches2007/47270135/47270135.pdf
+func (g2 *G2) ScalarMul(p *G2Affine, s *Scalar) *G2Affine {
+	// if p=(0,0) we assign a dummy point to p and continue
+	selector := g2.api.And(g2.Ext2.IsZero(&p.P.X), g2.Ext2.IsZero(&p.P.Y))
+	_, _, _, gen := bls12381.Generators()
+	dummy := NewG2Affine(gen)
+	p = g2.Select(selector, &dummy, p)
+
+	var st ScalarField
+	sr := g2.fr.Reduce(s)
+	sBits := g2.fr.ToBits(sr)
+	n := st.Modulus().BitLen()
+
+	// i = 1
+	Rb := g2.triple(p)
+	R0 := g2.Select(sBits[1], Rb, p)
+	R1 := g2.Select(sBits[1], p, Rb)
+
+	for i := 2; i < n-1; i++ {
+		Rb = g2.doubleAndAddSelect(sBits[i], R0, R1)
+		R0 = g2.Select(sBits[i], Rb, R0)
+		R1 = g2.Select(sBits[i], R1, Rb)
+	}
+
+	// i = n-1
+	Rb = g2.doubleAndAddSelect(sBits[n-1], R0, R1)
+	R0 = g2.Select(sBits[n-1], Rb, R0)
+
+	// i = 0
+	// we use AddUnified here instead of add so that when s=0, res=(0,0)
+	// because AddUnified(p, -p) = (0,0)
+	R0 = g2.Select(sBits[0], R0, g2.AddUnified(R0, g2.neg(p)))
+
+	// if p=(0,0), return (0,0)
+	infinity := &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.Zero(),
+			Y: *g2.Ext2.Zero(),
+		},
+	}
+	R0 = g2.Select(selector, infinity, R0)
+
+	return R0
+}
+
+// doubleAndAddSelect is the same as doubleAndAdd but computes either:
+//
+//	2p+q is b=1 or
+//	2q+p is b=0
+//
+// It first computes the x-coordinate of p+q via the slope(p,q)
+// and then based on a Select adds either p or q.
+func (g2 *G2) doubleAndAddSelect(b frontend.Variable, p, q *G2Affine) *G2Affine {
+
+	// compute λ1 = (q.y-p.y)/(q.x-p.x)
+	yqyp := g2.Ext2.Sub(&q.P.Y, &p.P.Y)
+	xqxp := g2.Ext2.Sub(&q.P.X, &p.P.X)
+	λ1 := g2.Ext2.DivUnchecked(yqyp, xqxp)
+
+	// compute x2 = λ1²-p.x-q.x
+	λ1λ1 := g2.Ext2.Square(λ1)
+	xqxp = g2.Ext2.Add(&p.P.X, &q.P.X)
+	x2 := g2.Ext2.Sub(λ1λ1, xqxp)
+
+	// ommit y2 computation
+
+	// conditional second addition
+	t := g2.Select(b, p, q)
+
+	// compute λ2 = λ1+2*t.y/(x2-t.x)
+	ypyp := g2.Ext2.Add(&t.P.Y, &t.P.Y)
+	x2xp := g2.Ext2.Sub(x2, &t.P.X)
+	λ2 := g2.Ext2.DivUnchecked(ypyp, x2xp)
+	λ2 = g2.Ext2.Add(λ1, λ2)
+
+	// compute x3 =λ2²-t.x-x2
+	λ2λ2 := g2.Ext2.Square(λ2)
+	x3 := g2.Ext2.Sub(λ2λ2, g2.Ext2.Add(&t.P.X, x2))
+
+	// compute y3 = -λ2*(t.x - x3)-t.y
+	y3 := g2.Ext2.Sub(x3, &t.P.X)
+	y3 = g2.Ext2.Mul(λ2, y3)
+	y3 = g2.Ext2.Sub(y3, &t.P.Y)
+
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.reduce(x3),
+			Y: *g2.reduce(y3),
+		},
+	}
+}
+
+// reduce reduces the coordinates of x so that the overflow does not
+// accumulate over the iterations of the scalar multiplication.
+func (g2 *G2) reduce(x *fields_bls12381.E2) *fields_bls12381.E2 {
+	return &fields_bls12381.E2{
+		A0: *g2.fp.Reduce(&x.A0),
+		A1: *g2.fp.Reduce(&x.A1),
+	}
+}
+
+// clearCofactor maps a point on the twist to the r-torsion using the method of
+// Budroni-Pintore, see https://eprint.iacr.org/2017/419.pdf, Section 4.1.
+func (g2 *G2) clearCofactor(q *G2Affine) *G2Affine {
+	// [x₀]q
+	xq := g2.scalarMulBySeed(q)
+	// [x₀²]q
+	xxq := g2.scalarMulBySeed(xq)
+
+	// [x₀²]q - [x₀]q - q
+	res := g2.sub(xxq, xq)
+	res = g2.sub(res, q)
+
+	// ψ([x₀]q - q)
+	t := g2.sub(xq, q)
+	t = g2.psi(t)
+	res = g2.add(res, t)
+
+	// [2]q with the x-coordinate multiplied by ω
+	t = g2.double(q)
+	t = &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.MulByElement(&t.P.X, g2.w),
+			Y: t.P.Y,
+		},
+	}
+	return g2.sub(res, t)
+}
@@ -1,12 +1,15 @@
 package sw_bls12381
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
 	"github.com/consensys/gnark/test"
 )
 
@@ -118,3 +121,108 @@ func TestScalarMulG2BySeedTestSolve(t *testing.T) {
 	err := test.IsSolved(&scalarMulG2BySeedCircuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type addUnifiedG2Circuit struct {
+	In1, In2 G2Affine
+	Res      G2Affine
+}
+
+func (c *addUnifiedG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.AddUnified(&c.In1, &c.In2)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestAddUnifiedG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in1 := randomG1G2Affines()
+	_, in2 := randomG1G2Affines()
+	var neg, inf bls12381.G2Affine
+	neg.Neg(&in1)
+	for i, tc := range []struct{ p, q bls12381.G2Affine }{
+		{in1, in2}, {in1, in1}, {in1, neg}, {inf, in2}, {in1, inf}, {inf, inf},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var res bls12381.G2Affine
+			res.Add(&tc.p, &tc.q)
+			witness := addUnifiedG2Circuit{
+				In1: NewG2Affine(tc.p),
+				In2: NewG2Affine(tc.q),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&addUnifiedG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+type scalarMulG2Circuit struct {
+	In1 G2Affine
+	S   Scalar
+	Res G2Affine
+}
+
+func (c *scalarMulG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.ScalarMul(&c.In1, &c.S)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestScalarMulG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in1 := randomG1G2Affines()
+	var inf bls12381.G2Affine
+	var s fr_bls12381.Element
+	s.SetRandom()
+	for i, tc := range []struct {
+		p bls12381.G2Affine
+		s fr_bls12381.Element
+	}{
+		{in1, s}, {in1, fr_bls12381.NewElement(0)}, {inf, s},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var res bls12381.G2Affine
+			res.ScalarMultiplication(&tc.p, tc.s.BigInt(new(big.Int)))
+			witness := scalarMulG2Circuit{
+				In1: NewG2Affine(tc.p),
+				S:   NewScalar(tc.s),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&scalarMulG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+type mapToG2Circuit struct {
+	U   fields_bls12381.E2
+	Res G2Affine
+}
+
+func (c *mapToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.MapToG2(&c.U)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var rnd, rndA0, rndA1 bls12381.E2
+	rnd.SetRandom()
+	rndA0.A0.SetRandom()
+	rndA1.A1.SetRandom()
+	for i, u := range []bls12381.E2{rnd, rndA0, rndA1, {}} {
+		assert.Run(func(assert *test.Assert) {
+			res := bls12381.MapToG2(u)
+			witness := mapToG2Circuit{
+				U:   fields_bls12381.FromE2(&u),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&mapToG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
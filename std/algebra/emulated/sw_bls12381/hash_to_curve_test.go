@@ -0,0 +1,77 @@
+package sw_bls12381
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+var testDST = []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+
+type hashToG1Circuit struct {
+	Msg []uints.U8
+	Res G1Affine
+}
+
+func (c *hashToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return fmt.Errorf("new G1 struct: %w", err)
+	}
+	res, err := g1.HashToG1(c.Msg, testDST)
+	if err != nil {
+		return fmt.Errorf("hash to G1: %w", err)
+	}
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestHashToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, msg := range []string{"", "abc"} {
+		assert.Run(func(assert *test.Assert) {
+			res, err := bls12381.HashToG1([]byte(msg), testDST)
+			assert.NoError(err)
+			witness := hashToG1Circuit{
+				Msg: uints.NewU8Array([]byte(msg)),
+				Res: NewG1Affine(res),
+			}
+			err = test.IsSolved(&hashToG1Circuit{Msg: make([]uints.U8, len(msg))}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("msg=%q", msg))
+	}
+}
+
+type hashToG2Circuit struct {
+	Msg []uints.U8
+	Res G2Affine
+}
+
+func (c *hashToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res, err := g2.HashToG2(c.Msg, testDST)
+	if err != nil {
+		return fmt.Errorf("hash to G2: %w", err)
+	}
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestHashToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	res, err := bls12381.HashToG2(msg, testDST)
+	assert.NoError(err)
+	witness := hashToG2Circuit{
+		Msg: uints.NewU8Array(msg),
+		Res: NewG2Affine(res),
+	}
+	err = test.IsSolved(&hashToG2Circuit{Msg: make([]uints.U8, len(msg))}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
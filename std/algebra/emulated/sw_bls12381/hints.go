@@ -0,0 +1,58 @@
+package sw_bls12381
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		mapToCurve1Hint,
+		mapToCurve2Hint,
+	}
+}
+
+// mapToCurve1Hint returns the y-coordinate of the SSWU map of the input on the
+// curve isogenous to G1.
+func mapToCurve1Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var u fp.Element
+
+			u.SetBigInt(inputs[0])
+
+			q := bls12381.MapToCurve1(&u)
+
+			q.Y.BigInt(outputs[0])
+
+			return nil
+		})
+}
+
+// mapToCurve2Hint returns the y-coordinate of the SSWU map of the input on the
+// curve isogenous to G2.
+func mapToCurve2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var u bls12381.E2
+
+			u.A0.SetBigInt(inputs[0])
+			u.A1.SetBigInt(inputs[1])
+
+			q := bls12381.MapToCurve2(&u)
+
+			q.Y.A0.BigInt(outputs[0])
+			q.Y.A1.BigInt(outputs[1])
+
+			return nil
+		})
+}
@@ -0,0 +1,168 @@
+package sw_bls12381
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// Coefficients (A0, A1) of the isogeny from the SSWU curve E' to the twist, in
+// increasing order of degree. The denominators are monic and the leading
+// coefficient is omitted. See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-E.3
+var g2IsogenyXNumerator = [][2]string{
+	{"889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542"},
+	{"0", "2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706522"},
+	{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706526", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853261"},
+	{"3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033", "0"},
+}
+
+var g2IsogenyXDenominator = [][2]string{
+	{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559715"},
+	{"12", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559775"},
+}
+
+var g2IsogenyYNumerator = [][2]string{
+	{"3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558", "3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558"},
+	{"0", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235518"},
+	{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706524", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853263"},
+	{"2816510427748580758331037284777117739799287910327449993381818688383577828123182200904113516794492504322962636245776", "0"},
+}
+
+var g2IsogenyYDenominator = [][2]string{
+	{"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355"},
+	{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559571"},
+	{"18", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559769"},
+}
+
+// MapToG2 maps u ∈ 𝔽p² to a point in G2. It is the circuit counterpart of the
+// native MapToG2 of gnark-crypto: it applies the simplified SWU map to the
+// isogenous curve E', the 3-isogeny to the twist and finally clears the
+// cofactor. u does not have to be reduced.
+//
+// The method is not complete: in the negligible probability case where the
+// isogeny or the cofactor clearing reaches the point at infinity, the circuit
+// has no solution.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-8.8.2
+func (g2 *G2) MapToG2(u *fields_bls12381.E2) *G2Affine {
+	q := g2.mapToCurve2(u)
+	q = g2.isogeny(q)
+	return g2.clearCofactor(q)
+}
+
+// mapToCurve2 implements the simplified SWU map to the curve E': y² = x³ + A'x + B'
+// isogenous to the twist. The y-coordinate is computed out-of-circuit and
+// constrained.
+func (g2 *G2) mapToCurve2(u *fields_bls12381.E2) *G2Affine {
+	sswuA := newE2("0", "240")
+	sswuB := newE2("1012", "1012")
+	// Z = -(2 + i)
+	sswuZ := newE2("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559785", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786")
+
+	// tv1 = Z * u²
+	tv1 := g2.Ext2.Square(u)
+	tv1 = g2.Ext2.Mul(tv1, sswuZ)
+	// tv2 = tv1² + tv1
+	tv2 := g2.Ext2.Square(tv1)
+	tv2 = g2.Ext2.Add(tv2, tv1)
+	// exceptional case when tv2 = 0
+	isExceptional := g2.Ext2.IsZero(tv2)
+	// x1 = B * (tv2 + 1) / (A * CMOV(-tv2, Z, tv2 == 0))
+	num := g2.Ext2.Add(tv2, g2.Ext2.One())
+	num = g2.Ext2.Mul(num, sswuB)
+	den := g2.Ext2.Select(isExceptional, sswuZ, g2.Ext2.Neg(tv2))
+	den = g2.Ext2.Mul(den, sswuA)
+	x1 := g2.Ext2.DivUnchecked(num, den)
+	// x2 = tv1 * x1
+	x2 := g2.Ext2.Mul(tv1, x1)
+	// gx1 = x1³ + A * x1 + B and gx2 = x2³ + A * x2 + B
+	gx1 := g2.evalCurve(x1, sswuA, sswuB)
+	gx2 := g2.evalCurve(x2, sswuA, sswuB)
+
+	hint, err := g2.fp.NewHint(mapToCurve2Hint, 2, &u.A0, &u.A1)
+	if err != nil {
+		panic(fmt.Sprintf("map to curve hint: %v", err))
+	}
+	y := &fields_bls12381.E2{A0: *hint[0], A1: *hint[1]}
+
+	// y² = gx1 if gx1 is square and y² = gx2 otherwise. gx1 and gx2 cannot be
+	// both squares as gx2 = Z³u⁶ gx1 and Z is a non-square.
+	y2 := g2.Ext2.Square(y)
+	isGx1Square := g2.Ext2.IsZero(g2.Ext2.Sub(y2, gx1))
+	g2.Ext2.AssertIsEqual(y2, g2.Ext2.Select(isGx1Square, gx1, gx2))
+	// in the exceptional case gx1 is always a square
+	g2.api.AssertIsEqual(g2.api.Mul(isExceptional, g2.api.Sub(1, isGx1Square)), 0)
+	x := g2.Ext2.Select(isGx1Square, x1, x2)
+
+	// sgn0(u) = sgn0(y)
+	g2.api.AssertIsEqual(g2.sgn0(u), g2.sgn0(y))
+
+	return &G2Affine{
+		P: g2AffP{
+			X: *x,
+			Y: *y,
+		},
+	}
+}
+
+// evalCurve returns x³ + a * x + b.
+func (g2 *G2) evalCurve(x, a, b *fields_bls12381.E2) *fields_bls12381.E2 {
+	res := g2.Ext2.Square(x)
+	res = g2.Ext2.Add(res, a)
+	res = g2.Ext2.Mul(res, x)
+	return g2.Ext2.Add(res, b)
+}
+
+// sgn0 returns the sign of x as defined in
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-4.1, i.e.
+// sgn0(x.A0) OR (x.A0 == 0 AND sgn0(x.A1)).
+func (g2 *G2) sgn0(x *fields_bls12381.E2) frontend.Variable {
+	a0 := g2.fp.Reduce(&x.A0)
+	g2.fp.AssertIsInRange(a0)
+	a1 := g2.fp.Reduce(&x.A1)
+	g2.fp.AssertIsInRange(a1)
+	sign0 := g2.fp.ToBits(a0)[0]
+	zero0 := g2.fp.IsZero(a0)
+	sign1 := g2.fp.ToBits(a1)[0]
+	return g2.api.Or(sign0, g2.api.And(zero0, sign1))
+}
+
+// isogeny maps a point from E' to the twist using the 3-isogeny.
+func (g2 *G2) isogeny(q *G2Affine) *G2Affine {
+	xn := g2.evalPolynomial(false, g2IsogenyXNumerator, &q.P.X)
+	xd := g2.evalPolynomial(true, g2IsogenyXDenominator, &q.P.X)
+	yn := g2.evalPolynomial(false, g2IsogenyYNumerator, &q.P.X)
+	yn = g2.Ext2.Mul(yn, &q.P.Y)
+	yd := g2.evalPolynomial(true, g2IsogenyYDenominator, &q.P.X)
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.DivUnchecked(xn, xd),
+			Y: *g2.Ext2.DivUnchecked(yn, yd),
+		},
+	}
+}
+
+// evalPolynomial evaluates the polynomial with the given coefficients at x
+// using Horner's method. If monic is set, then the leading coefficient 1 is
+// implicit.
+func (g2 *G2) evalPolynomial(monic bool, coefficients [][2]string, x *fields_bls12381.E2) *fields_bls12381.E2 {
+	last := coefficients[len(coefficients)-1]
+	res := newE2(last[0], last[1])
+	if monic {
+		res = g2.Ext2.Add(res, x)
+	}
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		res = g2.Ext2.Mul(res, x)
+		res = g2.Ext2.Add(res, newE2(coefficients[i][0], coefficients[i][1]))
+	}
+	return res
+}
+
+func newE2(a0, a1 string) *fields_bls12381.E2 {
+	return &fields_bls12381.E2{
+		A0: emulated.ValueOf[BaseField](a0),
+		A1: emulated.ValueOf[BaseField](a1),
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/math/emulated"
@@ -21,6 +22,9 @@ type Pairing struct {
 	g1     *G1
 	bTwist *fields_bls12381.E2
 	g2gen  *G2Affine
+	// complete is set when the pairing accepts the point at infinity (0,0)
+	// in G1, see [algopts.WithCompleteArithmetic].
+	complete bool
 }
 
 type GTEl = fields_bls12381.E12
@@ -58,7 +62,14 @@ func NewGTEl(v bls12381.GT) GTEl {
 	}
 }
 
-func NewPairing(api frontend.API) (*Pairing, error) {
+// NewPairing returns a new BLS12-381 pairing. With the option
+// [algopts.WithCompleteArithmetic], the pairing accepts the point at infinity
+// (0,0) in G1 at the cost of additional constraints.
+func NewPairing(api frontend.API, opts ...algopts.AlgebraOption) (*Pairing, error) {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new config: %w", err)
+	}
 	ba, err := emulated.NewField[BaseField](api)
 	if err != nil {
 		return nil, fmt.Errorf("new base api: %w", err)
@@ -76,13 +87,14 @@ func NewPairing(api frontend.API) (*Pairing, error) {
 		return nil, fmt.Errorf("new G1 struct: %w", err)
 	}
 	return &Pairing{
-		api:    api,
-		Ext12:  fields_bls12381.NewExt12(api),
-		curveF: ba,
-		curve:  curve,
-		g1:     g1,
-		g2:     NewG2(api),
-		bTwist: &bTwist,
+		api:      api,
+		Ext12:    fields_bls12381.NewExt12(api),
+		curveF:   ba,
+		curve:    curve,
+		g1:       g1,
+		g2:       NewG2(api),
+		bTwist:   &bTwist,
+		complete: cfg.CompleteArithmetic,
 	}, nil
 }
 
@@ -236,7 +248,9 @@ func (pr Pairing) Pair(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
 	if err != nil {
 		return nil, fmt.Errorf("miller loop: %w", err)
 	}
-	res = pr.finalExponentiation(res, len(P) == 1)
+	// when all the points P are (0,0), the Miller loop result is in E2, so we
+	// need the safe final exponentiation even for a single pair.
+	res = pr.finalExponentiation(res, len(P) == 1 && !pr.complete)
 	return res, nil
 }
 
@@ -284,13 +298,13 @@ func (pr Pairing) AssertIsOnG1(P *G1Affine) {
 	pr.AssertIsOnCurve(P)
 
 	// 2- Check P has the right subgroup order
-	// TODO: add phi and scalarMulBySeedSquare to g1.go
-	// [x²]ϕ(P)
+	// [x²]ϕ(P). We can not use the GLV scalar multiplication here as it
+	// assumes that ϕ acts as a multiplication by the eigenvalue, which holds
+	// only in the subgroup.
 	phiP := pr.g1.phi(P)
-	seedSquare := emulated.ValueOf[ScalarField]("228988810152649578064853576960394133504")
-	// TODO: use addchain to construct a fixed-scalar ScalarMul
-	_P := pr.curve.ScalarMul(phiP, &seedSquare)
-	_P = pr.curve.Neg(_P)
+	_P := pr.g1.scalarMulBySeed(phiP)
+	_P = pr.g1.scalarMulBySeed(_P)
+	_P = pr.g1.neg(_P)
 
 	// [r]Q == 0 <==>  P = -[x²]ϕ(P)
 	pr.curve.AssertIsEqual(_P, P)
@@ -324,6 +338,11 @@ var loopCounter = [64]int8{
 
 // MillerLoop computes the multi-Miller loop
 // ∏ᵢ { fᵢ_{u,Q}(P) }
+//
+// When the pairing is initialized with [algopts.WithCompleteArithmetic], then
+// P can be (0,0), in which case the pair does not contribute to the reduced
+// pairing. When all P are (0,0), then the result lies in E2, which is handled
+// by the final exponentiation in [Pairing.Pair].
 func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
 
 	// check input size match
@@ -355,12 +374,27 @@ func (pr Pairing) millerLoopLines(P []*G1Affine, lines []lineEvaluations) (*GTEl
 	// precomputations
 	yInv := make([]*emulated.Element[BaseField], n)
 	xNegOverY := make([]*emulated.Element[BaseField], n)
+	var isInfinity []frontend.Variable
+	if pr.complete {
+		isInfinity = make([]frontend.Variable, n)
+	}
 
 	for k := 0; k < n; k++ {
 		// P are supposed to be on G1 respectively of prime order r.
 		// The point (x,0) is of order 2. But this function does not check
 		// subgroup membership.
-		yInv[k] = pr.curveF.Inverse(&P[k].Y)
+		if pr.complete {
+			// If P=(0,0), then we set yInv=xNegOverY=0. All the line
+			// evaluations are then w³, so the contribution of the pair lies
+			// in a proper subfield of E12 and vanishes after the final
+			// exponentiation, i.e. e(0,Q)=1. We skip the last line (i=0) so
+			// that the contribution is an even power of w³, which lies in E2.
+			isInfinity[k] = pr.api.And(pr.curveF.IsZero(&P[k].X), pr.curveF.IsZero(&P[k].Y))
+			y := pr.curveF.Select(isInfinity[k], pr.curveF.One(), &P[k].Y)
+			yInv[k] = pr.curveF.Select(isInfinity[k], pr.curveF.Zero(), pr.curveF.Inverse(y))
+		} else {
+			yInv[k] = pr.curveF.Inverse(&P[k].Y)
+		}
 		xNegOverY[k] = pr.curveF.MulMod(&P[k].X, yInv[k])
 		xNegOverY[k] = pr.curveF.Neg(xNegOverY[k])
 	}
@@ -412,7 +446,13 @@ func (pr Pairing) millerLoopLines(P []*G1Affine, lines []lineEvaluations) (*GTEl
 		res = pr.Square(res)
 
 		for k := 0; k < n; k++ {
-			if loopCounter[i] == 0 {
+			if i == 0 && pr.complete {
+				// loopCounter[0] == 0
+				res = pr.Select(isInfinity[k], res, pr.MulBy014(res,
+					pr.MulByElement(&lines[k][0][i].R1, yInv[k]),
+					pr.MulByElement(&lines[k][0][i].R0, xNegOverY[k]),
+				))
+			} else if loopCounter[i] == 0 {
 				res = pr.MulBy014(res,
 					pr.MulByElement(&lines[k][0][i].R1, yInv[k]),
 					pr.MulByElement(&lines[k][0][i].R0, xNegOverY[k]),
@@ -8,10 +8,12 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/algebra/algopts"
 	"github.com/consensys/gnark/test"
 )
 
@@ -200,6 +202,59 @@ func TestPairingCheckTestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type PairingCheckCompleteCircuit struct {
+	InG1 []G1Affine
+	InG2 []G2Affine
+}
+
+func (c *PairingCheckCompleteCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api, algopts.WithCompleteArithmetic())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	P := make([]*G1Affine, len(c.InG1))
+	Q := make([]*G2Affine, len(c.InG2))
+	for i := range c.InG1 {
+		P[i] = &c.InG1[i]
+		Q[i] = &c.InG2[i]
+	}
+	return pairing.PairingCheck(P, Q)
+}
+
+func TestPairingCheckCompleteTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines()
+	_, q2 := randomG1G2Affines()
+	var p2, inf bls12381.G1Affine
+	p2.Neg(&p1)
+	for _, tc := range []struct {
+		name  string
+		P     []bls12381.G1Affine
+		Q     []bls12381.G2Affine
+		valid bool
+	}{
+		{"infinity", []bls12381.G1Affine{inf}, []bls12381.G2Affine{q1}, true},
+		{"all-infinity", []bls12381.G1Affine{inf, inf}, []bls12381.G2Affine{q1, q2}, true},
+		{"some-infinity", []bls12381.G1Affine{p1, inf, p2}, []bls12381.G2Affine{q1, q2, q1}, true},
+		{"not-one", []bls12381.G1Affine{p1, inf}, []bls12381.G2Affine{q1, q2}, false},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			circuit := PairingCheckCompleteCircuit{InG1: make([]G1Affine, len(tc.P)), InG2: make([]G2Affine, len(tc.Q))}
+			witness := PairingCheckCompleteCircuit{InG1: make([]G1Affine, len(tc.P)), InG2: make([]G2Affine, len(tc.Q))}
+			for i := range tc.P {
+				witness.InG1[i] = NewG1Affine(tc.P[i])
+				witness.InG2[i] = NewG2Affine(tc.Q[i])
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			if tc.valid {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		}, tc.name)
+	}
+}
+
 type GroupMembershipCircuit struct {
 	InG1 G1Affine
 	InG2 G2Affine
@@ -224,6 +279,21 @@ func TestGroupMembershipSolve(t *testing.T) {
 	}
 	err := test.IsSolved(&GroupMembershipCircuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
+
+	// point on the curve, but not in the subgroup
+	var b fp.Element
+	b.SetUint64(4)
+	for {
+		var rhs fp.Element
+		p.X.SetRandom()
+		rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &b)
+		if p.Y.Sqrt(&rhs) != nil && !p.IsInSubGroup() {
+			break
+		}
+	}
+	witness.InG1 = NewG1Affine(p)
+	err = test.IsSolved(&GroupMembershipCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
 }
 
 // bench
@@ -0,0 +1,60 @@
+package sw_bn254
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToG1Circuit struct {
+	U   emulated.Element[BaseField]
+	Res G1Affine
+}
+
+func (c *mapToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return fmt.Errorf("new G1 struct: %w", err)
+	}
+	res := g1.MapToG1(&c.U)
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestMapToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var rnd fp.Element
+	rnd.SetRandom()
+	for i, u := range []fp.Element{rnd, fp.NewElement(0), fp.NewElement(1), fp.NewElement(2)} {
+		assert.Run(func(assert *test.Assert) {
+			res := bn254.MapToG1(u)
+			witness := mapToG1Circuit{
+				U:   emulated.ValueOf[BaseField](u),
+				Res: NewG1Affine(res),
+			}
+			err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+func TestMapToG1WrongSign(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u fp.Element
+	u.SetRandom()
+	res := bn254.MapToG1(u)
+	res.Neg(&res)
+	witness := mapToG1Circuit{
+		U:   emulated.ValueOf[BaseField](u),
+		Res: NewG1Affine(res),
+	}
+	err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
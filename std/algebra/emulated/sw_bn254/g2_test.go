@@ -1,12 +1,14 @@
 package sw_bn254
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
 	"github.com/consensys/gnark/test"
 )
 
@@ -37,6 +39,41 @@ func TestAddG2TestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type addUnifiedG2Circuit struct {
+	In1, In2 G2Affine
+	Res      G2Affine
+}
+
+func (c *addUnifiedG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.AddUnified(&c.In1, &c.In2)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestAddUnifiedG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in1 := randomG1G2Affines()
+	_, in2 := randomG1G2Affines()
+	var neg, inf bn254.G2Affine
+	neg.Neg(&in1)
+	for i, tc := range []struct{ p, q bn254.G2Affine }{
+		{in1, in2}, {in1, in1}, {in1, neg}, {inf, in2}, {in1, inf}, {inf, inf},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var res bn254.G2Affine
+			res.Add(&tc.p, &tc.q)
+			witness := addUnifiedG2Circuit{
+				In1: NewG2Affine(tc.p),
+				In2: NewG2Affine(tc.q),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&addUnifiedG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
 type doubleG2Circuit struct {
 	In1 G2Affine
 	Res G2Affine
@@ -142,3 +179,34 @@ func TestEndomorphismG2TestSolve(t *testing.T) {
 	err := test.IsSolved(&endomorphismG2Circuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type mapToG2Circuit struct {
+	U   fields_bn254.E2
+	Res G2Affine
+}
+
+func (c *mapToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.MapToG2(&c.U)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var rnd, rndA0, rndA1 bn254.E2
+	rnd.SetRandom()
+	rndA0.A0.SetRandom()
+	rndA1.A1.SetRandom()
+	for i, u := range []bn254.E2{rnd, rndA0, rndA1, {}} {
+		assert.Run(func(assert *test.Assert) {
+			res := bn254.MapToG2(u)
+			witness := mapToG2Circuit{
+				U:   fields_bn254.FromE2(&u),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&mapToG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
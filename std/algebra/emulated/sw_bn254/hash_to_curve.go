@@ -0,0 +1,81 @@
+package sw_bn254
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	"github.com/consensys/gnark/std/hash/expand"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// HashToG1 hashes a message to a point in G1 using the SVDW map. It is the
+// circuit counterpart of the native HashToG1 of gnark-crypto. dst is the
+// domain separation tag and is fixed at compile time.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func (g1 *G1) HashToG1(msg []uints.U8, dst []byte) (*G1Affine, error) {
+	u, err := hashToFp(g1.api, g1.curveF, msg, dst, 2)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g1.MapToG1(u[0])
+	q1 := g1.MapToG1(u[1])
+	return g1.add(q0, q1), nil
+}
+
+// HashToG2 hashes a message to a point in G2 using the SVDW map. It is the
+// circuit counterpart of the native HashToG2 of gnark-crypto. dst is the
+// domain separation tag and is fixed at compile time.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func (g2 *G2) HashToG2(msg []uints.U8, dst []byte) (*G2Affine, error) {
+	u, err := hashToFp(g2.api, g2.fp, msg, dst, 4)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g2.mapToCurve2(&fields_bn254.E2{A0: *u[0], A1: *u[1]})
+	q1 := g2.mapToCurve2(&fields_bn254.E2{A0: *u[2], A1: *u[3]})
+	return g2.clearCofactor(g2.add(q0, q1)), nil
+}
+
+// hashToFp hashes msg to count elements of 𝔽p using the hash_to_field method
+// with expand_message_xmd instantiated with SHA2-256.
+func hashToFp(api frontend.API, f *emulated.Field[BaseField], msg []uints.U8, dst []byte, count int) ([]*emulated.Element[BaseField], error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) with the security parameter k = 128
+	const L = 48
+	uniformBytes, err := expand.ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	// each chunk is interpreted as hi * 2²⁵⁶ + lo where lo are the last 32
+	// bytes so that both parts fit in an element.
+	var shift big.Int
+	shift.Lsh(big.NewInt(1), 256)
+	shiftEl := emulated.ValueOf[BaseField](shift.Mod(&shift, BaseField{}.Modulus()))
+	res := make([]*emulated.Element[BaseField], count)
+	for i := range res {
+		chunk := uniformBytes[i*L : (i+1)*L]
+		hi := bytesToElement(api, f, chunk[:L-32])
+		lo := bytesToElement(api, f, chunk[L-32:])
+		res[i] = f.Add(f.Mul(hi, &shiftEl), lo)
+	}
+	return res, nil
+}
+
+// bytesToElement returns the element represented by the big-endian bytes bs.
+// The bytes must fit in the limbs of the element.
+func bytesToElement(api frontend.API, f *emulated.Field[BaseField], bs []uints.U8) *emulated.Element[BaseField] {
+	nbBits := int(BaseField{}.NbLimbs() * BaseField{}.BitsPerLimb())
+	bits := make([]frontend.Variable, nbBits)
+	for i := range bs {
+		bbits := api.ToBinary(bs[len(bs)-1-i].Val, 8)
+		copy(bits[8*i:], bbits)
+	}
+	for i := 8 * len(bs); i < nbBits; i++ {
+		bits[i] = 0
+	}
+	return f.FromBits(bits...)
+}
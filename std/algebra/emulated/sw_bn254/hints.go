@@ -0,0 +1,93 @@
+package sw_bn254
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		mapToCurve1Hint,
+		mapToCurve2Hint,
+	}
+}
+
+// mapToCurve1Hint returns the y-coordinate of the SVDW map of the input on G1
+// and the square roots of -gx1 and -gx2 proving that gx1 and gx2 are not
+// squares when they are not used. The roots are set to zero otherwise.
+func mapToCurve1Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var u, gx1, gx2, s fp.Element
+
+			u.SetBigInt(inputs[0])
+			gx1.SetBigInt(inputs[1])
+			gx2.SetBigInt(inputs[2])
+
+			q := bn254.MapToCurve1(&u)
+			q.Y.BigInt(outputs[0])
+
+			outputs[1].SetUint64(0)
+			outputs[2].SetUint64(0)
+			if gx1.Legendre() == -1 {
+				s.Neg(&gx1)
+				s.Sqrt(&s)
+				s.BigInt(outputs[1])
+				if gx2.Legendre() == -1 {
+					s.Neg(&gx2)
+					s.Sqrt(&s)
+					s.BigInt(outputs[2])
+				}
+			}
+
+			return nil
+		})
+}
+
+// mapToCurve2Hint returns the y-coordinate of the SVDW map of the input on the
+// twist and the square roots of ξ*gx1 and ξ*gx2 proving that gx1 and gx2 are
+// not squares when they are not used. The roots are set to zero otherwise.
+func mapToCurve2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var u, gx1, gx2, s bn254.E2
+
+			u.A0.SetBigInt(inputs[0])
+			u.A1.SetBigInt(inputs[1])
+			gx1.A0.SetBigInt(inputs[2])
+			gx1.A1.SetBigInt(inputs[3])
+			gx2.A0.SetBigInt(inputs[4])
+			gx2.A1.SetBigInt(inputs[5])
+
+			q := bn254.MapToCurve2(&u)
+			q.Y.A0.BigInt(outputs[0])
+			q.Y.A1.BigInt(outputs[1])
+
+			for i := 2; i < 6; i++ {
+				outputs[i].SetUint64(0)
+			}
+			if gx1.Legendre() == -1 {
+				s.MulByNonResidue(&gx1)
+				s.Sqrt(&s)
+				s.A0.BigInt(outputs[2])
+				s.A1.BigInt(outputs[3])
+				if gx2.Legendre() == -1 {
+					s.MulByNonResidue(&gx2)
+					s.Sqrt(&s)
+					s.A0.BigInt(outputs[4])
+					s.A1.BigInt(outputs[5])
+				}
+			}
+
+			return nil
+		})
+}
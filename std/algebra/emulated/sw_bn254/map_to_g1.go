@@ -0,0 +1,97 @@
+package sw_bn254
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// MapToG1 maps u ∈ 𝔽p to a point in G1. It is the circuit counterpart of the
+// native MapToG1 of gnark-crypto: it applies the Shallue-van de Woestijne map
+// to the curve. As G1 has prime order, there is no cofactor to clear. u does
+// not have to be reduced.
+//
+// The method is not complete: in the negligible probability case where u² is
+// a root of 1 - g(Z)u⁴, the circuit has no solution.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.1
+func (g1 *G1) MapToG1(u *emulated.Element[BaseField]) *G1Affine {
+	// c1 = g(Z), c2 = -Z / 2, c3 = sqrt(-g(Z) * 3 * Z²), c4 = -4 * g(Z) / (3 * Z²)
+	// with Z = 1.
+	c1 := emulated.ValueOf[BaseField](4)
+	c2 := emulated.ValueOf[BaseField]("10944121435919637611123202872628637544348155578648911831344518947322613104291")
+	c3 := emulated.ValueOf[BaseField]("8815841940592487685674414971303048083897117035520822607866")
+	c4 := emulated.ValueOf[BaseField]("7296080957279758407415468581752425029565437052432607887563012631548408736189")
+	b := emulated.ValueOf[BaseField](3)
+
+	// tv1 = u² * c1
+	tv1 := g1.curveF.Mul(u, u)
+	tv1 = g1.curveF.Mul(tv1, &c1)
+	// tv2 = 1 + tv1
+	tv2 := g1.curveF.Add(g1.curveF.One(), tv1)
+	// tv1 = 1 - tv1
+	tv1 = g1.curveF.Sub(g1.curveF.One(), tv1)
+	// tv3 = 1 / (tv1 * tv2)
+	tv3 := g1.curveF.Mul(tv1, tv2)
+	tv3 = g1.curveF.Inverse(tv3)
+	// tv4 = u * tv1 * tv3 * c3
+	tv4 := g1.curveF.Mul(u, tv1)
+	tv4 = g1.curveF.Mul(tv4, tv3)
+	tv4 = g1.curveF.Mul(tv4, &c3)
+	// x1 = c2 - tv4 and x2 = c2 + tv4
+	x1 := g1.curveF.Sub(&c2, tv4)
+	x2 := g1.curveF.Add(&c2, tv4)
+	// x3 = Z + c4 * (tv2² * tv3)²
+	x3 := g1.curveF.Mul(tv2, tv2)
+	x3 = g1.curveF.Mul(x3, tv3)
+	x3 = g1.curveF.Mul(x3, x3)
+	x3 = g1.curveF.Mul(x3, &c4)
+	x3 = g1.curveF.Add(x3, g1.curveF.One())
+	// gxi = xi³ + B
+	gx1 := g1.evalCurve(x1, &b)
+	gx2 := g1.evalCurve(x2, &b)
+	gx3 := g1.evalCurve(x3, &b)
+
+	hint, err := g1.curveF.NewHint(mapToCurve1Hint, 3, u, gx1, gx2)
+	if err != nil {
+		panic(fmt.Sprintf("map to curve hint: %v", err))
+	}
+	y, s1, s2 := hint[0], hint[1], hint[2]
+
+	// The first of gx1, gx2, gx3 which is a square is selected. When gx1
+	// (resp. gx2) is not selected, the hint provides the square root s1 (resp.
+	// s2) of -gx1 (resp. -gx2) which proves that gx1 (resp. gx2) is not a
+	// square as -1 is not a square in 𝔽p.
+	y2 := g1.curveF.Mul(y, y)
+	isGx1Square := g1.curveF.IsZero(g1.curveF.Sub(y2, gx1))
+	isGx2Square := g1.curveF.IsZero(g1.curveF.Sub(y2, gx2))
+	g1.curveF.AssertIsEqual(y2, g1.curveF.Select(isGx1Square, gx1, g1.curveF.Select(isGx2Square, gx2, gx3)))
+	s1s1 := g1.curveF.Mul(s1, s1)
+	g1.curveF.AssertIsEqual(s1s1, g1.curveF.Select(isGx1Square, s1s1, g1.curveF.Neg(gx1)))
+	s2s2 := g1.curveF.Mul(s2, s2)
+	g1.curveF.AssertIsEqual(s2s2, g1.curveF.Select(g1.api.Or(isGx1Square, isGx2Square), s2s2, g1.curveF.Neg(gx2)))
+	x := g1.curveF.Select(isGx1Square, x1, g1.curveF.Select(isGx2Square, x2, x3))
+
+	// sgn0(u) = sgn0(y)
+	g1.api.AssertIsEqual(g1.sgn0(u), g1.sgn0(y))
+
+	return &G1Affine{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// evalCurve returns x³ + b.
+func (g1 *G1) evalCurve(x, b *emulated.Element[BaseField]) *emulated.Element[BaseField] {
+	res := g1.curveF.Mul(x, x)
+	res = g1.curveF.Mul(res, x)
+	return g1.curveF.Add(res, b)
+}
+
+// sgn0 returns the parity of the canonical representation of x.
+func (g1 *G1) sgn0(x *emulated.Element[BaseField]) frontend.Variable {
+	x = g1.curveF.Reduce(x)
+	g1.curveF.AssertIsInRange(x)
+	return g1.curveF.ToBits(x)[0]
+}
@@ -0,0 +1,126 @@
+package sw_bn254
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// MapToG2 maps u ∈ 𝔽p² to a point in G2. It is the circuit counterpart of the
+// native MapToG2 of gnark-crypto: it applies the Shallue-van de Woestijne map
+// to the twist and then clears the cofactor. u does not have to be reduced.
+//
+// The method is not complete: in the negligible probability case where u² is
+// a root of 1 - g(Z)u⁴ or the cofactor clearing reaches the point at infinity,
+// the circuit has no solution.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.1
+func (g2 *G2) MapToG2(u *fields_bn254.E2) *G2Affine {
+	q := g2.mapToCurve2(u)
+	return g2.clearCofactor(q)
+}
+
+// mapToCurve2 implements the Shallue-van de Woestijne map to the twist. The
+// y-coordinate is computed out-of-circuit and constrained.
+func (g2 *G2) mapToCurve2(u *fields_bn254.E2) *G2Affine {
+	// c1 = g(Z), c2 = -Z / 2, c3 = sqrt(-g(Z) * 3 * Z²), c4 = -4 * g(Z) / (3 * Z²)
+	// with Z = 1.
+	c1 := newE2("19485874751759354771024239261021720505790618469301721065564631296452457478374", "266929791119991161246907387137283842545076965332900288569378510910307636690")
+	c2 := newE2("10944121435919637611123202872628637544348155578648911831344518947322613104291", "0")
+	c3 := newE2("18992192239972082890849143911285057164064277369389217330423471574879236301292", "21819008332247140148575583693947636719449476128975323941588917397607662637108")
+	c4 := newE2("10499238450719652342378357227399831140106360636427411350395554762472100376473", "6940174569119770192419592065569379906172001098655407502803841283667998553941")
+	// B = 3 / (9 + u)
+	b := newE2("19485874751759354771024239261021720505790618469301721065564631296452457478373", "266929791119991161246907387137283842545076965332900288569378510910307636690")
+	// ξ = 9 + u is not a square in 𝔽p²
+	xi := newE2("9", "1")
+
+	// tv1 = u² * c1
+	tv1 := g2.Ext2.Square(u)
+	tv1 = g2.Ext2.Mul(tv1, c1)
+	// tv2 = 1 + tv1
+	tv2 := g2.Ext2.Add(g2.Ext2.One(), tv1)
+	// tv1 = 1 - tv1
+	tv1 = g2.Ext2.Sub(g2.Ext2.One(), tv1)
+	// tv3 = 1 / (tv1 * tv2)
+	tv3 := g2.Ext2.Mul(tv1, tv2)
+	tv3 = g2.Ext2.Inverse(tv3)
+	// tv4 = u * tv1 * tv3 * c3
+	tv4 := g2.Ext2.Mul(u, tv1)
+	tv4 = g2.Ext2.Mul(tv4, tv3)
+	tv4 = g2.Ext2.Mul(tv4, c3)
+	// x1 = c2 - tv4 and x2 = c2 + tv4
+	x1 := g2.Ext2.Sub(c2, tv4)
+	x2 := g2.Ext2.Add(c2, tv4)
+	// x3 = Z + c4 * (tv2² * tv3)²
+	x3 := g2.Ext2.Square(tv2)
+	x3 = g2.Ext2.Mul(x3, tv3)
+	x3 = g2.Ext2.Square(x3)
+	x3 = g2.Ext2.Mul(x3, c4)
+	x3 = g2.Ext2.Add(x3, g2.Ext2.One())
+	// gxi = xi³ + B
+	gx1 := g2.evalCurve(x1, b)
+	gx2 := g2.evalCurve(x2, b)
+	gx3 := g2.evalCurve(x3, b)
+
+	hint, err := g2.fp.NewHint(mapToCurve2Hint, 6, &u.A0, &u.A1, &gx1.A0, &gx1.A1, &gx2.A0, &gx2.A1)
+	if err != nil {
+		panic(fmt.Sprintf("map to curve hint: %v", err))
+	}
+	y := &fields_bn254.E2{A0: *hint[0], A1: *hint[1]}
+	s1 := &fields_bn254.E2{A0: *hint[2], A1: *hint[3]}
+	s2 := &fields_bn254.E2{A0: *hint[4], A1: *hint[5]}
+
+	// The first of gx1, gx2, gx3 which is a square is selected. When gx1
+	// (resp. gx2) is not selected, the hint provides the square root s1 (resp.
+	// s2) of ξ * gx1 (resp. ξ * gx2) which proves that gx1 (resp. gx2) is not
+	// a square.
+	y2 := g2.Ext2.Square(y)
+	isGx1Square := g2.Ext2.IsZero(g2.Ext2.Sub(y2, gx1))
+	isGx2Square := g2.Ext2.IsZero(g2.Ext2.Sub(y2, gx2))
+	g2.Ext2.AssertIsEqual(y2, g2.Ext2.Select(isGx1Square, gx1, g2.Ext2.Select(isGx2Square, gx2, gx3)))
+	s1s1 := g2.Ext2.Square(s1)
+	g2.Ext2.AssertIsEqual(s1s1, g2.Ext2.Select(isGx1Square, s1s1, g2.Ext2.Mul(xi, gx1)))
+	s2s2 := g2.Ext2.Square(s2)
+	g2.Ext2.AssertIsEqual(s2s2, g2.Ext2.Select(g2.api.Or(isGx1Square, isGx2Square), s2s2, g2.Ext2.Mul(xi, gx2)))
+	x := g2.Ext2.Select(isGx1Square, x1, g2.Ext2.Select(isGx2Square, x2, x3))
+
+	// sgn0(u) = sgn0(y)
+	g2.api.AssertIsEqual(g2.sgn0(u), g2.sgn0(y))
+
+	return &G2Affine{
+		P: g2AffP{
+			X: *x,
+			Y: *y,
+		},
+	}
+}
+
+// evalCurve returns x³ + b.
+func (g2 *G2) evalCurve(x, b *fields_bn254.E2) *fields_bn254.E2 {
+	res := g2.Ext2.Square(x)
+	res = g2.Ext2.Mul(res, x)
+	return g2.Ext2.Add(res, b)
+}
+
+// sgn0 returns the sign of x as defined in
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-4.1, i.e.
+// sgn0(x.A0) OR (x.A0 == 0 AND sgn0(x.A1)).
+func (g2 *G2) sgn0(x *fields_bn254.E2) frontend.Variable {
+	a0 := g2.fp.Reduce(&x.A0)
+	g2.fp.AssertIsInRange(a0)
+	a1 := g2.fp.Reduce(&x.A1)
+	g2.fp.AssertIsInRange(a1)
+	sign0 := g2.fp.ToBits(a0)[0]
+	zero0 := g2.fp.IsZero(a0)
+	sign1 := g2.fp.ToBits(a1)[0]
+	return g2.api.Or(sign0, g2.api.And(zero0, sign1))
+}
+
+func newE2(a0, a1 string) *fields_bn254.E2 {
+	return &fields_bn254.E2{
+		A0: emulated.ValueOf[BaseField](a0),
+		A1: emulated.ValueOf[BaseField](a1),
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Package te_emulated implements elliptic curve group operations in twisted
+Edwards form.
+
+The elliptic curve is the set of points (X,Y) satisfying the equation:
+
+	aX² + Y² = 1 + dX²Y²
+
+over some base field 𝐅p for some constants a, d ∈ 𝐅p. Additionally, for every
+curve we also define its generator (base point) G. All these parameters are
+stored in the variable of type [CurveParams].
+
+When a is a square and d is not a square in 𝐅p, the addition law is complete,
+i.e. it is correct for all inputs including the identity element (0,1) and
+equal points. This is the case for the curves defined in this package, so the
+package exposes only complete group operations.
+
+The package provides the curve parameters for Ed25519, see function
+[GetEd25519Params]. Similarly to the package [sw_emulated], the base field of
+the points is defined by the type parameters and the coefficients of the curve
+by variables. The method [GetCurveParams] allows to resolve the curve
+parameters depending on the type parameter defining the base field.
+
+This package uses field emulation (unlike package
+[github.com/consensys/gnark/std/algebra/native/twistededwards], which requires
+the base field of the curve to be the native field). This allows to use any
+curve over any native (SNARK) field. The drawback of this approach is the
+extreme cost of the operations.
+*/
+package te_emulated
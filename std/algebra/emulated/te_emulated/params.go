@@ -0,0 +1,59 @@
+package te_emulated
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// CurveParams defines parameters of an elliptic curve in twisted Edwards form
+// given by the equation
+//
+//	aX² + Y² = 1 + dX²Y²
+//
+// The base point is defined by (Gx, Gy) and generates the prime-order subgroup
+// of the curve. The order of the curve is Cofactor times the order of the
+// subgroup.
+type CurveParams struct {
+	A        *big.Int // a in curve equation
+	D        *big.Int // d in curve equation
+	Gx       *big.Int // base point x
+	Gy       *big.Int // base point y
+	Cofactor *big.Int // cofactor of the curve
+}
+
+// GetEd25519Params returns the curve parameters for the curve Ed25519 as
+// defined in RFC 8032. When initialising new curve, use the base field
+// [emulated.Ed25519Fp] and scalar field [emulated.Ed25519Fr].
+func GetEd25519Params() CurveParams {
+	a, _ := new(big.Int).SetString("57896044618658097711785492504343953926634992332820282019728792003956564819948", 10)
+	d, _ := new(big.Int).SetString("37095705934669439343138083508754565189542113879843219016388785533085940283555", 10)
+	gx, _ := new(big.Int).SetString("15112221349535400772501151409588531511454012693041857206046113283949847762202", 10)
+	gy, _ := new(big.Int).SetString("46316835694926478169428394003475163141307993866256225615783033603165251855960", 10)
+	return CurveParams{
+		A:        a,
+		D:        d,
+		Gx:       gx,
+		Gy:       gy,
+		Cofactor: big.NewInt(8),
+	}
+}
+
+// GetCurveParams returns suitable curve parameters given the parametric type
+// Base as base field. It caches the parameters and modifying the values in the
+// parameters struct leads to undefined behaviour.
+func GetCurveParams[Base emulated.FieldParams]() CurveParams {
+	var t Base
+	switch t.Modulus().String() {
+	case emulated.Ed25519Fp{}.Modulus().String():
+		return ed25519Params
+	default:
+		panic("no stored parameters")
+	}
+}
+
+var ed25519Params CurveParams
+
+func init() {
+	ed25519Params = GetEd25519Params()
+}
@@ -0,0 +1,274 @@
+package te_emulated
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// New returns a new [Curve] instance over the base field Base and scalar field
+// Scalars defined by the curve parameters params. It returns an error if
+// initialising the field emulation fails (for example, when the native field is
+// too small).
+func New[Base, Scalars emulated.FieldParams](api frontend.API, params CurveParams) (*Curve[Base, Scalars], error) {
+	ba, err := emulated.NewField[Base](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	sa, err := emulated.NewField[Scalars](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar api: %w", err)
+	}
+	if params.Cofactor == nil || params.Cofactor.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid cofactor")
+	}
+	return &Curve[Base, Scalars]{
+		params:    params,
+		api:       api,
+		baseApi:   ba,
+		scalarApi: sa,
+		g: AffinePoint[Base]{
+			X: emulated.ValueOf[Base](params.Gx),
+			Y: emulated.ValueOf[Base](params.Gy),
+		},
+		a: emulated.ValueOf[Base](params.A),
+		d: emulated.ValueOf[Base](params.D),
+	}, nil
+}
+
+// Curve is an initialised curve which allows performing group operations.
+type Curve[Base, Scalars emulated.FieldParams] struct {
+	// params is the parameters of the curve
+	params CurveParams
+	// api is the native api, we construct it ourselves to be sure
+	api frontend.API
+	// baseApi is the api for point operations
+	baseApi *emulated.Field[Base]
+	// scalarApi is the api for scalar operations
+	scalarApi *emulated.Field[Scalars]
+
+	// g is the generator (base point) of the curve.
+	g AffinePoint[Base]
+
+	a emulated.Element[Base]
+	d emulated.Element[Base]
+}
+
+// AffinePoint represents a point on the elliptic curve. We do not check that
+// the point is actually on the curve.
+type AffinePoint[Base emulated.FieldParams] struct {
+	X, Y emulated.Element[Base]
+}
+
+// Generator returns the base point of the curve. The method does not copy and
+// modifying the returned element leads to undefined behaviour!
+func (c *Curve[B, S]) Generator() *AffinePoint[B] {
+	return &c.g
+}
+
+// Identity returns the identity element (0,1) of the curve.
+func (c *Curve[B, S]) Identity() *AffinePoint[B] {
+	return &AffinePoint[B]{
+		X: *c.baseApi.Zero(),
+		Y: *c.baseApi.One(),
+	}
+}
+
+// Neg returns an inverse of p. It doesn't modify p.
+func (c *Curve[B, S]) Neg(p *AffinePoint[B]) *AffinePoint[B] {
+	return &AffinePoint[B]{
+		X: *c.baseApi.Neg(&p.X),
+		Y: p.Y,
+	}
+}
+
+// AssertIsEqual asserts that p and q are the same point.
+func (c *Curve[B, S]) AssertIsEqual(p, q *AffinePoint[B]) {
+	c.baseApi.AssertIsEqual(&p.X, &q.X)
+	c.baseApi.AssertIsEqual(&p.Y, &q.Y)
+}
+
+// AssertIsOnCurve asserts that p satisfies the curve equation
+// aX² + Y² = 1 + dX²Y².
+func (c *Curve[B, S]) AssertIsOnCurve(p *AffinePoint[B]) {
+	xx := c.baseApi.Mul(&p.X, &p.X)
+	yy := c.baseApi.Mul(&p.Y, &p.Y)
+	lhs := c.baseApi.Add(c.baseApi.Mul(&c.a, xx), yy)
+	rhs := c.baseApi.Mul(&c.d, c.baseApi.Mul(xx, yy))
+	rhs = c.baseApi.Add(rhs, c.baseApi.One())
+	c.baseApi.AssertIsEqual(lhs, rhs)
+}
+
+// Add adds p and q and returns it. It doesn't modify p nor q.
+//
+// It uses the complete addition formulas in affine coordinates:
+//
+//	x3 = (x1y2 + y1x2) / (1 + dx1x2y1y2)
+//	y3 = (y1y2 - ax1x2) / (1 - dx1x2y1y2)
+func (c *Curve[B, S]) Add(p, q *AffinePoint[B]) *AffinePoint[B] {
+	x1y2 := c.baseApi.Mul(&p.X, &q.Y)
+	y1x2 := c.baseApi.Mul(&p.Y, &q.X)
+	x1x2 := c.baseApi.Mul(&p.X, &q.X)
+	y1y2 := c.baseApi.Mul(&p.Y, &q.Y)
+	dxy := c.baseApi.Mul(&c.d, c.baseApi.Mul(x1x2, y1y2))
+
+	x3 := c.baseApi.Div(
+		c.baseApi.Add(x1y2, y1x2),
+		c.baseApi.Add(c.baseApi.One(), dxy),
+	)
+	y3 := c.baseApi.Div(
+		c.baseApi.Sub(y1y2, c.baseApi.Mul(&c.a, x1x2)),
+		c.baseApi.Sub(c.baseApi.One(), dxy),
+	)
+	return &AffinePoint[B]{
+		X: *x3,
+		Y: *y3,
+	}
+}
+
+// Double doubles p and returns it. It doesn't modify p.
+//
+// It uses the dedicated doubling formulas in affine coordinates:
+//
+//	x3 = 2xy / (ax² + y²)
+//	y3 = (y² - ax²) / (2 - ax² - y²)
+func (c *Curve[B, S]) Double(p *AffinePoint[B]) *AffinePoint[B] {
+	xy := c.baseApi.Mul(&p.X, &p.Y)
+	axx := c.baseApi.Mul(&c.a, c.baseApi.Mul(&p.X, &p.X))
+	yy := c.baseApi.Mul(&p.Y, &p.Y)
+	axxyy := c.baseApi.Add(axx, yy)
+
+	x3 := c.baseApi.Div(
+		c.baseApi.Add(xy, xy),
+		axxyy,
+	)
+	y3 := c.baseApi.Div(
+		c.baseApi.Sub(yy, axx),
+		c.baseApi.Sub(c.baseApi.NewElement(2), axxyy),
+	)
+	return &AffinePoint[B]{
+		X: *x3,
+		Y: *y3,
+	}
+}
+
+// Select selects between p and q given the selector b. If b == 1, then returns
+// p and q otherwise.
+func (c *Curve[B, S]) Select(b frontend.Variable, p, q *AffinePoint[B]) *AffinePoint[B] {
+	x := c.baseApi.Select(b, &p.X, &q.X)
+	y := c.baseApi.Select(b, &p.Y, &q.Y)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// Lookup2 performs a 2-bit lookup between i0, i1, i2, i3 based on bits b0
+// and b1. Returns:
+//   - i0 if b0=0 and b1=0,
+//   - i1 if b0=1 and b1=0,
+//   - i2 if b0=0 and b1=1,
+//   - i3 if b0=1 and b1=1.
+func (c *Curve[B, S]) Lookup2(b0, b1 frontend.Variable, i0, i1, i2, i3 *AffinePoint[B]) *AffinePoint[B] {
+	x := c.baseApi.Lookup2(b0, b1, &i0.X, &i1.X, &i2.X, &i3.X)
+	y := c.baseApi.Lookup2(b0, b1, &i0.Y, &i1.Y, &i2.Y, &i3.Y)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// scalarBits returns the bits of the canonical representation of s.
+func (c *Curve[B, S]) scalarBits(s *emulated.Element[S]) []frontend.Variable {
+	var st S
+	sr := c.scalarApi.Reduce(s)
+	c.scalarApi.AssertIsInRange(sr)
+	return c.scalarApi.ToBits(sr)[:st.Modulus().BitLen()]
+}
+
+// ScalarMul computes [s]p and returns it. It doesn't modify p nor s. This
+// function doesn't check that the p is on the curve. See AssertIsOnCurve.
+//
+// The scalar s is reduced modulo the scalar field modulus r, i.e. the method
+// computes [s mod r]p. For points in the prime-order subgroup this is the same
+// as [s]p.
+//
+// It uses a left-to-right double-and-add algorithm with a 2-bit window and
+// complete formulas, so there are no edge cases.
+func (c *Curve[B, S]) ScalarMul(p *AffinePoint[B], s *emulated.Element[S]) *AffinePoint[B] {
+	sBits := c.scalarBits(s)
+	n := len(sBits)
+
+	// table = [O, p, 2p, 3p]
+	p2 := c.Double(p)
+	p3 := c.Add(p2, p)
+	o := c.Identity()
+
+	var res *AffinePoint[B]
+	i := n - 1
+	if n%2 == 1 {
+		res = c.Select(sBits[i], p, o)
+		i--
+	} else {
+		res = c.Lookup2(sBits[i-1], sBits[i], o, p, p2, p3)
+		i -= 2
+	}
+	for ; i >= 1; i -= 2 {
+		res = c.Double(c.Double(res))
+		tmp := c.Lookup2(sBits[i-1], sBits[i], o, p, p2, p3)
+		res = c.Add(res, tmp)
+	}
+	return res
+}
+
+// ScalarMulBase computes [s]g and returns it, where g is the fixed generator.
+// It doesn't modify s.
+func (c *Curve[B, S]) ScalarMulBase(s *emulated.Element[S]) *AffinePoint[B] {
+	return c.ScalarMul(c.Generator(), s)
+}
+
+// JointScalarMulBase computes [s2]p + [s1]g and returns it, where g is the
+// fixed generator. It doesn't modify p, s1 and s2.
+//
+// It uses the Shamir's trick with complete formulas, so there are no edge
+// cases.
+func (c *Curve[B, S]) JointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Element[S]) *AffinePoint[B] {
+	s1Bits := c.scalarBits(s1)
+	s2Bits := c.scalarBits(s2)
+	n := len(s1Bits)
+
+	// table = [O, g, p, g+p]
+	g := c.Generator()
+	gp := c.Add(g, p)
+	o := c.Identity()
+
+	res := c.Lookup2(s1Bits[n-1], s2Bits[n-1], o, g, p, gp)
+	for i := n - 2; i >= 0; i-- {
+		res = c.Double(res)
+		tmp := c.Lookup2(s1Bits[i], s2Bits[i], o, g, p, gp)
+		res = c.Add(res, tmp)
+	}
+	return res
+}
+
+// MulByCofactor computes [h]p where h is the cofactor of the curve and
+// returns it. It doesn't modify p. The result is in the prime-order subgroup.
+func (c *Curve[B, S]) MulByCofactor(p *AffinePoint[B]) *AffinePoint[B] {
+	h := c.params.Cofactor
+	res := p
+	for i := h.BitLen() - 2; i >= 0; i-- {
+		res = c.Double(res)
+		if h.Bit(i) == 1 {
+			res = c.Add(res, p)
+		}
+	}
+	return res
+}
+
+// IsIdentity returns 1 if p is the identity element (0,1) and 0 otherwise.
+func (c *Curve[B, S]) IsIdentity(p *AffinePoint[B]) frontend.Variable {
+	xZ := c.baseApi.IsZero(&p.X)
+	yO := c.baseApi.IsZero(c.baseApi.Sub(&p.Y, c.baseApi.One()))
+	return c.api.And(xZ, yO)
+}
@@ -0,0 +1,253 @@
+package te_emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+var testCurve = ecc.BN254
+
+// refPoint is a reference implementation of the affine twisted Edwards
+// arithmetic used for computing the expected values.
+type refPoint struct {
+	x, y *big.Int
+}
+
+func refAdd(params CurveParams, p, q refPoint) refPoint {
+	mod := emulated.Ed25519Fp{}.Modulus()
+	x1y2 := new(big.Int).Mul(p.x, q.y)
+	y1x2 := new(big.Int).Mul(p.y, q.x)
+	x1x2 := new(big.Int).Mul(p.x, q.x)
+	y1y2 := new(big.Int).Mul(p.y, q.y)
+	dxy := new(big.Int).Mul(params.D, x1x2)
+	dxy.Mul(dxy, y1y2).Mod(dxy, mod)
+	xn := new(big.Int).Add(x1y2, y1x2)
+	xd := new(big.Int).Add(big.NewInt(1), dxy)
+	yn := new(big.Int).Mul(params.A, x1x2)
+	yn.Sub(y1y2, yn)
+	yd := new(big.Int).Sub(big.NewInt(1), dxy)
+	xd.ModInverse(xd.Mod(xd, mod), mod)
+	yd.ModInverse(yd.Mod(yd, mod), mod)
+	xn.Mul(xn, xd).Mod(xn, mod)
+	yn.Mul(yn, yd).Mod(yn, mod)
+	return refPoint{xn, yn}
+}
+
+func refScalarMul(params CurveParams, p refPoint, s *big.Int) refPoint {
+	res := refPoint{big.NewInt(0), big.NewInt(1)}
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		res = refAdd(params, res, res)
+		if s.Bit(i) == 1 {
+			res = refAdd(params, res, p)
+		}
+	}
+	return res
+}
+
+func refValueOf(p refPoint) AffinePoint[emulated.Ed25519Fp] {
+	return AffinePoint[emulated.Ed25519Fp]{
+		X: emulated.ValueOf[emulated.Ed25519Fp](p.x),
+		Y: emulated.ValueOf[emulated.Ed25519Fp](p.y),
+	}
+}
+
+func randomScalar(assert *test.Assert) *big.Int {
+	s, err := rand.Int(rand.Reader, emulated.Ed25519Fr{}.Modulus())
+	assert.NoError(err)
+	return s
+}
+
+type AddTest[T, S emulated.FieldParams] struct {
+	P, Q, R AffinePoint[T]
+}
+
+func (c *AddTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.Add(&c.P, &c.Q)
+	cr.AssertIsEqual(res, &c.R)
+	return nil
+}
+
+func TestAdd(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := refPoint{params.Gx, params.Gy}
+	p := refScalarMul(params, g, randomScalar(assert))
+	q := refScalarMul(params, g, randomScalar(assert))
+	r := refAdd(params, p, q)
+	circuit := AddTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{}
+	witness := AddTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{
+		P: refValueOf(p),
+		Q: refValueOf(q),
+		R: refValueOf(r),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type DoubleTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+}
+
+func (c *DoubleTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.Double(&c.P)
+	cr.AssertIsEqual(res, &c.Q)
+	// doubling and adding the point to itself should match
+	cr.AssertIsEqual(res, cr.Add(&c.P, &c.P))
+	return nil
+}
+
+func TestDouble(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := refPoint{params.Gx, params.Gy}
+	p := refScalarMul(params, g, randomScalar(assert))
+	q := refAdd(params, p, p)
+	circuit := DoubleTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{}
+	witness := DoubleTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{
+		P: refValueOf(p),
+		Q: refValueOf(q),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type IsOnCurveTest[T, S emulated.FieldParams] struct {
+	P AffinePoint[T]
+}
+
+func (c *IsOnCurveTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsOnCurve(&c.P)
+	return nil
+}
+
+func TestIsOnCurve(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := refPoint{params.Gx, params.Gy}
+	p := refScalarMul(params, g, randomScalar(assert))
+	circuit := IsOnCurveTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{}
+	witness := IsOnCurveTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{
+		P: refValueOf(p),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+	witness.P.Y = emulated.ValueOf[emulated.Ed25519Fp](new(big.Int).Add(p.y, big.NewInt(1)))
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+type ScalarMulTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+	S    emulated.Element[S]
+}
+
+func (c *ScalarMulTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.ScalarMul(&c.P, &c.S)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestScalarMul(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := refPoint{params.Gx, params.Gy}
+	p := refScalarMul(params, g, randomScalar(assert))
+	for _, s := range []*big.Int{randomScalar(assert), big.NewInt(0), big.NewInt(1), big.NewInt(3)} {
+		q := refScalarMul(params, p, s)
+		circuit := ScalarMulTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{}
+		witness := ScalarMulTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{
+			P: refValueOf(p),
+			Q: refValueOf(q),
+			S: emulated.ValueOf[emulated.Ed25519Fr](s),
+		}
+		err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type JointScalarMulBaseTest[T, S emulated.FieldParams] struct {
+	P, Q   AffinePoint[T]
+	S1, S2 emulated.Element[S]
+}
+
+func (c *JointScalarMulBaseTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.JointScalarMulBase(&c.P, &c.S2, &c.S1)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestJointScalarMulBase(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := refPoint{params.Gx, params.Gy}
+	p := refScalarMul(params, g, randomScalar(assert))
+	s1, s2 := randomScalar(assert), randomScalar(assert)
+	q := refAdd(params, refScalarMul(params, g, s1), refScalarMul(params, p, s2))
+	circuit := JointScalarMulBaseTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{}
+	witness := JointScalarMulBaseTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{
+		P:  refValueOf(p),
+		Q:  refValueOf(q),
+		S1: emulated.ValueOf[emulated.Ed25519Fr](s1),
+		S2: emulated.ValueOf[emulated.Ed25519Fr](s2),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type MulByCofactorTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+}
+
+func (c *MulByCofactorTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.MulByCofactor(&c.P)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestMulByCofactor(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := refPoint{params.Gx, params.Gy}
+	// (0, -1) is the point of order 2
+	mod := emulated.Ed25519Fp{}.Modulus()
+	t2 := refPoint{big.NewInt(0), new(big.Int).Sub(mod, big.NewInt(1))}
+	p := refAdd(params, refScalarMul(params, g, randomScalar(assert)), t2)
+	q := refScalarMul(params, p, params.Cofactor)
+	circuit := MulByCofactorTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{}
+	witness := MulByCofactorTest[emulated.Ed25519Fp, emulated.Ed25519Fr]{
+		P: refValueOf(p),
+		Q: refValueOf(q),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
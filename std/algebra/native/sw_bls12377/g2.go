@@ -83,6 +83,52 @@ func (p *g2AffP) AddAssign(api frontend.API, p1 g2AffP) *g2AffP {
 	return p
 }
 
+// AddUnified adds q to p and returns p. Unlike AddAssign it handles the cases
+// p == q, p == -q and the point at infinity (0,0).
+func (p *g2AffP) AddUnified(api frontend.API, q g2AffP) *g2AffP {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := api.And(p.X.IsZero(api), p.Y.IsZero(api))
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := api.And(q.X.IsZero(api), q.Y.IsZero(api))
+
+	// λ = ((p.x+q.x)² - p.x*q.x)/(p.y + q.y)
+	var pxqx, pxplusqx, num, denum, one, λ fields_bls12377.E2
+	pxqx.Mul(api, p.X, q.X)
+	pxplusqx.Add(api, p.X, q.X)
+	num.Square(api, pxplusqx)
+	num.Sub(api, num, pxqx)
+	denum.Add(api, p.Y, q.Y)
+	// if p.y + q.y = 0, assign dummy 1 to denum and continue
+	selector3 := denum.IsZero(api)
+	one.SetOne()
+	denum.Select(api, selector3, one, denum)
+	λ.DivUnchecked(api, num, denum)
+
+	// x = λ^2 - p.x - q.x
+	var result, infinity g2AffP
+	result.X.Square(api, λ)
+	result.X.Sub(api, result.X, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	result.Y.Sub(api, p.X, result.X)
+	result.Y.Mul(api, result.Y, λ)
+	result.Y.Sub(api, result.Y, p.Y)
+
+	infinity.X.SetZero()
+	infinity.Y.SetZero()
+	// if p=(0,0) return q
+	result.Select(api, selector1, q, result)
+	// if q=(0,0) return p
+	result.Select(api, selector2, *p, result)
+	// if p.y + q.y = 0, return (0, 0)
+	result.Select(api, selector3, infinity, result)
+
+	p.X = result.X
+	p.Y = result.Y
+
+	return p
+}
+
 // AddAssign adds 2 point in Jacobian coordinates
 // p=p, a=p1
 func (p *G2Jac) AddAssign(api frontend.API, p1 *G2Jac) *G2Jac {
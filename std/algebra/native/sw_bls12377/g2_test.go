@@ -106,6 +106,55 @@ func TestAddAssignAffineG2(t *testing.T) {
 
 }
 
+// -------------------------------------------------------------------------------------------------
+// Add affine (unified)
+
+type g2AddUnifiedAffine struct {
+	A, B g2AffP
+	C    g2AffP `gnark:",public"`
+}
+
+func (circuit *g2AddUnifiedAffine) Define(api frontend.API) error {
+	expected := circuit.A
+	expected.AddUnified(api, circuit.B)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestAddUnifiedAffineG2(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	_a := randomPointG2()
+	var a, b, aNeg, infinity bls12377.G2Affine
+	a.FromJacobian(&_a)
+	_b := randomPointG2()
+	b.FromJacobian(&_b)
+	aNeg.Neg(&a)
+
+	for _, tc := range []struct {
+		name string
+		a, b bls12377.G2Affine
+	}{
+		{"distinct", a, b},
+		{"double", a, a},
+		{"opposite", a, aNeg},
+		{"left-infinity", infinity, b},
+		{"right-infinity", a, infinity},
+		{"both-infinity", infinity, infinity},
+	} {
+		tc := tc
+		assert.Run(func(assert *test.Assert) {
+			var c bls12377.G2Affine
+			c.Add(&tc.a, &tc.b)
+			var circuit, witness g2AddUnifiedAffine
+			witness.A.Assign(&tc.a)
+			witness.B.Assign(&tc.b)
+			witness.C.Assign(&c)
+			assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BW6_761))
+		}, tc.name)
+	}
+}
+
 // -------------------------------------------------------------------------------------------------
 // Double Jacobian
 
@@ -0,0 +1,63 @@
+package sw_bls12377
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
+	"github.com/consensys/gnark/std/hash/expand"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// HashToG1 hashes a message to a point in G1 using the SSWU map. It is the
+// circuit counterpart of the native HashToG1 of gnark-crypto. dst is the
+// domain separation tag and is fixed at compile time.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func HashToG1(api frontend.API, msg []uints.U8, dst []byte) (G1Affine, error) {
+	u, err := hashToFp(api, msg, dst, 2)
+	if err != nil {
+		return G1Affine{}, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g1Isogeny(api, mapToCurve1(api, u[0]))
+	q1 := g1Isogeny(api, mapToCurve1(api, u[1]))
+	q0.AddAssign(api, q1)
+	return g1ClearCofactor(api, q0), nil
+}
+
+// HashToG2 hashes a message to a point in G2 using the SSWU map. It is the
+// circuit counterpart of the native HashToG2 of gnark-crypto. dst is the
+// domain separation tag and is fixed at compile time.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func HashToG2(api frontend.API, msg []uints.U8, dst []byte) (G2Affine, error) {
+	u, err := hashToFp(api, msg, dst, 4)
+	if err != nil {
+		return G2Affine{}, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g2Isogeny(api, mapToCurve2(api, fields_bls12377.E2{A0: u[0], A1: u[1]}))
+	q1 := g2Isogeny(api, mapToCurve2(api, fields_bls12377.E2{A0: u[2], A1: u[3]}))
+	q0.AddAssign(api, q1)
+	return G2Affine{P: g2ClearCofactor(api, q0)}, nil
+}
+
+// hashToFp hashes msg to count elements of 𝔽p using the hash_to_field method
+// with expand_message_xmd instantiated with SHA2-256. As 𝔽p is the native
+// field, the reduction modulo p is implicit.
+func hashToFp(api frontend.API, msg []uints.U8, dst []byte, count int) ([]frontend.Variable, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) with the security parameter k = 128
+	const L = 64
+	uniformBytes, err := expand.ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]frontend.Variable, count)
+	for i := range res {
+		var acc frontend.Variable = 0
+		for _, b := range uniformBytes[i*L : (i+1)*L] {
+			acc = api.Add(api.Mul(acc, 256), b.Val)
+		}
+		res[i] = acc
+	}
+	return res, nil
+}
@@ -0,0 +1,126 @@
+package sw_bls12377
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+var testDST = []byte("QUUX-V01-CS02-with-BLS12377G1_XMD:SHA-256_SSWU_RO_")
+
+type mapToG1Circuit struct {
+	U   frontend.Variable
+	Res G1Affine
+}
+
+func (c *mapToG1Circuit) Define(api frontend.API) error {
+	res := MapToG1(api, c.U)
+	res.AssertIsEqual(api, c.Res)
+	return nil
+}
+
+func TestMapToG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	var rnd fp.Element
+	rnd.SetRandom()
+	for i, u := range []fp.Element{rnd, fp.NewElement(1), fp.NewElement(2)} {
+		assert.Run(func(assert *test.Assert) {
+			res := bls12377.MapToG1(u)
+			witness := mapToG1Circuit{
+				U:   u.String(),
+				Res: NewG1Affine(res),
+			}
+			err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BW6_761.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+type mapToG2Circuit struct {
+	U   fields_bls12377.E2
+	Res G2Affine
+}
+
+func (c *mapToG2Circuit) Define(api frontend.API) error {
+	res := MapToG2(api, c.U)
+	res.P.AssertIsEqual(api, c.Res.P)
+	return nil
+}
+
+func TestMapToG2(t *testing.T) {
+	assert := test.NewAssert(t)
+	var rnd, rndA0 bls12377.E2
+	rnd.SetRandom()
+	rndA0.A0.SetRandom()
+	for i, u := range []bls12377.E2{rnd, rndA0, {}} {
+		assert.Run(func(assert *test.Assert) {
+			res := bls12377.MapToG2(u)
+			var witness mapToG2Circuit
+			witness.U.Assign(&u)
+			witness.Res = NewG2Affine(res)
+			err := test.IsSolved(&mapToG2Circuit{}, &witness, ecc.BW6_761.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+type hashToG1Circuit struct {
+	Msg []uints.U8
+	Res G1Affine
+}
+
+func (c *hashToG1Circuit) Define(api frontend.API) error {
+	res, err := HashToG1(api, c.Msg, testDST)
+	if err != nil {
+		return err
+	}
+	res.AssertIsEqual(api, c.Res)
+	return nil
+}
+
+func TestHashToG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	res, err := bls12377.HashToG1(msg, testDST)
+	assert.NoError(err)
+	witness := hashToG1Circuit{
+		Msg: uints.NewU8Array(msg),
+		Res: NewG1Affine(res),
+	}
+	err = test.IsSolved(&hashToG1Circuit{Msg: make([]uints.U8, len(msg))}, &witness, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+}
+
+type hashToG2Circuit struct {
+	Msg []uints.U8
+	Res G2Affine
+}
+
+func (c *hashToG2Circuit) Define(api frontend.API) error {
+	res, err := HashToG2(api, c.Msg, testDST)
+	if err != nil {
+		return err
+	}
+	res.P.AssertIsEqual(api, c.Res.P)
+	return nil
+}
+
+func TestHashToG2(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	res, err := bls12377.HashToG2(msg, testDST)
+	assert.NoError(err)
+	witness := hashToG2Circuit{
+		Msg: uints.NewU8Array(msg),
+		Res: NewG2Affine(res),
+	}
+	err = test.IsSolved(&hashToG2Circuit{Msg: make([]uints.U8, len(msg))}, &witness, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+}
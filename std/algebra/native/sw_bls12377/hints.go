@@ -0,0 +1,51 @@
+package sw_bls12377
+
+import (
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns the hint functions used for mapping to the curve.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		mapToCurve1Hint,
+		mapToCurve2Hint,
+	}
+}
+
+// mapToCurve1Hint returns the y-coordinate of the SSWU map of the input on the
+// curve isogenous to G1.
+func mapToCurve1Hint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	var u fp.Element
+
+	u.SetBigInt(inputs[0])
+
+	q := bls12377.MapToCurve1(&u)
+
+	q.Y.BigInt(outputs[0])
+
+	return nil
+}
+
+// mapToCurve2Hint returns the y-coordinate of the SSWU map of the input on the
+// curve isogenous to G2.
+func mapToCurve2Hint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	var u bls12377.E2
+
+	u.A0.SetBigInt(inputs[0])
+	u.A1.SetBigInt(inputs[1])
+
+	q := bls12377.MapToCurve2(&u)
+
+	q.Y.A0.BigInt(outputs[0])
+	q.Y.A1.BigInt(outputs[1])
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package sw_bls12377
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Coefficients of the isogeny from the SSWU curve E' to G1, in increasing
+// order of degree. The denominators are monic and the leading coefficient is
+// omitted.
+var g1IsogenyXNumerator = []string{
+	"193998319509726820447277314072485610595876362210707887456279225959507476652652651634192264150953923683470146535424",
+	"40474824132456359704279181570318738632422647360355249739068643631356267969150730939906729705473",
+	"193998319509726820507989550271170150152295134566185995404913197000040351261255617081226666104680020093330241093633",
+}
+
+var g1IsogenyXDenominator = []string{
+	"161899296529825438817116726281274954529690589441420998956274574525425071876602923759626918821892",
+}
+
+var g1IsogenyYNumerator = []string{
+	"193998319509726820507989550271170150152295134566185995404913197000040351261255617081226666104680020093330241093631",
+	"32333053251621136903112182208573040583096119983059602439070460434672245065050016464457115901761911040205276577794",
+	"129332213006484547066038603046131306324615528732935438218576102373893108782773376834518846023512776472080255287298",
+	"226331372761347957259321141983031841844344323660550327972398729833380409804798219928097777122126690108885281275905",
+}
+
+var g1IsogenyYDenominator = []string{
+	"258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458169",
+	"971395779178952632902700357687649727178143536648525993737647447152550431259617542557761512931340",
+	"485697889589476316451350178843824863589071768324262996868823723576275215629808771278880756465676",
+}
+
+// MapToG1 maps u ∈ 𝔽p to a point in G1. It is the circuit counterpart of the
+// native MapToG1 of gnark-crypto: it applies the simplified SWU map to the
+// isogenous curve E', the 2-isogeny to the curve and finally clears the
+// cofactor.
+//
+// The method is not complete: in the negligible probability case where the
+// isogeny or the cofactor clearing reaches the point at infinity, the circuit
+// has no solution.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.3
+func MapToG1(api frontend.API, u frontend.Variable) G1Affine {
+	q := mapToCurve1(api, u)
+	q = g1Isogeny(api, q)
+	return g1ClearCofactor(api, q)
+}
+
+// mapToCurve1 implements the simplified SWU map to the curve E': y² = x³ + A'x + B'
+// isogenous to G1. The y-coordinate is computed out-of-circuit and constrained.
+func mapToCurve1(api frontend.API, u frontend.Variable) G1Affine {
+	sswuA := "258664426012969092796408009721202742408018065645352501567204841856062976176281513834280849065051431927238430294002"
+	sswuB := 22
+	sswuZ := 5
+
+	// tv1 = Z * u²
+	tv1 := api.Mul(u, u, sswuZ)
+	// tv2 = tv1² + tv1
+	tv2 := api.Add(api.Mul(tv1, tv1), tv1)
+	// exceptional case when tv2 = 0
+	isExceptional := api.IsZero(tv2)
+	// x1 = B * (tv2 + 1) / (A * CMOV(-tv2, Z, tv2 == 0))
+	num := api.Mul(api.Add(tv2, 1), sswuB)
+	den := api.Select(isExceptional, sswuZ, api.Neg(tv2))
+	den = api.Mul(den, sswuA)
+	x1 := api.Div(num, den)
+	// x2 = tv1 * x1
+	x2 := api.Mul(tv1, x1)
+	// gx1 = x1³ + A * x1 + B and gx2 = x2³ + A * x2 + B
+	gx1 := g1EvalCurve(api, x1, sswuA, sswuB)
+	gx2 := g1EvalCurve(api, x2, sswuA, sswuB)
+
+	hint, err := api.Compiler().NewHint(mapToCurve1Hint, 1, u)
+	if err != nil {
+		panic(fmt.Sprintf("map to curve hint: %v", err))
+	}
+	y := hint[0]
+
+	// y² = gx1 if gx1 is square and y² = gx2 otherwise. gx1 and gx2 cannot be
+	// both squares as gx2 = Z³u⁶ gx1 and Z is a non-square.
+	y2 := api.Mul(y, y)
+	isGx1Square := api.IsZero(api.Sub(y2, gx1))
+	api.AssertIsEqual(y2, api.Select(isGx1Square, gx1, gx2))
+	// in the exceptional case gx1 is always a square
+	api.AssertIsEqual(api.Mul(isExceptional, api.Sub(1, isGx1Square)), 0)
+	x := api.Select(isGx1Square, x1, x2)
+
+	// sgn0(u) = sgn0(y)
+	api.AssertIsEqual(g1Sgn0(api, u), g1Sgn0(api, y))
+
+	return G1Affine{
+		X: x,
+		Y: y,
+	}
+}
+
+// g1EvalCurve returns x³ + a * x + b.
+func g1EvalCurve(api frontend.API, x, a, b frontend.Variable) frontend.Variable {
+	res := api.Add(api.Mul(x, x), a)
+	return api.Add(api.Mul(res, x), b)
+}
+
+// g1Sgn0 returns the parity of the canonical representation of x.
+func g1Sgn0(api frontend.API, x frontend.Variable) frontend.Variable {
+	return api.ToBinary(x)[0]
+}
+
+// g1Isogeny maps a point from E' to G1 using the 2-isogeny.
+func g1Isogeny(api frontend.API, q G1Affine) G1Affine {
+	xn := g1EvalPolynomial(api, false, g1IsogenyXNumerator, q.X)
+	xd := g1EvalPolynomial(api, true, g1IsogenyXDenominator, q.X)
+	yn := g1EvalPolynomial(api, false, g1IsogenyYNumerator, q.X)
+	yn = api.Mul(yn, q.Y)
+	yd := g1EvalPolynomial(api, true, g1IsogenyYDenominator, q.X)
+	return G1Affine{
+		X: api.Div(xn, xd),
+		Y: api.Div(yn, yd),
+	}
+}
+
+// g1EvalPolynomial evaluates the polynomial with the given coefficients at x
+// using Horner's method. If monic is set, then the leading coefficient 1 is
+// implicit.
+func g1EvalPolynomial(api frontend.API, monic bool, coefficients []string, x frontend.Variable) frontend.Variable {
+	var res frontend.Variable = coefficients[len(coefficients)-1]
+	if monic {
+		res = api.Add(res, x)
+	}
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		res = api.Mul(res, x)
+		res = api.Add(res, coefficients[i])
+	}
+	return res
+}
+
+// g1ScalarMulBySeed returns [x₀]q. Contrary to [G1Affine.ScalarMul] it does not
+// use the GLV decomposition and can be applied to points outside of G1.
+func g1ScalarMulBySeed(api frontend.API, q G1Affine) G1Affine {
+	x0 := new(big.Int).SetUint64(9586122913090633729)
+	res := q
+	for i := x0.BitLen() - 2; i >= 0; i-- {
+		if x0.Bit(i) == 1 {
+			res.DoubleAndAdd(api, &res, &q)
+		} else {
+			res.Double(api, res)
+		}
+	}
+	return res
+}
+
+// g1ClearCofactor maps a point on the curve to the r-torsion by computing
+// [1-x₀]q, see https://eprint.iacr.org/2019/403.pdf, Section 5.
+func g1ClearCofactor(api frontend.API, q G1Affine) G1Affine {
+	// [x₀]q
+	xq := g1ScalarMulBySeed(api, q)
+	// q - [x₀]q
+	xq.Neg(api, xq)
+	xq.AddAssign(api, q)
+	return xq
+}
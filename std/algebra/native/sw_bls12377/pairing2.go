@@ -289,6 +289,54 @@ func (p *Pairing) AssertIsEqual(e1, e2 *GT) {
 	e1.AssertIsEqual(p.api, *e2)
 }
 
+// AssertIsOnCurve asserts that P is on the curve Y² = X³ + 1.
+func (p *Pairing) AssertIsOnCurve(P *G1Affine) {
+	left := p.api.Mul(P.Y, P.Y)
+	right := p.api.Add(p.api.Mul(P.X, P.X, P.X), 1)
+	p.api.AssertIsEqual(left, right)
+}
+
+// AssertIsOnTwist asserts that Q is on the twist Y² = X³ + 1/u.
+func (p *Pairing) AssertIsOnTwist(Q *G2Affine) {
+	bTwist := fields_bls12377.E2{
+		A0: 0,
+		A1: "155198655607781456406391640216936120121836107652948796323930557600032281009004493664981332883744016074664192874906",
+	}
+	var left, right fields_bls12377.E2
+	left.Square(p.api, Q.P.Y)
+	right.Square(p.api, Q.P.X)
+	right.Mul(p.api, right, Q.P.X)
+	right.Add(p.api, right, bTwist)
+	left.AssertIsEqual(p.api, right)
+}
+
+// AssertIsOnG1 asserts that P is on the curve and in the prime order subgroup
+// by checking that [x₀²]ϕ(P) = -P.
+func (p *Pairing) AssertIsOnG1(P *G1Affine) {
+	p.AssertIsOnCurve(P)
+
+	// ϕ(P) = (ω * X, Y)
+	phiP := G1Affine{
+		X: p.api.Mul(P.X, "80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945"),
+		Y: P.Y,
+	}
+	res := g1ScalarMulBySeed(p.api, phiP)
+	res = g1ScalarMulBySeed(p.api, res)
+	var negP G1Affine
+	negP.Neg(p.api, *P)
+	res.AssertIsEqual(p.api, negP)
+}
+
+// AssertIsOnG2 asserts that Q is on the twist and in the prime order subgroup
+// by checking that [x₀]Q = ψ(Q).
+func (p *Pairing) AssertIsOnG2(Q *G2Affine) {
+	p.AssertIsOnTwist(Q)
+
+	xQ := g2ScalarMulBySeed(p.api, Q.P)
+	psiQ := g2Psi(p.api, Q.P)
+	xQ.AssertIsEqual(p.api, psiQ)
+}
+
 // NewG1Affine allocates a witness from the native G1 element and returns it.
 func NewG1Affine(v bls12377.G1Affine) G1Affine {
 	return G1Affine{
@@ -23,6 +23,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
@@ -213,6 +214,46 @@ func TestPairingCheckBLS377(t *testing.T) {
 
 }
 
+type groupMembershipBLS377 struct {
+	InG1 G1Affine
+	InG2 G2Affine
+}
+
+func (c *groupMembershipBLS377) Define(api frontend.API) error {
+	pairing := NewPairing(api)
+	pairing.AssertIsOnG1(&c.InG1)
+	pairing.AssertIsOnG2(&c.InG2)
+	return nil
+}
+
+func TestGroupMembershipBLS377(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, P, Q := bls12377.Generators()
+	var s fr.Element
+	s.SetRandom()
+	P.ScalarMultiplication(&P, s.BigInt(new(big.Int)))
+	Q.ScalarMultiplication(&Q, s.BigInt(new(big.Int)))
+	witness := groupMembershipBLS377{
+		InG1: NewG1Affine(P),
+		InG2: NewG2Affine(Q),
+	}
+	err := test.IsSolved(&groupMembershipBLS377{}, &witness, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+
+	// a point on the curve but outside of the prime order subgroup
+	var x, y, one fp.Element
+	one.SetOne()
+	for x.SetOne(); ; x.Add(&x, &one) {
+		y.Square(&x).Mul(&y, &x).Add(&y, &one)
+		if y.Sqrt(&y) != nil {
+			break
+		}
+	}
+	witness.InG1 = NewG1Affine(bls12377.G1Affine{X: x, Y: y})
+	err = test.IsSolved(&groupMembershipBLS377{}, &witness, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+}
+
 // utils
 func pairingData() (P bls12377.G1Affine, Q bls12377.G2Affine, milRes, pairingRes bls12377.GT) {
 	_, _, P, Q = bls12377.Generators()
@@ -54,16 +54,16 @@ func (p *G1Affine) Neg(api frontend.API, p1 G1Affine) *G1Affine {
 
 func (p *G1Affine) AddUnified(api frontend.API, q G1Affine) *G1Affine {
 	// selector1 = 1 when p is (0,0) and 0 otherwise
-	selector1 := api.And(api.IsZero(&p.X), api.IsZero(&p.Y))
+	selector1 := api.And(api.IsZero(p.X), api.IsZero(p.Y))
 	// selector2 = 1 when q is (0,0) and 0 otherwise
-	selector2 := api.And(api.IsZero(&q.X), api.IsZero(&q.Y))
+	selector2 := api.And(api.IsZero(q.X), api.IsZero(q.Y))
 
 	// λ = ((p.x+q.x)² - p.x*q.x + a)/(p.y + q.y)
-	pxqx := api.Mul(&p.X, &q.X)
-	pxplusqx := api.Add(&p.X, &q.X)
+	pxqx := api.Mul(p.X, q.X)
+	pxplusqx := api.Add(p.X, q.X)
 	num := api.Mul(pxplusqx, pxplusqx)
 	num = api.Sub(num, pxqx)
-	denum := api.Add(&p.Y, &q.Y)
+	denum := api.Add(p.Y, q.Y)
 	// if p.y + q.y = 0, assign dummy 1 to denum and continue
 	selector3 := api.IsZero(denum)
 	denum = api.Select(selector3, 1, denum)
@@ -74,9 +74,9 @@ func (p *G1Affine) AddUnified(api frontend.API, q G1Affine) *G1Affine {
 	xr = api.Sub(xr, pxplusqx)
 
 	// y = λ(p.x - xr) - p.y
-	yr := api.Sub(&p.X, xr)
+	yr := api.Sub(p.X, xr)
 	yr = api.Mul(yr, λ)
-	yr = api.Sub(yr, &p.Y)
+	yr = api.Sub(yr, p.Y)
 	result := G1Affine{
 		X: xr,
 		Y: yr,
@@ -422,23 +422,42 @@ func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.
 
 // CheckOpeningProof asserts the validity of the opening proof for the given
 // commitment at point.
-func (v *Verifier[FR, G1El, G2El, GTEl]) CheckOpeningProof(commitment Commitment[G1El], proof OpeningProof[FR, G1El], point emulated.Element[FR], vk VerifyingKey[G1El, G2El]) error {
+//
+// When the option [algopts.WithCompleteArithmetic] is given, then the
+// commitment and the quotient may be the point at infinity (0,0), for example
+// for the commitment to a constant polynomial, if the curve and the pairing
+// support it. Currently this is the case for emulated BLS12-381.
+func (v *Verifier[FR, G1El, G2El, GTEl]) CheckOpeningProof(commitment Commitment[G1El], proof OpeningProof[FR, G1El], point emulated.Element[FR], vk VerifyingKey[G1El, G2El], opts ...algopts.AlgebraOption) error {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new config: %w", err)
+	}
+	add := v.curve.Add
+	pairing := v.pairing
+	if cfg.CompleteArithmetic {
+		add = v.curve.AddUnified
+		// the inputs to the pairing check may be (0,0)
+		pairing, err = algebra.GetPairing[G1El, G2El, GTEl](v.api, algopts.WithCompleteArithmetic())
+		if err != nil {
+			return fmt.Errorf("new pairing: %w", err)
+		}
+	}
 
-	claimedValueG1 := v.curve.ScalarMulBase(&proof.ClaimedValue)
+	claimedValueG1 := v.curve.ScalarMulBase(&proof.ClaimedValue, opts...)
 
 	// [f(α) - f(a)]G₁
 	fminusfaG1 := v.curve.Neg(claimedValueG1)
-	fminusfaG1 = v.curve.Add(fminusfaG1, &commitment.G1El)
+	fminusfaG1 = add(fminusfaG1, &commitment.G1El)
 
 	// [-H(α)]G₁
 	negQuotientPoly := v.curve.Neg(&proof.Quotient)
 
 	// [f(α) - f(a) + a*H(α)]G₁
-	totalG1 := v.curve.ScalarMul(&proof.Quotient, &point)
-	totalG1 = v.curve.Add(totalG1, fminusfaG1)
+	totalG1 := v.curve.ScalarMul(&proof.Quotient, &point, opts...)
+	totalG1 = add(totalG1, fminusfaG1)
 
 	// e([f(α)-f(a)+aH(α)]G₁], G₂).e([-H(α)]G₁, [α]G₂) == 1
-	if err := v.pairing.PairingCheck(
+	if err := pairing.PairingCheck(
 		[]*G1El{totalG1, negQuotientPoly},
 		[]*G2El{&vk.G2[0], &vk.G2[1]},
 	); err != nil {
@@ -485,7 +504,7 @@ func (v *Verifier[FR, G1El, G2El, GTEl]) FoldProofsMultiPoint(digests []Commitme
 	// sample random numbers λᵢ for sampling
 	randomNumbers := make([]*emulated.Element[FR], len(digests))
 	randomNumbers[0] = v.scalarApi.One()
-	whSnark, err := recursion.NewHash(v.api, fr.Modulus(), true)
+	whSnark, err := recursion.NewHashPartitioned(v.api, fr.Modulus(), true)
 	if err != nil {
 		return nil, nil, err
 	}
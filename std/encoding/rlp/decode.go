@@ -0,0 +1,110 @@
+package rlp
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// decodeHeader decodes the RLP header at offset in the table t. The payload
+// length is at most maxLen, which bounds the number of bytes used for
+// encoding the length in long headers. It returns a boolean indicating if the
+// item is a list, the offset of the payload and the length of the payload.
+func (c *Codec) decodeHeader(t *logderivlookup.Table, offset frontend.Variable, maxLen int) (isList, start, length frontend.Variable) {
+	api := c.api
+	nbLen := nbLengthBytes(maxLen)
+	if nbLen == 0 {
+		nbLen = 1
+	}
+	inds := make([]frontend.Variable, nbLen+1)
+	for i := range inds {
+		inds[i] = api.Add(offset, i)
+	}
+	vals := t.Lookup(inds...)
+	bs := bits.ToBinary(api, vals[0], bits.WithNbDigits(8))
+	// b < 0x80 is a single byte, b in [0x80, 0xb7] a short string, b in
+	// [0xb8, 0xbf] a long string, b in [0xc0, 0xf7] a short list and b in
+	// [0xf8, 0xff] a long list.
+	isSingle := api.Sub(1, bs[7])
+	isList = api.Mul(bs[7], bs[6])
+	isLong := api.Mul(bs[7], api.Mul(bs[5], api.Mul(bs[4], bs[3])))
+	isShort := api.Sub(bs[7], isLong)
+	shortLen := bits.FromBinary(api, bs[:6])
+	// for long headers the length of the length is in the lowest three bits
+	lenOfLen := api.Add(bits.FromBinary(api, bs[:3]), 1)
+	var longLen, acc, eqSum frontend.Variable = 0, 0, 0
+	for k := 1; k <= nbLen; k++ {
+		acc = api.Add(api.Mul(acc, 256), vals[k])
+		eq := api.IsZero(api.Sub(lenOfLen, k))
+		longLen = api.Add(longLen, api.Mul(eq, acc))
+		eqSum = api.Add(eqSum, eq)
+	}
+	// the length of the length must fit the bound
+	api.AssertIsEqual(api.Mul(isLong, api.Sub(1, eqSum)), 0)
+	start = api.Add(offset, bs[7], api.Mul(isLong, lenOfLen))
+	length = api.Add(isSingle, api.Mul(isShort, shortLen), api.Mul(isLong, longLen))
+	return isList, start, length
+}
+
+// Decode decodes the RLP-encoded item in and returns a boolean indicating if
+// the item is a list and the payload of maximum length maxLen. For lists, the
+// payload is the concatenation of the encodings of the items. It asserts that
+// in is exactly the encoding of an item.
+func (c *Codec) Decode(in Bytes, maxLen int) (isList frontend.Variable, payload Bytes) {
+	api := c.api
+	in = c.truncate(in)
+	t := c.table(in, 0, nbLengthBytes(maxLen)+1+maxLen)
+	isList, start, length := c.decodeHeader(t, 0, maxLen)
+	api.AssertIsEqual(api.Add(start, length), in.Length)
+	return isList, c.extract(t, start, length, maxLen)
+}
+
+// DecodeString decodes the RLP-encoded string in and returns the payload of
+// maximum length maxLen. It asserts that in is exactly the encoding of a
+// string.
+func (c *Codec) DecodeString(in Bytes, maxLen int) Bytes {
+	isList, payload := c.Decode(in, maxLen)
+	c.api.AssertIsEqual(isList, 0)
+	return payload
+}
+
+// DecodeList decodes the RLP-encoded list in and returns its items and the
+// number of items. The list can have at most maxItems items, each of them at
+// most maxItemLen bytes long. The returned items are the RLP-encodings of the
+// items, which can be decoded further with [Codec.DecodeString] or
+// [Codec.DecodeList]. The items after nbItems are empty. The method asserts
+// that in is exactly the encoding of a list.
+func (c *Codec) DecodeList(in Bytes, maxItems, maxItemLen int) (items []Bytes, nbItems frontend.Variable) {
+	api := c.api
+	in = c.truncate(in)
+	// after the end of the list we decode the padding zeros as single bytes,
+	// we have to be able to read maxItems of them and the item.
+	t := c.table(in, 0, maxItems+maxItemLen+nbLengthBytes(len(in.Data))+1)
+	isList, start, length := c.decodeHeader(t, 0, len(in.Data))
+	api.AssertIsEqual(isList, 1)
+	end := api.Add(start, length)
+	api.AssertIsEqual(end, in.Length)
+
+	items = make([]Bytes, maxItems)
+	offset := start
+	// isEnd is 1 iff the item would start at the end of the list. As every
+	// item is at least one byte long, it is set exactly once.
+	var isActive frontend.Variable = 1
+	var isEndSum frontend.Variable = 0
+	nbItems = 0
+	for k := 0; k <= maxItems; k++ {
+		isEnd := api.IsZero(api.Sub(offset, end))
+		isEndSum = api.Add(isEndSum, isEnd)
+		nbItems = api.Add(nbItems, api.Mul(isEnd, k))
+		isActive = api.Sub(isActive, isEnd)
+		if k == maxItems {
+			break
+		}
+		_, itemStart, itemLen := c.decodeHeader(t, offset, maxItemLen)
+		itemLen = api.Add(api.Sub(itemStart, offset), itemLen)
+		items[k] = c.extract(t, offset, api.Mul(isActive, itemLen), maxItemLen)
+		offset = api.Add(offset, itemLen)
+	}
+	api.AssertIsEqual(isEndSum, 1)
+	return items, nbItems
+}
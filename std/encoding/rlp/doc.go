@@ -0,0 +1,17 @@
+// Package rlp implements in-circuit encoding and decoding of the Recursive
+// Length Prefix (RLP) serialization used in Ethereum.
+//
+// The package operates on variable-length byte strings [Bytes], which have a
+// maximum length fixed at compile time and the actual length given as a
+// variable. The bytes after the actual length are zero. All methods returning
+// [Bytes] ensure that, for the inputs the methods zero out the bytes after
+// the length.
+//
+// The decoder does not check that the encoding is canonical, i.e. that the
+// shortest possible header is used. It is the responsibility of the caller to
+// ensure that the decoded data is authenticated (for example by hashing).
+//
+// See the [Ethereum documentation] for the description of RLP.
+//
+// [Ethereum documentation]: https://ethereum.org/en/developers/docs/data-structures-and-encoding/rlp/
+package rlp
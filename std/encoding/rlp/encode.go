@@ -0,0 +1,119 @@
+package rlp
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// leadingZeros returns the number of leading zero bytes in the big-endian
+// bytes bs.
+func (c *Codec) leadingZeros(bs []frontend.Variable) frontend.Variable {
+	var isZero frontend.Variable = 1
+	var res frontend.Variable = 0
+	for i := range bs {
+		isZero = c.api.Mul(isZero, c.api.IsZero(bs[i]))
+		res = c.api.Add(res, isZero)
+	}
+	return res
+}
+
+// toBytes returns the big-endian byte decomposition of v of length nbBytes.
+func (c *Codec) toBytes(v frontend.Variable, nbBytes int) []frontend.Variable {
+	vbits := bits.ToBinary(c.api, v, bits.WithNbDigits(8*nbBytes))
+	res := make([]frontend.Variable, nbBytes)
+	for i := range res {
+		res[nbBytes-1-i] = bits.FromBinary(c.api, vbits[8*i:8*i+8])
+	}
+	return res
+}
+
+// header returns the short or long RLP header for the payload of length at
+// most maxLen using the offset offsetString or offsetList.
+func (c *Codec) header(length frontend.Variable, maxLen int, offset int) Bytes {
+	api := c.api
+	nbLen := nbLengthBytes(maxLen)
+	if nbLen == 0 {
+		nbLen = 1
+	}
+	// isLong is the highest bit of length - 56 + 2^(8*nbLen).
+	dbits := bits.ToBinary(api, api.Add(api.Sub(length, maxShortLength+1), 1<<(8*nbLen)), bits.WithNbDigits(8*nbLen+1))
+	isLong := dbits[8*nbLen]
+	isShort := api.Sub(1, isLong)
+	// the length bytes without the leading zeros
+	lenBytes := c.toBytes(length, nbLen)
+	lz := c.leadingZeros(lenBytes)
+	t := logderivlookup.New(api)
+	for i := range lenBytes {
+		t.Insert(lenBytes[i])
+	}
+	for i := 0; i < nbLen; i++ {
+		t.Insert(0)
+	}
+	nl := api.Sub(nbLen, lz)
+	res := Bytes{Data: make([]uints.U8, 1+nbLen)}
+	res.Data[0] = uints.U8{Val: api.Add(
+		api.Mul(isShort, api.Add(offset, length)),
+		api.Mul(isLong, api.Add(offset+maxShortLength, nl)),
+	)}
+	for i := 0; i < nbLen; i++ {
+		res.Data[1+i] = uints.U8{Val: api.Mul(isLong, t.Lookup(api.Add(lz, i))[0])}
+	}
+	res.Length = api.Add(isShort, api.Mul(isLong, api.Add(1, nl)))
+	return res
+}
+
+// EncodeString returns the RLP encoding of the byte string in. The maximum
+// length of the result is the maximum length of in plus the maximum length of
+// the header, which is one byte and the number of bytes needed to encode the
+// maximum length.
+func (c *Codec) EncodeString(in Bytes) Bytes {
+	api := c.api
+	in = c.truncate(in)
+	hdr := c.header(in.Length, len(in.Data), offsetString)
+	if len(in.Data) > 0 {
+		// a single byte less than 0x80 is its own encoding
+		b7 := bits.ToBinary(api, in.Data[0].Val, bits.WithNbDigits(8))[7]
+		isSingle := api.Mul(api.IsZero(api.Sub(in.Length, 1)), api.Sub(1, b7))
+		for i := range hdr.Data {
+			hdr.Data[i] = uints.U8{Val: api.Mul(api.Sub(1, isSingle), hdr.Data[i].Val)}
+		}
+		hdr.Length = api.Mul(api.Sub(1, isSingle), hdr.Length)
+	}
+	return c.concat(hdr, in)
+}
+
+// EncodeUint returns the RLP encoding of the non-negative integer v, which is
+// at most nbBytes bytes long. The integer is encoded as a big-endian byte
+// string without leading zeros, in particular zero is encoded as an empty
+// string.
+func (c *Codec) EncodeUint(v frontend.Variable, nbBytes int) Bytes {
+	api := c.api
+	bs := c.toBytes(v, nbBytes)
+	lz := c.leadingZeros(bs)
+	t := logderivlookup.New(api)
+	for i := range bs {
+		t.Insert(bs[i])
+	}
+	for i := 0; i < nbBytes; i++ {
+		t.Insert(0)
+	}
+	res := Bytes{Data: make([]uints.U8, nbBytes), Length: api.Sub(nbBytes, lz)}
+	for i := range res.Data {
+		res.Data[i] = uints.U8{Val: t.Lookup(api.Add(lz, i))[0]}
+	}
+	return c.EncodeString(res)
+}
+
+// EncodeList returns the RLP encoding of the list of items. The items must be
+// RLP-encoded. The maximum length of the result is computed as in
+// [Codec.EncodeString].
+func (c *Codec) EncodeList(items ...Bytes) Bytes {
+	payload := Bytes{Length: 0}
+	for i := range items {
+		payload = c.concat(payload, items[i])
+	}
+	hdr := c.header(payload.Length, len(payload.Data), offsetList)
+	return c.concat(hdr, payload)
+}
@@ -0,0 +1,143 @@
+package rlp
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/selector"
+)
+
+const (
+	// offsetString is the first byte of the string header.
+	offsetString = 0x80
+	// offsetList is the first byte of the list header.
+	offsetList = 0xc0
+	// maxShortLength is the maximum payload length with a single byte header.
+	maxShortLength = 55
+)
+
+// Bytes is a variable-length byte string with the maximum length len(Data).
+type Bytes struct {
+	// Data is the byte string padded with zeros to the maximum length.
+	Data []uints.U8
+	// Length is the actual length of the byte string.
+	Length frontend.Variable
+}
+
+// PlaceholderBytes returns a placeholder for the byte string of maximum length
+// maxLen for compiling the circuit.
+func PlaceholderBytes(maxLen int) Bytes {
+	return Bytes{Data: make([]uints.U8, maxLen)}
+}
+
+// ValueOfBytes returns the witness assignment for the byte string b padded to
+// the maximum length maxLen.
+func ValueOfBytes(b []byte, maxLen int) (Bytes, error) {
+	if len(b) > maxLen {
+		return Bytes{}, fmt.Errorf("length %d exceeds maximum length %d", len(b), maxLen)
+	}
+	buf := make([]byte, maxLen)
+	copy(buf, b)
+	return Bytes{Data: uints.NewU8Array(buf), Length: len(b)}, nil
+}
+
+// Codec implements RLP encoding and decoding of variable-length byte strings.
+type Codec struct {
+	api frontend.API
+}
+
+// New returns a new [Codec].
+func New(api frontend.API) *Codec {
+	return &Codec{api: api}
+}
+
+// nbLengthBytes returns the number of bytes required to encode length maxLen.
+func nbLengthBytes(maxLen int) int {
+	var res int
+	for ; maxLen > 0; maxLen >>= 8 {
+		res++
+	}
+	return res
+}
+
+// lengthIndicators returns indicators eq where eq[k] == 1 iff length == k and
+// asserts that exactly one of them is set, i.e. that length <= maxLen.
+func (c *Codec) lengthIndicators(length frontend.Variable, maxLen int) []frontend.Variable {
+	eq := make([]frontend.Variable, maxLen+1)
+	var eqSum frontend.Variable = 0
+	for k := range eq {
+		eq[k] = c.api.IsZero(c.api.Sub(length, k))
+		eqSum = c.api.Add(eqSum, eq[k])
+	}
+	c.api.AssertIsEqual(eqSum, 1)
+	return eq
+}
+
+// truncate returns the bytes of in with the bytes after in.Length zeroed. It
+// asserts that in.Length <= len(in.Data).
+func (c *Codec) truncate(in Bytes) Bytes {
+	vals := make([]frontend.Variable, len(in.Data))
+	for i := range in.Data {
+		vals[i] = in.Data[i].Val
+	}
+	switch len(vals) {
+	case 0:
+		c.api.AssertIsEqual(in.Length, 0)
+	case 1:
+		c.api.AssertIsBoolean(in.Length)
+		vals[0] = c.api.Mul(vals[0], in.Length)
+	default:
+		vals = selector.Partition(c.api, in.Length, false, vals)
+	}
+	res := Bytes{Data: make([]uints.U8, len(vals)), Length: in.Length}
+	for i := range vals {
+		res.Data[i] = uints.U8{Val: vals[i]}
+	}
+	return res
+}
+
+// table returns a lookup table of the bytes of in, prefixed with before zeros
+// and suffixed with after zeros.
+func (c *Codec) table(in Bytes, before, after int) *logderivlookup.Table {
+	t := logderivlookup.New(c.api)
+	for i := 0; i < before; i++ {
+		t.Insert(0)
+	}
+	for i := range in.Data {
+		t.Insert(in.Data[i].Val)
+	}
+	for i := 0; i < after; i++ {
+		t.Insert(0)
+	}
+	return t
+}
+
+// extract returns the bytes of the table at [start, start+length) as a byte
+// string of maximum length maxLen.
+func (c *Codec) extract(t *logderivlookup.Table, start, length frontend.Variable, maxLen int) Bytes {
+	res := Bytes{Data: make([]uints.U8, maxLen), Length: length}
+	for i := range res.Data {
+		res.Data[i] = uints.U8{Val: t.Lookup(c.api.Add(start, i))[0]}
+	}
+	return c.truncate(res)
+}
+
+// concat returns the concatenation of a and b.
+func (c *Codec) concat(a, b Bytes) Bytes {
+	a = c.truncate(a)
+	b = c.truncate(b)
+	// the table is prefixed with len(a.Data) zeros, so that for i < a.Length
+	// we read zeros from the table.
+	t := c.table(b, len(a.Data), len(a.Data))
+	res := Bytes{Data: make([]uints.U8, len(a.Data)+len(b.Data)), Length: c.api.Add(a.Length, b.Length)}
+	for i := range res.Data {
+		v := t.Lookup(c.api.Add(c.api.Sub(i, a.Length), len(a.Data)))[0]
+		if i < len(a.Data) {
+			v = c.api.Add(v, a.Data[i].Val)
+		}
+		res.Data[i] = uints.U8{Val: v}
+	}
+	return res
+}
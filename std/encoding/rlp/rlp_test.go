@@ -0,0 +1,272 @@
+package rlp
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+func nativeHeader(length int, offset byte) []byte {
+	if length <= maxShortLength {
+		return []byte{offset + byte(length)}
+	}
+	var lb []byte
+	for l := length; l > 0; l >>= 8 {
+		lb = append([]byte{byte(l)}, lb...)
+	}
+	return append([]byte{offset + maxShortLength + byte(len(lb))}, lb...)
+}
+
+func nativeString(b []byte) []byte {
+	if len(b) == 1 && b[0] < offsetString {
+		return []byte{b[0]}
+	}
+	return append(nativeHeader(len(b), offsetString), b...)
+}
+
+func nativeList(items ...[]byte) []byte {
+	payload := bytes.Join(items, nil)
+	return append(nativeHeader(len(payload), offsetList), payload...)
+}
+
+func assertBytesEqual(api frontend.API, expected, actual Bytes) {
+	if len(expected.Data) != len(actual.Data) {
+		panic(fmt.Sprintf("length mismatch %d != %d", len(expected.Data), len(actual.Data)))
+	}
+	api.AssertIsEqual(expected.Length, actual.Length)
+	for i := range expected.Data {
+		api.AssertIsEqual(expected.Data[i].Val, actual.Data[i].Val)
+	}
+}
+
+func mustValueOfBytes(t *testing.T, b []byte, maxLen int) Bytes {
+	res, err := ValueOfBytes(b, maxLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+type encodeStringCircuit struct {
+	In       Bytes
+	Expected Bytes
+}
+
+func (c *encodeStringCircuit) Define(api frontend.API) error {
+	codec := New(api)
+	res := codec.EncodeString(c.In)
+	assertBytesEqual(api, c.Expected, res)
+	return nil
+}
+
+func TestEncodeString(t *testing.T) {
+	assert := test.NewAssert(t)
+	const maxLen = 300
+	for _, in := range [][]byte{{}, {0x01}, {0x7f}, {0x80}, bytes.Repeat([]byte{0xaa}, 55), bytes.Repeat([]byte{0xbb}, 56), bytes.Repeat([]byte{0xcc}, 256), bytes.Repeat([]byte{0xdd}, maxLen)} {
+		assert.Run(func(assert *test.Assert) {
+			expected := nativeString(in)
+			circuit := encodeStringCircuit{In: PlaceholderBytes(maxLen), Expected: PlaceholderBytes(maxLen + 3)}
+			witness := encodeStringCircuit{In: mustValueOfBytes(t, in, maxLen), Expected: mustValueOfBytes(t, expected, maxLen+3)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("length=%d", len(in)))
+	}
+}
+
+type encodeUintCircuit struct {
+	In       frontend.Variable
+	Expected Bytes
+}
+
+func (c *encodeUintCircuit) Define(api frontend.API) error {
+	codec := New(api)
+	res := codec.EncodeUint(c.In, 32)
+	assertBytesEqual(api, c.Expected, res)
+	return nil
+}
+
+func TestEncodeUint(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, v := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(127), big.NewInt(128), big.NewInt(256), new(big.Int).Lsh(big.NewInt(1), 200)} {
+		assert.Run(func(assert *test.Assert) {
+			expected := nativeString(v.Bytes())
+			circuit := encodeUintCircuit{Expected: PlaceholderBytes(34)}
+			witness := encodeUintCircuit{In: v, Expected: mustValueOfBytes(t, expected, 34)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("value=%s", v.String()))
+	}
+}
+
+type encodeListCircuit struct {
+	Nonce    frontend.Variable
+	Data     Bytes
+	Expected Bytes
+}
+
+func (c *encodeListCircuit) Define(api frontend.API) error {
+	codec := New(api)
+	res := codec.EncodeList(codec.EncodeUint(c.Nonce, 8), codec.EncodeString(c.Data))
+	assertBytesEqual(api, c.Expected, res)
+	return nil
+}
+
+func TestEncodeList(t *testing.T) {
+	assert := test.NewAssert(t)
+	const maxLen = 100
+	// maximum length of the encoded list: list header, nonce and data
+	const maxEncodedLen = 2 + (2 + 8) + (2 + maxLen)
+	for _, data := range [][]byte{{}, {0x01}, bytes.Repeat([]byte{0xaa}, 40), bytes.Repeat([]byte{0xbb}, maxLen)} {
+		assert.Run(func(assert *test.Assert) {
+			nonce := big.NewInt(1234)
+			expected := nativeList(nativeString(nonce.Bytes()), nativeString(data))
+			circuit := encodeListCircuit{Data: PlaceholderBytes(maxLen), Expected: PlaceholderBytes(maxEncodedLen)}
+			witness := encodeListCircuit{Nonce: nonce, Data: mustValueOfBytes(t, data, maxLen), Expected: mustValueOfBytes(t, expected, maxEncodedLen)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("length=%d", len(data)))
+	}
+}
+
+type decodeStringCircuit struct {
+	In       Bytes
+	Expected Bytes
+}
+
+func (c *decodeStringCircuit) Define(api frontend.API) error {
+	codec := New(api)
+	res := codec.DecodeString(c.In, len(c.Expected.Data))
+	assertBytesEqual(api, c.Expected, res)
+	return nil
+}
+
+func TestDecodeString(t *testing.T) {
+	assert := test.NewAssert(t)
+	const maxLen = 300
+	for _, payload := range [][]byte{{}, {0x01}, {0x80}, bytes.Repeat([]byte{0xaa}, 55), bytes.Repeat([]byte{0xbb}, 56), bytes.Repeat([]byte{0xcc}, maxLen)} {
+		assert.Run(func(assert *test.Assert) {
+			in := nativeString(payload)
+			circuit := decodeStringCircuit{In: PlaceholderBytes(maxLen + 3), Expected: PlaceholderBytes(maxLen)}
+			witness := decodeStringCircuit{In: mustValueOfBytes(t, in, maxLen+3), Expected: mustValueOfBytes(t, payload, maxLen)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("length=%d", len(payload)))
+	}
+	// trailing data is not accepted
+	in := append(nativeString([]byte{0x80}), 0x01)
+	circuit := decodeStringCircuit{In: PlaceholderBytes(maxLen + 3), Expected: PlaceholderBytes(maxLen)}
+	witness := decodeStringCircuit{In: mustValueOfBytes(t, in, maxLen+3), Expected: mustValueOfBytes(t, []byte{0x80}, maxLen)}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// list is not accepted
+	in = nativeList(nativeString([]byte{0x80}))
+	witness = decodeStringCircuit{In: mustValueOfBytes(t, in, maxLen+3), Expected: mustValueOfBytes(t, in[1:], maxLen)}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type decodeCircuit struct {
+	In             Bytes
+	ExpectedIsList frontend.Variable
+	Expected       Bytes
+}
+
+func (c *decodeCircuit) Define(api frontend.API) error {
+	codec := New(api)
+	isList, res := codec.Decode(c.In, len(c.Expected.Data))
+	api.AssertIsEqual(isList, c.ExpectedIsList)
+	assertBytesEqual(api, c.Expected, res)
+	return nil
+}
+
+func TestDecode(t *testing.T) {
+	assert := test.NewAssert(t)
+	const maxLen = 100
+	list := nativeList(nativeString([]byte("cat")), nativeString(bytes.Repeat([]byte{0xaa}, 60)))
+	for i, tc := range []struct {
+		in      []byte
+		isList  int
+		payload []byte
+	}{
+		{nativeString([]byte{0x01}), 0, []byte{0x01}},
+		{nativeString(bytes.Repeat([]byte{0xbb}, 56)), 0, bytes.Repeat([]byte{0xbb}, 56)},
+		{nativeList(), 1, nil},
+		{list, 1, list[2:]},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			circuit := decodeCircuit{In: PlaceholderBytes(maxLen + 3), Expected: PlaceholderBytes(maxLen)}
+			witness := decodeCircuit{In: mustValueOfBytes(t, tc.in, maxLen+3), ExpectedIsList: tc.isList, Expected: mustValueOfBytes(t, tc.payload, maxLen)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+			// wrong item type
+			witness.ExpectedIsList = 1 - tc.isList
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.Error(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+const (
+	testMaxItems   = 4
+	testMaxItemLen = 70
+)
+
+type decodeListCircuit struct {
+	In              Bytes
+	ExpectedItems   [testMaxItems]Bytes
+	ExpectedNbItems frontend.Variable
+	// ExpectedNested are the items of the nested list in the last item.
+	ExpectedNested [2]Bytes
+}
+
+func (c *decodeListCircuit) Define(api frontend.API) error {
+	codec := New(api)
+	items, nbItems := codec.DecodeList(c.In, testMaxItems, testMaxItemLen)
+	api.AssertIsEqual(nbItems, c.ExpectedNbItems)
+	for i := range items {
+		assertBytesEqual(api, c.ExpectedItems[i], items[i])
+	}
+	nested, nbNested := codec.DecodeList(items[2], 2, 10)
+	api.AssertIsEqual(nbNested, 2)
+	for i := range nested {
+		s := codec.DecodeString(nested[i], 8)
+		assertBytesEqual(api, c.ExpectedNested[i], s)
+	}
+	return nil
+}
+
+func TestDecodeList(t *testing.T) {
+	assert := test.NewAssert(t)
+	const maxLen = 200
+	items := [][]byte{
+		nativeString([]byte{0x01}),
+		nativeString(bytes.Repeat([]byte{0xaa}, 60)),
+		nativeList(nativeString([]byte("cat")), nativeString([]byte("dog"))),
+	}
+	in := nativeList(items...)
+	circuit := decodeListCircuit{In: PlaceholderBytes(maxLen)}
+	witness := decodeListCircuit{In: mustValueOfBytes(t, in, maxLen), ExpectedNbItems: len(items)}
+	for i := range circuit.ExpectedItems {
+		circuit.ExpectedItems[i] = PlaceholderBytes(testMaxItemLen)
+		var item []byte
+		if i < len(items) {
+			item = items[i]
+		}
+		witness.ExpectedItems[i] = mustValueOfBytes(t, item, testMaxItemLen)
+	}
+	for i, s := range []string{"cat", "dog"} {
+		circuit.ExpectedNested[i] = PlaceholderBytes(8)
+		witness.ExpectedNested[i] = mustValueOfBytes(t, []byte(s), 8)
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// wrong number of items
+	witness.ExpectedNbItems = len(items) - 1
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha3"
 	"github.com/consensys/gnark/std/math/bits"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
 )
 
 // ECRecover implements [ECRECOVER] precompile contract at address 0x01.
@@ -84,3 +86,130 @@ func ECRecover(api frontend.API, msg emulated.Element[emulated.Secp256k1Fr],
 	curve.AssertIsEqual(C, &P)
 	return &P
 }
+
+// ECRecoverAddress implements [ECRECOVER] precompile contract at address 0x01,
+// but instead of the public key returns the Ethereum address
+// keccak256(x || y)[12:] of the recovered public key as a native field
+// element.
+//
+// Contrary to [ECRecover], the function does not fail for invalid inputs but
+// returns zero as the precompile does. The input is invalid when v is not in
+// {27, 28}, r or s are not in [1, n-1] (s in [1, (n-1)/2] when strictRange is
+// set), r is not a x-coordinate of a point on the curve or the recovered public
+// key is the point at infinity.
+//
+// [ECRECOVER]: https://ethereum.github.io/execution-specs/autoapi/ethereum/paris/vm/precompiled_contracts/ecrecover/index.html
+func ECRecoverAddress(api frontend.API, msg emulated.Element[emulated.Secp256k1Fr],
+	v frontend.Variable, r, s emulated.Element[emulated.Secp256k1Fr],
+	strictRange frontend.Variable) frontend.Variable {
+	var emfp emulated.Secp256k1Fp
+	var emfr emulated.Secp256k1Fr
+	fpField, err := emulated.NewField[emulated.Secp256k1Fp](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	frField, err := emulated.NewField[emulated.Secp256k1Fr](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	curve, err := sw_emulated.New[emulated.Secp256k1Fp, emulated.Secp256k1Fr](api, sw_emulated.GetSecp256k1Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		panic(fmt.Sprintf("new uints: %v", err))
+	}
+	h, err := sha3.NewLegacyKeccak256(api)
+	if err != nil {
+		panic(fmt.Sprintf("new keccak: %v", err))
+	}
+
+	// 1- check that v \in {27, 28}. EVM uses v \in {27, 28}, but everyone
+	// else v >= 0. Convert back
+	v = api.Sub(v, 27)
+	vIsValid := api.IsZero(api.Mul(v, api.Sub(v, 1)))
+	v = api.Select(vIsValid, v, 0)
+
+	// 2- check that 0 < r < n and 0 < s < n (or 0 < s <= (n-1)/2 in strict
+	// mode).
+	rbits := frField.ToBits(&r)
+	rIsValid := api.And(
+		api.Sub(1, isZeroBits(api, rbits)),
+		isLessThanConst(api, rbits, emfr.Modulus()),
+	)
+	halfFr := new(big.Int).Sub(emfr.Modulus(), big.NewInt(1))
+	halfFr.Div(halfFr, big.NewInt(2))
+	sbits := frField.ToBits(&s)
+	sIsValid := api.And(
+		api.Sub(1, isZeroBits(api, sbits)),
+		api.Select(strictRange,
+			isLessThanConst(api, sbits, new(big.Int).Add(halfFr, big.NewInt(1))),
+			isLessThanConst(api, sbits, emfr.Modulus()),
+		),
+	)
+
+	// 3- recover the point R = (r, y). The hint returns a flag indicating if
+	// r^3+7 is a quadratic residue and a square root of r^3+7 or -(r^3+7)
+	// respectively. As p = 3 mod 4, then -1 is a non-residue and exactly one
+	// of the square roots exists.
+	Rlimbs, err := api.Compiler().NewHint(recoverYHint, 1+int(emfp.NbLimbs()), recoverPointHintArgs(v, r)...)
+	if err != nil {
+		panic(fmt.Sprintf("point hint: %v", err))
+	}
+	isQR := Rlimbs[0]
+	api.AssertIsBoolean(isQR)
+	rfp := fpField.FromBits(rbits...)
+	Ry := fpField.NewElement(Rlimbs[1:])
+	rhs := fpField.Mul(rfp, rfp)
+	rhs = fpField.Mul(rhs, rfp)
+	rhs = fpField.Add(rhs, fpField.NewElement(7))
+	fpField.AssertIsEqual(fpField.Mul(Ry, Ry), fpField.Select(isQR, rhs, fpField.Neg(rhs)))
+	// check that oddity(y) = v when the point exists
+	Ry = fpField.Reduce(Ry)
+	fpField.AssertIsInRange(Ry)
+	Rybits := fpField.ToBits(Ry)
+	api.AssertIsEqual(api.Mul(isQR, api.Sub(Rybits[0], v)), 0)
+
+	isValid := api.And(api.And(vIsValid, isQR), api.And(rIsValid, sIsValid))
+
+	// 4- replace invalid inputs with dummy values so that the computations
+	// below are always satisfiable. The result is 0 in that case.
+	R := curve.Select(isValid, &sw_emulated.AffinePoint[emulated.Secp256k1Fp]{X: *rfp, Y: *Ry}, curve.Generator())
+	rr := frField.Select(isValid, &r, frField.One())
+	// compute rinv = r^{-1} mod fr
+	rinv := frField.Inverse(rr)
+	// compute u1 = -msg * rinv
+	u1 := frField.MulMod(&msg, rinv)
+	u1 = frField.Neg(u1)
+	// compute u2 = s * rinv
+	u2 := frField.MulMod(&s, rinv)
+	// compute P = u1 * G + u2 R. We use the complete methods as the
+	// intermediate results may be the point at infinity.
+	P := curve.AddUnified(curve.ScalarMulBase(u1), curve.ScalarMulGeneric(R, u2))
+
+	// 5- serialize the public key as 64 bytes big-endian x || y and check
+	// that it is not the point at infinity.
+	Px := fpField.Reduce(&P.X)
+	fpField.AssertIsInRange(Px)
+	Py := fpField.Reduce(&P.Y)
+	fpField.AssertIsInRange(Py)
+	Pxbits := fpField.ToBits(Px)
+	Pybits := fpField.ToBits(Py)
+	isValid = api.And(isValid, api.Sub(1, api.And(isZeroBits(api, Pxbits), isZeroBits(api, Pybits))))
+	nbBytes := (emfp.Modulus().BitLen() + 7) / 8
+	pk := make([]uints.U8, 2*nbBytes)
+	for i := 0; i < nbBytes; i++ {
+		pk[nbBytes-1-i] = uapi.ByteValueOf(bits.FromBinary(api, Pxbits[8*i:8*i+8]))
+		pk[2*nbBytes-1-i] = uapi.ByteValueOf(bits.FromBinary(api, Pybits[8*i:8*i+8]))
+	}
+
+	// 6- the address is the last 20 bytes of the hash
+	h.Write(pk)
+	digest := h.Sum()
+	var addr frontend.Variable = 0
+	for i := len(digest) - 20; i < len(digest); i++ {
+		addr = api.Add(api.Mul(addr, 256), digest[i].Val)
+	}
+	return api.Select(isValid, addr, 0)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/sha3"
 )
 
 func TestSignForRecoverCorrectness(t *testing.T) {
@@ -140,3 +141,81 @@ func TestECRecoverCircuitFull(t *testing.T) {
 		test.NoProverChecks(),
 	)
 }
+
+type ecrecoverAddressCircuit struct {
+	Message  emulated.Element[emulated.Secp256k1Fr]
+	V        frontend.Variable
+	R        emulated.Element[emulated.Secp256k1Fr]
+	S        emulated.Element[emulated.Secp256k1Fr]
+	Strict   frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *ecrecoverAddressCircuit) Define(api frontend.API) error {
+	res := ECRecoverAddress(api, c.Message, c.V, c.R, c.S, c.Strict)
+	api.AssertIsEqual(res, c.Expected)
+	return nil
+}
+
+func TestECRecoverAddressCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	halfFr := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	halfFr.Div(halfFr, big.NewInt(2))
+	sk, err := ecdsa.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	pk := sk.PublicKey
+	msg := []byte("test")
+	v, r, s, err := sk.SignForRecover(msg, nil)
+	assert.NoError(err)
+	// compute the expected address
+	pkx, pky := pk.A.X.Bytes(), pk.A.Y.Bytes()
+	h := sha3.NewLegacyKeccak256()
+	h.Write(pkx[:])
+	h.Write(pky[:])
+	addr := new(big.Int).SetBytes(h.Sum(nil)[12:])
+	// find r which is not a x-coordinate of a point on the curve
+	p := emulated.Secp256k1Fp{}.Modulus()
+	rNotOnCurve := big.NewInt(1)
+	for {
+		rhs := new(big.Int).Exp(rNotOnCurve, big.NewInt(3), p)
+		rhs.Add(rhs, big.NewInt(7))
+		if big.Jacobi(rhs, p) == -1 {
+			break
+		}
+		rNotOnCurve.Add(rNotOnCurve, big.NewInt(1))
+	}
+	// s which is always out of range in the strict mode
+	sHigh := new(big.Int).Add(halfFr, big.NewInt(1))
+
+	testCases := []struct {
+		name     string
+		v        uint
+		r, s     *big.Int
+		strict   int
+		expected *big.Int
+	}{
+		{"valid", v + 27, r, s, 0, addr},
+		{"invalid v", v + 29, r, s, 0, big.NewInt(0)},
+		{"zero r", v + 27, big.NewInt(0), s, 0, big.NewInt(0)},
+		{"zero s", v + 27, r, big.NewInt(0), 0, big.NewInt(0)},
+		{"r equals n", v + 27, fr.Modulus(), s, 0, big.NewInt(0)},
+		{"s equals n", v + 27, r, fr.Modulus(), 0, big.NewInt(0)},
+		{"high s strict", v + 27, r, sHigh, 1, big.NewInt(0)},
+		{"r not on curve", v + 27, rNotOnCurve, s, 0, big.NewInt(0)},
+	}
+	for _, tc := range testCases {
+		assert.Run(func(assert *test.Assert) {
+			circuit := ecrecoverAddressCircuit{}
+			witness := ecrecoverAddressCircuit{
+				Message:  emulated.ValueOf[emulated.Secp256k1Fr](ecdsa.HashToInt(msg)),
+				V:        tc.v,
+				R:        emulated.ValueOf[emulated.Secp256k1Fr](tc.r),
+				S:        emulated.ValueOf[emulated.Secp256k1Fr](tc.s),
+				Strict:   tc.strict,
+				Expected: tc.expected,
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, tc.name)
+	}
+}
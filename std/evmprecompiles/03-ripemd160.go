@@ -0,0 +1,27 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/ripemd160"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// RIPEMD160 implements [RIPEMD160] precompile contract at address 0x03.
+//
+// The output is 32 bytes, where the 20-byte digest is left-padded with zeros
+// as in the EVM.
+//
+// [RIPEMD160]: https://ethereum.github.io/execution-specs/autoapi/ethereum/paris/vm/precompiled_contracts/ripemd160/index.html
+func RIPEMD160(api frontend.API, data []uints.U8) []uints.U8 {
+	h, err := ripemd160.New(api)
+	if err != nil {
+		panic(fmt.Sprintf("new ripemd160: %v", err))
+	}
+	h.Write(data)
+	dgst := h.Sum()
+	res := uints.NewU8Array(make([]uint8, 32-len(dgst)))
+	res = append(res, dgst...)
+	return res
+}
@@ -0,0 +1,46 @@
+package evmprecompiles
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // used as reference implementation
+)
+
+type ripemd160Circuit struct {
+	In       []uints.U8
+	Expected [32]uints.U8
+}
+
+func (c *ripemd160Circuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return fmt.Errorf("new uints api: %w", err)
+	}
+	res := RIPEMD160(api, c.In)
+	if len(res) != 32 {
+		return fmt.Errorf("expected 32 bytes, got %d", len(res))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestRIPEMD160(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := []byte("hello world, this is the RIPEMD160 precompile test")
+	h := ripemd160.New()
+	h.Write(in)
+	dgst := h.Sum(nil)
+	expected := make([]byte, 32)
+	copy(expected[12:], dgst)
+	assignment := &ripemd160Circuit{In: uints.NewU8Array(in)}
+	copy(assignment.Expected[:], uints.NewU8Array(expected))
+	err := test.IsSolved(&ripemd160Circuit{In: make([]uints.U8, len(in))}, assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
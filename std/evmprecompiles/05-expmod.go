@@ -1 +1,46 @@
 package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// Expmod implements [MODEXP] precompile contract at address 0x05.
+//
+// Internally, uses arithmetic over a variable modulus. The type parameter
+// defines the maximum length of the inputs. For inputs up to 256 bytes use
+// [emparams.Mod1e2048] and for inputs up to 512 bytes use [emparams.Mod1e4096].
+// The cost of the method depends on the maximum length and not on the actual
+// length of the inputs.
+//
+// The result is fully reduced, i.e. strictly less than the modulus. As in the
+// EVM, if the modulus is zero, then the result is zero.
+//
+// [MODEXP]: https://ethereum.github.io/execution-specs/autoapi/ethereum/paris/vm/precompiled_contracts/expmod/index.html
+func Expmod[P emulated.FieldParams](api frontend.API, base, exp, modulus *emulated.Element[P]) *emulated.Element[P] {
+	f, err := emulated.NewField[P](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	// x mod 0 = 0. The limbs of the modulus are width-constrained, so the sum
+	// of the limbs does not overflow and is zero only when all limbs are zero.
+	// We cannot use [emulated.Field.IsZero] as it reduces the modulus by the
+	// type parameter modulus.
+	var limbSum frontend.Variable = 0
+	for i := range modulus.Limbs {
+		limbSum = api.Add(limbSum, modulus.Limbs[i])
+	}
+	isZeroMod := api.IsZero(limbSum)
+	// in case the modulus is zero, then compute with dummy modulus and return
+	// zero as a result.
+	modulus = f.Select(isZeroMod, f.One(), modulus)
+	res := f.ModExp(base, exp, modulus)
+	// x^0 = 1, unless the modulus is 1. Reduce and ensure the result is in
+	// range.
+	res = f.ModReduce(res, modulus)
+	f.ModAssertIsInRange(res, modulus)
+	res = f.Select(isZeroMod, f.Zero(), res)
+	return res
+}
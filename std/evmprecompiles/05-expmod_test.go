@@ -0,0 +1,123 @@
+package evmprecompiles
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	"github.com/consensys/gnark/test"
+)
+
+type expmodCircuit[P emulated.FieldParams] struct {
+	Base   emulated.Element[P]
+	Exp    emulated.Element[P]
+	Mod    emulated.Element[P]
+	Result emulated.Element[P]
+}
+
+func (c *expmodCircuit[P]) Define(api frontend.API) error {
+	f, err := emulated.NewField[P](api)
+	if err != nil {
+		return fmt.Errorf("new field: %w", err)
+	}
+	res := Expmod(api, &c.Base, &c.Exp, &c.Mod)
+	f.AssertLimbsEquality(&c.Result, res)
+	return nil
+}
+
+func testInstance(base, exp, modulus *big.Int) error {
+	return testInstanceWithParams[emparams.Mod1e512](base, exp, modulus)
+}
+
+func testInstanceWithParams[P emulated.FieldParams](base, exp, modulus *big.Int) error {
+	var res *big.Int
+	if modulus.Sign() == 0 {
+		res = big.NewInt(0)
+	} else {
+		res = new(big.Int).Exp(base, exp, modulus)
+	}
+	circuit := &expmodCircuit[P]{}
+	assignment := &expmodCircuit[P]{
+		Base:   emulated.ValueOf[P](base),
+		Exp:    emulated.ValueOf[P](exp),
+		Mod:    emulated.ValueOf[P](modulus),
+		Result: emulated.ValueOf[P](res),
+	}
+	return test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+}
+
+func TestRandomInstance(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, bits := range []int{256, 512} {
+		assert.Run(func(assert *test.Assert) {
+			modulus := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+			base, _ := rand.Int(rand.Reader, modulus)
+			exp, _ := rand.Int(rand.Reader, modulus)
+			modulus, _ = rand.Int(rand.Reader, modulus)
+			err := testInstance(base, exp, modulus)
+			assert.NoError(err)
+		}, fmt.Sprintf("random-%d", bits))
+	}
+}
+
+func TestRandomInstance2048(t *testing.T) {
+	// the MODEXP precompile is commonly used with 256 byte operands (RSA-2048)
+	assert := test.NewAssert(t)
+	bound := new(big.Int).Lsh(big.NewInt(1), 2048)
+	base, _ := rand.Int(rand.Reader, bound)
+	exp, _ := rand.Int(rand.Reader, bound)
+	modulus, _ := rand.Int(rand.Reader, bound)
+	modulus.SetBit(modulus, 2047, 1)
+	err := testInstanceWithParams[emparams.Mod1e2048](base, exp, modulus)
+	assert.NoError(err)
+	// the largest modulus and small RSA public exponent
+	modulus = emparams.Mod1e2048{}.Modulus()
+	err = testInstanceWithParams[emparams.Mod1e2048](base, big.NewInt(65537), modulus)
+	assert.NoError(err)
+}
+
+func TestEdgeCases(t *testing.T) {
+	assert := test.NewAssert(t)
+	testCases := []struct {
+		base, exp, modulus *big.Int
+	}{
+		{big.NewInt(0), big.NewInt(0), big.NewInt(0)},                                 // 0^0 mod 0 = 0
+		{big.NewInt(0), big.NewInt(0), big.NewInt(1)},                                 // 0^0 mod 1 = 0
+		{big.NewInt(0), big.NewInt(0), big.NewInt(2)},                                 // 0^0 mod 2 = 1
+		{big.NewInt(0), big.NewInt(0), big.NewInt(3)},                                 // 0^0 mod 3 = 1
+		{big.NewInt(0), big.NewInt(1), big.NewInt(3)},                                 // 0^1 mod 3 = 0
+		{big.NewInt(3), big.NewInt(0), big.NewInt(3)},                                 // 3^0 mod 3 = 1
+		{big.NewInt(5), big.NewInt(1), big.NewInt(3)},                                 // 5^1 mod 3 = 2
+		{big.NewInt(5), big.NewInt(2), big.NewInt(3)},                                 // 5^2 mod 3 = 1
+		{big.NewInt(5), big.NewInt(3), big.NewInt(0)},                                 // 5^3 mod 0 = 0
+		{emparams.Mod1e512{}.Modulus(), big.NewInt(2), emparams.Mod1e512{}.Modulus()}, // largest modulus
+	}
+	for i, tc := range testCases {
+		assert.Run(func(assert *test.Assert) {
+			err := testInstance(tc.base, tc.exp, tc.modulus)
+			assert.NoError(err)
+		}, fmt.Sprintf("edge-%d", i))
+	}
+}
+
+func TestWrongResult(t *testing.T) {
+	assert := test.NewAssert(t)
+	base, exp, modulus := big.NewInt(5), big.NewInt(3), big.NewInt(7)
+	// 5^3 mod 7 = 6. Unreduced result must not be accepted.
+	for _, wrong := range []int64{5, 13} {
+		circuit := &expmodCircuit[emparams.Mod1e512]{}
+		assignment := &expmodCircuit[emparams.Mod1e512]{
+			Base:   emulated.ValueOf[emparams.Mod1e512](base),
+			Exp:    emulated.ValueOf[emparams.Mod1e512](exp),
+			Mod:    emulated.ValueOf[emparams.Mod1e512](modulus),
+			Result: emulated.ValueOf[emparams.Mod1e512](wrong),
+		}
+		err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}
+}
@@ -0,0 +1,35 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2"
+)
+
+// BLAKE2F implements [BLAKE2F] precompile contract at address 0x09.
+//
+// The input h is the state vector, m the message block vector, t the offset
+// counter and final the final block indicator flag, which must be boolean. The
+// words are in little-endian byte order, as in the EVM input encoding.
+//
+// In the EVM the number of rounds is a 4-byte input. In-circuit the number of
+// rounds is a variable, but it is bounded by maxRounds which is fixed at circuit
+// compile time. The method asserts that rounds is at most maxRounds, so that
+// calls with more rounds cannot be proven. The cost of the method is linear in
+// maxRounds.
+//
+// [BLAKE2F]: https://eips.ethereum.org/EIPS/eip-152
+func BLAKE2F(api frontend.API, maxRounds int, rounds frontend.Variable, h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, final frontend.Variable) [8]uints.U64 {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		panic(fmt.Sprintf("new uints api: %v", err))
+	}
+	api.AssertIsBoolean(final)
+	var f uints.U64
+	for i := range f {
+		f[i] = uints.U8{Val: api.Mul(final, 0xff)}
+	}
+	return blake2.Blake2bVariableRounds(api, uapi, maxRounds, rounds, h, m, t, f)
+}
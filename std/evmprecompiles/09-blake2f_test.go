@@ -0,0 +1,96 @@
+package evmprecompiles
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type blake2fCircuit struct {
+	Rounds   frontend.Variable
+	H        [8]uints.U64
+	M        [16]uints.U64
+	T        [2]uints.U64
+	F        frontend.Variable
+	Expected [8]uints.U64
+
+	maxRounds int
+}
+
+func (c *blake2fCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return fmt.Errorf("new uints api: %w", err)
+	}
+	res := BLAKE2F(api, c.maxRounds, c.Rounds, c.H, c.M, c.T, c.F)
+	for i := range c.Expected {
+		uapi.AssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+// blake2fVector returns the witness for the EIP-152 test vectors, which all
+// hash "abc".
+func blake2fVector(rounds, final int, expected string) (*blake2fCircuit, error) {
+	expectedBts, err := hex.DecodeString(expected)
+	if err != nil {
+		return nil, err
+	}
+	h := []uint64{
+		0x6a09e667f2bdc948, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+		0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+	}
+	m := make([]uint64, 16)
+	m[0] = 0x0000000000636261 // "abc"
+	witness := &blake2fCircuit{Rounds: rounds, F: final}
+	copy(witness.H[:], uints.NewU64Array(h))
+	copy(witness.M[:], uints.NewU64Array(m))
+	witness.T = [2]uints.U64{uints.NewU64(3), uints.NewU64(0)}
+	for j := range witness.Expected {
+		witness.Expected[j] = uints.NewU64(binary.LittleEndian.Uint64(expectedBts[8*j:]))
+	}
+	return witness, nil
+}
+
+func TestBLAKE2F(t *testing.T) {
+	assert := test.NewAssert(t)
+	// test vectors 4, 5, 6 and 7 from EIP-152
+	testCases := []struct {
+		rounds   int
+		final    int
+		expected string
+	}{
+		{0, 1, "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b"},
+		{12, 1, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{12, 0, "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735"},
+		{1, 1, "b63a380cb2897d521994a85234ee2c181b5f844d2c624c002677e9703449d2fba551b3a8333bcdf5f2f7e08993d53923de3d64fcc68c034e717b9293fed7a421"},
+	}
+	for i, tc := range testCases {
+		assert.Run(func(assert *test.Assert) {
+			witness, err := blake2fVector(tc.rounds, tc.final, tc.expected)
+			assert.NoError(err)
+			err = test.IsSolved(&blake2fCircuit{maxRounds: 12}, witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+			// wrong number of rounds
+			witness.Rounds = tc.rounds + 1
+			err = test.IsSolved(&blake2fCircuit{maxRounds: 12}, witness, ecc.BN254.ScalarField())
+			assert.Error(err)
+		}, fmt.Sprintf("vector-%d", i+4))
+	}
+}
+
+func TestBLAKE2FTooManyRounds(t *testing.T) {
+	assert := test.NewAssert(t)
+	// without the bound, all the 12 rounds would be applied for 13 rounds and
+	// the result would match the one of 12 rounds.
+	witness, err := blake2fVector(13, 1, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923")
+	assert.NoError(err)
+	err = test.IsSolved(&blake2fCircuit{maxRounds: 12}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,150 @@
+package evmprecompiles
+
+import (
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/commitments/kzg"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// versionedHashVersionKzg is the version byte of the versioned hash of a KZG
+// commitment, as defined in EIP-4844.
+const versionedHashVersionKzg = 0x01
+
+// KzgPointEvaluation implements [KZG_POINT_EVALUATION] precompile contract at
+// address 0x0a.
+//
+// It asserts that versionedHash corresponds to the commitment and that the
+// polynomial committed in commitment evaluates to claimedValue at
+// evaluationPoint. The input setupG2 is the [τ]G₂ element of the trusted setup.
+// It is embedded into the circuit as a constant with precomputed lines.
+//
+// The versioned hash is computed over the compressed serialization of the
+// commitment. As per the EIP, the commitment and proof are checked to be in
+// G1. The point at infinity is encoded as (0,0) and is accepted both for the
+// commitment and the proof, e.g. for the commitment to a constant polynomial.
+//
+// As the output of the precompile is constant (FIELD_ELEMENTS_PER_BLOB and
+// BLS_MODULUS), then the function does not return anything besides an error
+// when the gadget cannot be initialized.
+//
+// [KZG_POINT_EVALUATION]: https://eips.ethereum.org/EIPS/eip-4844#point-evaluation-precompile
+func KzgPointEvaluation(api frontend.API, versionedHash [32]uints.U8, evaluationPoint, claimedValue *sw_bls12381.Scalar, commitment, proof *sw_bls12381.G1Affine, setupG2 bls12381.G2Affine) error {
+	fp, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		return fmt.Errorf("new base field: %w", err)
+	}
+	fr, err := emulated.NewField[sw_bls12381.ScalarField](api)
+	if err != nil {
+		return fmt.Errorf("new scalar field: %w", err)
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return fmt.Errorf("new curve: %w", err)
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+
+	// -- check the versioned hash
+	h, err := sha2.New(api)
+	if err != nil {
+		return fmt.Errorf("new sha256: %w", err)
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return fmt.Errorf("new uints api: %w", err)
+	}
+	h.Write(compressG1(api, fp, commitment))
+	dgst := h.Sum()
+	uapi.ByteAssertEq(versionedHash[0], uints.NewU8(versionedHashVersionKzg))
+	for i := 1; i < len(versionedHash); i++ {
+		uapi.ByteAssertEq(versionedHash[i], dgst[i])
+	}
+
+	// -- check that the commitment and the proof are in G1 or at infinity.
+	// The subgroup check is incomplete for (0,0), so we check the generator
+	// instead.
+	_, _, g1, g2 := bls12381.Generators()
+	genG1 := sw_bls12381.NewG1Affine(g1)
+	isInfinityCom := api.And(fp.IsZero(&commitment.X), fp.IsZero(&commitment.Y))
+	isInfinityProof := api.And(fp.IsZero(&proof.X), fp.IsZero(&proof.Y))
+	pairing.AssertIsOnG1(curve.Select(isInfinityCom, &genG1, commitment))
+	pairing.AssertIsOnG1(curve.Select(isInfinityProof, &genG1, proof))
+
+	// -- check the opening proof
+	// the EVM rejects non-canonical field elements
+	fr.AssertIsInRange(evaluationPoint)
+	fr.AssertIsInRange(claimedValue)
+	vk := kzg.VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine]{
+		G1: genG1,
+		G2: [2]sw_bls12381.G2Affine{
+			sw_bls12381.NewG2AffineFixed(g2),
+			sw_bls12381.NewG2AffineFixed(setupG2),
+		},
+	}
+	verifier, err := kzg.NewVerifier[sw_bls12381.ScalarField, sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl](api)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	if err := verifier.CheckOpeningProof(
+		kzg.Commitment[sw_bls12381.G1Affine]{G1El: *commitment},
+		kzg.OpeningProof[sw_bls12381.ScalarField, sw_bls12381.G1Affine]{Quotient: *proof, ClaimedValue: *claimedValue},
+		*evaluationPoint, vk, algopts.WithCompleteArithmetic()); err != nil {
+		return fmt.Errorf("check opening proof: %w", err)
+	}
+	return nil
+}
+
+// compressG1 returns the 48-byte compressed serialization of the point P. The
+// serialization is the big-endian encoding of the x-coordinate, where the most
+// significant bit is the compression flag, the second most significant bit
+// indicates the point at infinity and the third most significant bit indicates
+// if the y-coordinate is lexicographically largest. The point at infinity
+// (0,0) is serialized as 0xc0 followed by zeros.
+func compressG1(api frontend.API, fp *emulated.Field[sw_bls12381.BaseField], P *sw_bls12381.G1Affine) []uints.U8 {
+	x := fp.Reduce(&P.X)
+	fp.AssertIsInRange(x)
+	y := fp.Reduce(&P.Y)
+	fp.AssertIsInRange(y)
+	xBits := fp.ToBits(x)
+	yBits := fp.ToBits(y)
+	isInfinity := api.And(fp.IsZero(x), fp.IsZero(y))
+
+	// y is lexicographically largest iff y > (p-1)/2. We compare the bits
+	// starting from the most significant bit. For the point at infinity y=0,
+	// so the flag is not set.
+	var fpParams sw_bls12381.BaseField
+	half := new(big.Int).Sub(fpParams.Modulus(), big.NewInt(1))
+	half.Rsh(half, 1)
+	var isGreater, isEqual frontend.Variable = 0, 1
+	for i := len(yBits) - 1; i >= 0; i-- {
+		if half.Bit(i) == 1 {
+			isEqual = api.Mul(isEqual, yBits[i])
+		} else {
+			isGreater = api.Add(isGreater, api.Mul(isEqual, yBits[i]))
+			isEqual = api.Sub(isEqual, api.Mul(isEqual, yBits[i]))
+		}
+	}
+
+	nbBytes := (fpParams.Modulus().BitLen() + 7) / 8
+	res := make([]uints.U8, nbBytes)
+	for i := range res {
+		// big-endian byte order
+		res[nbBytes-i-1] = uints.U8{Val: bits.FromBinary(api, xBits[8*i:8*i+8])}
+	}
+	// the modulus is 381 bits, so the three most significant bits of x are
+	// zero and we can set the flags by addition.
+	res[0].Val = api.Add(res[0].Val, 0x80, api.Mul(isInfinity, 0x40), api.Mul(isGreater, 0x20))
+	return res
+}
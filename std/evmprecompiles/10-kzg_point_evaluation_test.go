@@ -0,0 +1,195 @@
+package evmprecompiles
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fp_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type kzgPointEvalCircuit struct {
+	VersionedHash   [32]uints.U8
+	EvaluationPoint sw_bls12381.Scalar
+	ClaimedValue    sw_bls12381.Scalar
+	Commitment      sw_bls12381.G1Affine
+	Proof           sw_bls12381.G1Affine
+
+	setupG2 bls12381.G2Affine
+}
+
+func (c *kzgPointEvalCircuit) Define(api frontend.API) error {
+	return KzgPointEvaluation(api, c.VersionedHash, &c.EvaluationPoint, &c.ClaimedValue, &c.Commitment, &c.Proof, c.setupG2)
+}
+
+func TestKzgPointEvaluation(t *testing.T) {
+	assert := test.NewAssert(t)
+	alpha, err := rand.Int(rand.Reader, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+	srs, err := kzg_bls12381.NewSRS(32, alpha)
+	assert.NoError(err)
+	f := make([]fr_bls12381.Element, 20)
+	for i := range f {
+		f[i].SetRandom()
+	}
+	com, err := kzg_bls12381.Commit(f, srs.Pk)
+	assert.NoError(err)
+	var point fr_bls12381.Element
+	point.SetRandom()
+	proof, err := kzg_bls12381.Open(f, point, srs.Pk)
+	assert.NoError(err)
+	circuit := &kzgPointEvalCircuit{setupG2: srs.Vk.G2[1]}
+	// the negated commitment has the opposite lexicographic flag. Check both.
+	for _, neg := range []bool{false, true} {
+		if neg {
+			com.Neg(&com)
+			proof.H.Neg(&proof.H)
+			proof.ClaimedValue.Neg(&proof.ClaimedValue)
+		}
+		assignment := kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, proof.H)
+		err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+
+	// wrong version byte
+	assignment := kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, proof.H)
+	assignment.VersionedHash[0] = uints.NewU8(0x00)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// versioned hash of a different commitment
+	var other bls12381.G1Affine
+	other.Add(&com, &srs.Pk.G1[0])
+	assignment = kzgPointEvalAssignment(other, com, point, proof.ClaimedValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// wrong claimed value
+	var wrongValue fr_bls12381.Element
+	wrongValue.SetOne()
+	wrongValue.Add(&wrongValue, &proof.ClaimedValue)
+	assignment = kzgPointEvalAssignment(com, com, point, wrongValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// wrong proof
+	var wrongProof bls12381.G1Affine
+	wrongProof.Add(&proof.H, &srs.Pk.G1[0])
+	assignment = kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, wrongProof)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// commitment to a different polynomial with matching versioned hash
+	g := make([]fr_bls12381.Element, len(f))
+	for i := range g {
+		g[i].SetRandom()
+	}
+	otherCom, err := kzg_bls12381.Commit(g, srs.Pk)
+	assert.NoError(err)
+	assignment = kzgPointEvalAssignment(otherCom, otherCom, point, proof.ClaimedValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// proof not in the subgroup
+	notInG1 := randomPointNotInG1BLS()
+	assignment = kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, notInG1)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestKzgPointEvaluationInfinity(t *testing.T) {
+	assert := test.NewAssert(t)
+	alpha, err := rand.Int(rand.Reader, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+	srs, err := kzg_bls12381.NewSRS(32, alpha)
+	assert.NoError(err)
+	circuit := &kzgPointEvalCircuit{setupG2: srs.Vk.G2[1]}
+	var point fr_bls12381.Element
+	point.SetRandom()
+
+	// the zero polynomial, the commitment and the proof are at infinity.
+	f := make([]fr_bls12381.Element, 20)
+	com, err := kzg_bls12381.Commit(f, srs.Pk)
+	assert.NoError(err)
+	proof, err := kzg_bls12381.Open(f, point, srs.Pk)
+	assert.NoError(err)
+	assert.True(com.IsInfinity())
+	assert.True(proof.H.IsInfinity())
+	comBts := com.Bytes()
+	assert.Equal(byte(0xc0), comBts[0])
+	assignment := kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong claimed value for the zero polynomial
+	var wrongValue fr_bls12381.Element
+	wrongValue.SetOne()
+	assignment = kzgPointEvalAssignment(com, com, point, wrongValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// a constant polynomial, only the proof is at infinity.
+	f[0].SetRandom()
+	com, err = kzg_bls12381.Commit(f, srs.Pk)
+	assert.NoError(err)
+	proof, err = kzg_bls12381.Open(f, point, srs.Pk)
+	assert.NoError(err)
+	assert.True(proof.H.IsInfinity())
+	assignment = kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// proof at infinity for a non-constant polynomial
+	f[1].SetRandom()
+	com, err = kzg_bls12381.Commit(f, srs.Pk)
+	assert.NoError(err)
+	proof, err = kzg_bls12381.Open(f, point, srs.Pk)
+	assert.NoError(err)
+	var inf bls12381.G1Affine
+	assignment = kzgPointEvalAssignment(com, com, point, proof.ClaimedValue, inf)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// commitment at infinity with a valid-looking proof of another polynomial
+	assignment = kzgPointEvalAssignment(inf, inf, point, proof.ClaimedValue, proof.H)
+	err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// kzgPointEvalAssignment returns the witness for the point evaluation circuit,
+// where the versioned hash is computed from hashed.
+func kzgPointEvalAssignment(hashed, com bls12381.G1Affine, point, value fr_bls12381.Element, proof bls12381.G1Affine) *kzgPointEvalCircuit {
+	comBts := hashed.Bytes()
+	versionedHash := sha256.Sum256(comBts[:])
+	versionedHash[0] = 0x01
+	assignment := &kzgPointEvalCircuit{
+		EvaluationPoint: sw_bls12381.NewScalar(point),
+		ClaimedValue:    sw_bls12381.NewScalar(value),
+		Commitment:      sw_bls12381.NewG1Affine(com),
+		Proof:           sw_bls12381.NewG1Affine(proof),
+	}
+	copy(assignment.VersionedHash[:], uints.NewU8Array(versionedHash[:]))
+	return assignment
+}
+
+// randomPointNotInG1BLS returns a point on the BLS12-381 curve which is not in
+// the prime order subgroup.
+func randomPointNotInG1BLS() bls12381.G1Affine {
+	var P bls12381.G1Affine
+	for {
+		var rhs fp_bls12381.Element
+		P.X.SetRandom()
+		rhs.Square(&P.X).Mul(&rhs, &P.X).Add(&rhs, new(fp_bls12381.Element).SetUint64(4))
+		if P.Y.Sqrt(&rhs) != nil && !P.IsInSubGroup() {
+			return P
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package evmprecompiles
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMSMG1BLS implements [BLS12_G1MSM] precompile contract at address 0x0c.
+//
+// It returns ∑ᵢ [sᵢ]Pᵢ. The point at infinity is encoded as (0,0). As per the
+// EIP, the inputs are checked to be in the subgroup. The scalars are given as
+// elements of the scalar field, i.e. reduced modulo r (done in the zkEVM ⚠️ ).
+// As the points are in the prime order subgroup, this does not change the
+// result.
+//
+// [BLS12_G1MSM]: https://eips.ethereum.org/EIPS/eip-2537
+func ECMSMG1BLS(api frontend.API, P []*sw_bls12381.G1Affine, s []*sw_bls12381.Scalar) *sw_bls12381.G1Affine {
+	if len(P) != len(s) {
+		panic("P and s length mismatch")
+	}
+	if len(P) == 0 {
+		panic("invalid MSM size bound")
+	}
+	curve, err := sw_emulated.New[emulated.BLS12381Fp, emulated.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(err)
+	}
+	pair, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(err)
+	}
+	res := curve.ScalarMulGeneric(P[0], s[0])
+	for i := range P {
+		// Check that Pᵢ are in G1
+		assertIsOnG1BLS(api, curve, pair, P[i])
+		if i > 0 {
+			res = curve.AddUnified(res, curve.ScalarMulGeneric(P[i], s[i]))
+		}
+	}
+	return res
+}
+
+// assertIsOnG1BLS asserts that P is in G1 or is the point at infinity (0,0).
+func assertIsOnG1BLS(api frontend.API, curve *sw_emulated.Curve[emulated.BLS12381Fp, emulated.BLS12381Fr], pair *sw_bls12381.Pairing, P *sw_bls12381.G1Affine) {
+	fp, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		panic(err)
+	}
+	// the subgroup check is incomplete for (0,0), so we check the generator
+	// instead.
+	isInfinity := api.And(fp.IsZero(&P.X), fp.IsZero(&P.Y))
+	_, _, g1, _ := bls12381.Generators()
+	gen := sw_bls12381.NewG1Affine(g1)
+	pair.AssertIsOnG1(curve.Select(isInfinity, &gen, P))
+}
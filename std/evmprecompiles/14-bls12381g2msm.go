@@ -0,0 +1,57 @@
+package evmprecompiles
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+)
+
+// ECMSMG2BLS implements [BLS12_G2MSM] precompile contract at address 0x0e.
+//
+// It returns ∑ᵢ [sᵢ]Qᵢ. The point at infinity is encoded as (0,0). As per the
+// EIP, the inputs are checked to be in the subgroup. The scalars are given as
+// elements of the scalar field, i.e. reduced modulo r (done in the zkEVM ⚠️ ).
+// As the points are in the prime order subgroup, this does not change the
+// result.
+//
+// [BLS12_G2MSM]: https://eips.ethereum.org/EIPS/eip-2537
+func ECMSMG2BLS(api frontend.API, Q []*sw_bls12381.G2Affine, s []*sw_bls12381.Scalar) *sw_bls12381.G2Affine {
+	if len(Q) != len(s) {
+		panic("Q and s length mismatch")
+	}
+	if len(Q) == 0 {
+		panic("invalid MSM size bound")
+	}
+	g2 := sw_bls12381.NewG2(api)
+	pair, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(err)
+	}
+	res := g2.ScalarMul(Q[0], s[0])
+	for i := range Q {
+		// Check that Qᵢ are in G2
+		assertIsOnG2BLS(api, g2, pair, Q[i])
+		if i > 0 {
+			res = g2.AddUnified(res, g2.ScalarMul(Q[i], s[i]))
+		}
+	}
+	return res
+}
+
+// isInfinityG2BLS returns 1 if Q is the point at infinity (0,0) and 0
+// otherwise.
+func isInfinityG2BLS(api frontend.API, Q *sw_bls12381.G2Affine) frontend.Variable {
+	e2 := fields_bls12381.NewExt2(api)
+	return api.And(e2.IsZero(&Q.P.X), e2.IsZero(&Q.P.Y))
+}
+
+// assertIsOnG2BLS asserts that Q is in G2 or is the point at infinity (0,0).
+func assertIsOnG2BLS(api frontend.API, g2 *sw_bls12381.G2, pair *sw_bls12381.Pairing, Q *sw_bls12381.G2Affine) {
+	// the subgroup check is incomplete for (0,0), so we check the generator
+	// instead.
+	isInfinity := isInfinityG2BLS(api, Q)
+	_, _, _, g2Gen := bls12381.Generators()
+	gen := sw_bls12381.NewG2Affine(g2Gen)
+	pair.AssertIsOnG2(g2.Select(isInfinity, &gen, Q))
+}
@@ -0,0 +1,90 @@
+package evmprecompiles
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECPairBLS implements [BLS12_PAIRING_CHECK] precompile contract at address 0x0f.
+//
+// It asserts that ∏ᵢ e(Pᵢ, Qᵢ) == 1. The point at infinity is encoded as (0,0)
+// and the corresponding pairs do not contribute to the product. As per the
+// EIP, the inputs are checked to be in the subgroups.
+//
+// We compute a single multi-Miller loop over all the pairs followed with a
+// single final exponentiation. To handle the points at infinity, we replace
+// the corresponding pairs with the generators (g₁, g₂) and compare the result
+// with e(g₁, g₂)ᵏ, where k is the number of pairs with a point at infinity.
+//
+// The precompile outputs 1 if the check holds and 0 otherwise. As [ECPair],
+// ECPairBLS asserts the check instead, so the circuit is not satisfiable for
+// the inputs for which the precompile outputs 0.
+//
+// [BLS12_PAIRING_CHECK]: https://eips.ethereum.org/EIPS/eip-2537
+func ECPairBLS(api frontend.API, P []*sw_bls12381.G1Affine, Q []*sw_bls12381.G2Affine) {
+	if len(P) != len(Q) {
+		panic("P and Q length mismatch")
+	}
+	if len(P) == 0 {
+		panic("invalid multipairing size bound")
+	}
+	n := len(P)
+	curve, err := sw_emulated.New[emulated.BLS12381Fp, emulated.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(err)
+	}
+	fp, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		panic(err)
+	}
+	g2 := sw_bls12381.NewG2(api)
+	pair, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(err)
+	}
+	_, _, g1Gen, g2Gen := bls12381.Generators()
+	genP := sw_bls12381.NewG1Affine(g1Gen)
+	genQ := sw_bls12381.NewG2Affine(g2Gen)
+
+	ps := make([]*sw_bls12381.G1Affine, n)
+	qs := make([]*sw_bls12381.G2Affine, n)
+	var nbInfinity frontend.Variable = 0
+	for i := 0; i < n; i++ {
+		// 1- Check that Pᵢ are in G1 and Qᵢ are in G2
+		assertIsOnG1BLS(api, curve, pair, P[i])
+		assertIsOnG2BLS(api, g2, pair, Q[i])
+
+		// 2- if either Pᵢ or Qᵢ is at infinity, then e(Pᵢ, Qᵢ) = 1. We use
+		// the generators instead and account for e(g₁, g₂) in the result.
+		isInfinityP := api.And(fp.IsZero(&P[i].X), fp.IsZero(&P[i].Y))
+		isInfinityQ := isInfinityG2BLS(api, Q[i])
+		isInfinity := api.Or(isInfinityP, isInfinityQ)
+		ps[i] = curve.Select(isInfinity, &genP, P[i])
+		qs[i] = g2.Select(isInfinity, &genQ, Q[i])
+		nbInfinity = api.Add(nbInfinity, isInfinity)
+	}
+
+	// 3- Check that ∏ᵢ e(Pᵢ, Qᵢ) == 1, i.e. that the product of the
+	// substituted pairs is e(g₁, g₂)ᵏ
+	ml, err := pair.MillerLoop(ps, qs)
+	if err != nil {
+		panic(err)
+	}
+	res := pair.FinalExponentiation(ml)
+	eGen, err := bls12381.Pair([]bls12381.G1Affine{g1Gen}, []bls12381.G2Affine{g2Gen})
+	if err != nil {
+		panic(err)
+	}
+	var acc bls12381.GT
+	acc.SetOne()
+	expected := pair.One()
+	for k := 1; k <= n; k++ {
+		acc.Mul(&acc, &eGen)
+		eK := sw_bls12381.NewGTEl(acc)
+		expected = pair.Select(api.IsZero(api.Sub(nbInfinity, k)), &eK, expected)
+	}
+	pair.AssertIsEqual(res, expected)
+}
@@ -0,0 +1,30 @@
+package evmprecompiles
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMapToG1BLS implements [BLS12_MAP_FP_TO_G1] precompile contract at address 0x10.
+//
+// It maps the field element u to G1 using the simplified SWU map, followed by
+// the isogeny and cofactor clearing as in the [hash-to-curve] specification.
+// As per the EIP, u is checked to be strictly less than the modulus.
+//
+// [BLS12_MAP_FP_TO_G1]: https://eips.ethereum.org/EIPS/eip-2537
+// [hash-to-curve]: https://www.rfc-editor.org/rfc/rfc9380.html
+func ECMapToG1BLS(api frontend.API, u *emulated.Element[emulated.BLS12381Fp]) *sw_bls12381.G1Affine {
+	fp, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		panic(err)
+	}
+	g1, err := sw_bls12381.NewG1(api)
+	if err != nil {
+		panic(err)
+	}
+	// Check that u is canonical
+	fp.AssertIsInRange(u)
+	res := g1.MapToG1(u)
+	return res
+}
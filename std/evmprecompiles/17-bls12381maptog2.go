@@ -0,0 +1,30 @@
+package evmprecompiles
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMapToG2BLS implements [BLS12_MAP_FP2_TO_G2] precompile contract at address 0x11.
+//
+// It maps the field element u to G2 using the simplified SWU map, followed by
+// the isogeny and cofactor clearing as in the [hash-to-curve] specification.
+// As per the EIP, both coordinates of u are checked to be strictly less than
+// the modulus.
+//
+// [BLS12_MAP_FP2_TO_G2]: https://eips.ethereum.org/EIPS/eip-2537
+// [hash-to-curve]: https://www.rfc-editor.org/rfc/rfc9380.html
+func ECMapToG2BLS(api frontend.API, u *fields_bls12381.E2) *sw_bls12381.G2Affine {
+	fp, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		panic(err)
+	}
+	g2 := sw_bls12381.NewG2(api)
+	// Check that u is canonical
+	fp.AssertIsInRange(&u.A0)
+	fp.AssertIsInRange(&u.A1)
+	res := g2.MapToG2(u)
+	return res
+}
@@ -0,0 +1,148 @@
+package evmprecompiles
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// P256Verify implements [P256VERIFY] precompile contract at address 0x100.
+//
+// The input is the 160-byte precompile input: the message hash, the signature
+// components r and s and the public key coordinates x and y, each as a 32-byte
+// big-endian integer. It returns 1 if the signature is valid and 0 otherwise.
+// In particular, it returns 0 when r or s is not in [1, n-1], when x or y is
+// not in [0, p-1] or when the public key is not on the curve.
+//
+// Internally, invalid inputs are replaced with dummy valid values so that the
+// circuit is satisfiable for any input.
+//
+// [P256VERIFY]: https://github.com/ethereum/RIPs/blob/master/RIPS/rip-7212.md
+func P256Verify(api frontend.API, input [160]uints.U8) frontend.Variable {
+	var emfp emulated.P256Fp
+	var emfr emulated.P256Fr
+	fpField, err := emulated.NewField[emulated.P256Fp](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	frField, err := emulated.NewField[emulated.P256Fr](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	params := sw_emulated.GetP256Params()
+	curve, err := sw_emulated.New[emulated.P256Fp, emulated.P256Fr](api, params)
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+
+	hBits := bytesToBitsLE(api, input[0:32])
+	rBits := bytesToBitsLE(api, input[32:64])
+	sBits := bytesToBitsLE(api, input[64:96])
+	xBits := bytesToBitsLE(api, input[96:128])
+	yBits := bytesToBitsLE(api, input[128:160])
+
+	// 1- check the ranges: 0 < r < n, 0 < s < n, x < p, y < p
+	rIsValid := api.And(
+		api.Sub(1, isZeroBits(api, rBits)),
+		isLessThanConst(api, rBits, emfr.Modulus()),
+	)
+	sIsValid := api.And(
+		api.Sub(1, isZeroBits(api, sBits)),
+		isLessThanConst(api, sBits, emfr.Modulus()),
+	)
+	xIsValid := isLessThanConst(api, xBits, emfp.Modulus())
+	yIsValid := isLessThanConst(api, yBits, emfp.Modulus())
+
+	h := frField.FromBits(hBits...)
+	r := frField.FromBits(rBits...)
+	s := frField.FromBits(sBits...)
+	Q := &sw_emulated.AffinePoint[emulated.P256Fp]{
+		X: *fpField.FromBits(xBits...),
+		Y: *fpField.FromBits(yBits...),
+	}
+
+	// 2- check that the public key is on the curve: y² == x³ + ax + b. This
+	// also excludes (0,0) as b ≠ 0.
+	lhs := fpField.Mul(&Q.Y, &Q.Y)
+	rhs := fpField.Mul(&Q.X, &Q.X)
+	rhs = fpField.Add(rhs, fpField.NewElement(params.A))
+	rhs = fpField.Mul(rhs, &Q.X)
+	rhs = fpField.Add(rhs, fpField.NewElement(params.B))
+	qIsOnCurve := fpField.IsZero(fpField.Sub(lhs, rhs))
+
+	isValid := api.And(api.And(rIsValid, sIsValid), api.And(api.And(xIsValid, yIsValid), qIsOnCurve))
+
+	// 3- replace invalid inputs with dummy values so that the computations
+	// below are always satisfiable. The verdict is 0 in that case.
+	r = frField.Select(isValid, r, frField.One())
+	s = frField.Select(isValid, s, frField.One())
+	Q = curve.Select(isValid, Q, curve.Generator())
+
+	// 4- compute R = [h/s]G + [r/s]Q
+	sInv := frField.Inverse(s)
+	u1 := frField.Mul(h, sInv)
+	u2 := frField.Mul(r, sInv)
+	// we use ScalarMulBase and AddUnified as u1 can be zero and the result
+	// can be the point at infinity.
+	R := curve.AddUnified(curve.ScalarMulBase(u1), curve.ScalarMulGeneric(Q, u2))
+	rIsNotInfinity := api.Sub(1, api.And(fpField.IsZero(&R.X), fpField.IsZero(&R.Y)))
+
+	// 5- check that R.x mod n == r. As p < 2n, then either R.x == r or R.x ==
+	// r + n. The latter is possible only when r + n < p.
+	rFp := fpField.FromBits(rBits...)
+	isEqual := fpField.IsZero(fpField.Sub(&R.X, rFp))
+	pMinusN := new(big.Int).Sub(emfp.Modulus(), emfr.Modulus())
+	isEqualShifted := api.And(
+		isLessThanConst(api, rBits, pMinusN),
+		fpField.IsZero(fpField.Sub(&R.X, fpField.Add(rFp, fpField.NewElement(emfr.Modulus())))),
+	)
+	isEqual = api.Or(isEqual, isEqualShifted)
+
+	return api.And(isValid, api.And(rIsNotInfinity, isEqual))
+}
+
+// bytesToBitsLE returns the little-endian bit decomposition of the big-endian
+// byte slice in.
+func bytesToBitsLE(api frontend.API, in []uints.U8) []frontend.Variable {
+	res := make([]frontend.Variable, 0, 8*len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		res = append(res, bits.ToBinary(api, in[i].Val, bits.WithNbDigits(8))...)
+	}
+	return res
+}
+
+// isZeroBits returns 1 if all bits in bs are zero and 0 otherwise.
+func isZeroBits(api frontend.API, bs []frontend.Variable) frontend.Variable {
+	var sum frontend.Variable = 0
+	for i := range bs {
+		sum = api.Add(sum, bs[i])
+	}
+	return api.IsZero(sum)
+}
+
+// isLessThanConst returns 1 if the integer defined by the little-endian bits bs
+// is strictly less than the constant c and 0 otherwise. The constant must fit
+// into len(bs) bits.
+func isLessThanConst(api frontend.API, bs []frontend.Variable, c *big.Int) frontend.Variable {
+	if c.BitLen() > len(bs) {
+		panic("constant does not fit into the bit length")
+	}
+	// we iterate from the most significant bit and keep track if the prefix
+	// is equal to the prefix of c.
+	var isLess, isEqual frontend.Variable = 0, 1
+	for i := len(bs) - 1; i >= 0; i-- {
+		if c.Bit(i) == 1 {
+			// bs[i] == 0 and prefix equal implies less
+			isLess = api.Add(isLess, api.Mul(isEqual, api.Sub(1, bs[i])))
+			isEqual = api.Mul(isEqual, bs[i])
+		} else {
+			isEqual = api.Mul(isEqual, api.Sub(1, bs[i]))
+		}
+	}
+	return isLess
+}
@@ -0,0 +1,77 @@
+package evmprecompiles
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type p256verifyCircuit struct {
+	Input    [160]uints.U8
+	Expected frontend.Variable
+}
+
+func (c *p256verifyCircuit) Define(api frontend.API) error {
+	res := P256Verify(api, c.Input)
+	api.AssertIsEqual(res, c.Expected)
+	return nil
+}
+
+func p256verifyInput(h []byte, r, s, x, y *big.Int) [160]uints.U8 {
+	var buf [160]byte
+	copy(buf[0:32], h)
+	r.FillBytes(buf[32:64])
+	s.FillBytes(buf[64:96])
+	x.FillBytes(buf[96:128])
+	y.FillBytes(buf[128:160])
+	var res [160]uints.U8
+	for i := range buf {
+		res[i] = uints.NewU8(buf[i])
+	}
+	return res
+}
+
+func TestP256VerifyCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+	h := sha256.Sum256([]byte("test"))
+	r, s, err := ecdsa.Sign(rand.Reader, sk, h[:])
+	assert.NoError(err)
+	n := elliptic.P256().Params().N
+	p := elliptic.P256().Params().P
+	x, y := sk.PublicKey.X, sk.PublicKey.Y
+	wrongH := sha256.Sum256([]byte("wrong"))
+
+	testCases := []struct {
+		name     string
+		input    [160]uints.U8
+		expected int
+	}{
+		{"valid", p256verifyInput(h[:], r, s, x, y), 1},
+		{"wrong message", p256verifyInput(wrongH[:], r, s, x, y), 0},
+		{"zero r", p256verifyInput(h[:], big.NewInt(0), s, x, y), 0},
+		{"zero s", p256verifyInput(h[:], r, big.NewInt(0), x, y), 0},
+		{"r equals n", p256verifyInput(h[:], n, s, x, y), 0},
+		{"s equals n", p256verifyInput(h[:], r, n, x, y), 0},
+		{"x equals p", p256verifyInput(h[:], r, s, p, y), 0},
+		{"not on curve", p256verifyInput(h[:], r, s, x, new(big.Int).Add(y, big.NewInt(1))), 0},
+		{"infinity", p256verifyInput(h[:], r, s, big.NewInt(0), big.NewInt(0)), 0},
+	}
+	for _, tc := range testCases {
+		assert.Run(func(assert *test.Assert) {
+			circuit := p256verifyCircuit{}
+			witness := p256verifyCircuit{Input: tc.input, Expected: tc.expected}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, tc.name)
+	}
+}
@@ -0,0 +1,391 @@
+package evmprecompiles
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+// The test vectors in testdata/eip2537 are the EIP-2537 test vectors, as
+// vendored in go-ethereum (core/vm/testdata/precompiles). The multi-scalar
+// multiplication vectors with more than 32 pairs have been removed to keep the
+// test data small. Running all the vectors takes several hours, so in short
+// mode we only run a subset of the vectors with at most two pairs.
+//
+// The precompiles take the decoded points and field elements as inputs. The
+// input length, the zero padding of the field elements and their canonical
+// encoding are checked when decoding the input, outside of the circuit. The
+// curve, twist and subgroup membership are checked in the circuit.
+
+type eip2537Vector struct {
+	Input         string
+	Expected      string
+	ExpectedError string
+	Name          string
+}
+
+// eip2537Runner decodes the input of a precompile and returns the circuit and
+// the assignment checking the precompile. If output is nil, then the output of
+// the precompile is not checked.
+type eip2537Runner func(input, output []byte) (circuit, assignment frontend.Circuit, err error)
+
+func TestEIP2537Vectors(t *testing.T) {
+	for _, tc := range []struct {
+		file string
+		run  eip2537Runner
+		// pairLen is the length of an input pair for the precompiles taking
+		// a variable number of pairs, 0 otherwise.
+		pairLen int
+	}{
+		{"blsG1Add", runG1AddEIP2537, 0},
+		{"blsG2Add", runG2AddEIP2537, 0},
+		{"blsG1Mul", runG1MSMEIP2537, 128 + 32},
+		{"blsG2Mul", runG2MSMEIP2537, 256 + 32},
+		{"blsG1MultiExp", runG1MSMEIP2537, 128 + 32},
+		{"blsG2MultiExp", runG2MSMEIP2537, 256 + 32},
+		{"blsPairing", runPairingEIP2537, 128 + 256},
+		{"blsMapG1", runMapToG1EIP2537, 0},
+		{"blsMapG2", runMapToG2EIP2537, 0},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			assert := test.NewAssert(t)
+			vectors := loadEIP2537Vectors(t, tc.file)
+			if testing.Short() {
+				vectors = shortEIP2537Vectors(vectors, tc.pairLen)
+			}
+			for _, v := range vectors {
+				assert.Run(func(assert *test.Assert) {
+					input, err := hex.DecodeString(v.Input)
+					assert.NoError(err)
+					output, err := hex.DecodeString(v.Expected)
+					assert.NoError(err)
+					circuit, assignment, err := tc.run(input, output)
+					assert.NoError(err)
+					err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+					// the pairing check is asserted in-circuit, the circuit is
+					// not satisfiable when the precompile returns 0.
+					if tc.file == "blsPairing" && output[len(output)-1] == 0 {
+						assert.Error(err)
+					} else {
+						assert.NoError(err)
+					}
+				}, v.Name)
+			}
+			for _, v := range loadEIP2537Vectors(t, "fail-"+tc.file) {
+				assert.Run(func(assert *test.Assert) {
+					input, err := hex.DecodeString(v.Input)
+					assert.NoError(err)
+					circuit, assignment, err := tc.run(input, nil)
+					if err != nil {
+						// rejected when decoding the input, check that it is
+						// for the expected reason.
+						assert.ErrorContains(err, v.ExpectedError)
+						return
+					}
+					// the points are not on the curve or not in the subgroup,
+					// which is checked in the circuit.
+					assert.True(strings.Contains(v.ExpectedError, "curve") || strings.Contains(v.ExpectedError, "subgroup"),
+						"input decoded but expected error %q", v.ExpectedError)
+					err = test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+					assert.Error(err, v.ExpectedError)
+				}, v.Name)
+			}
+		})
+	}
+}
+
+const (
+	// eip2537ShortStride is the stride at which the vectors are run in short
+	// mode.
+	eip2537ShortStride = 8
+	// eip2537ShortMaxPairs is the maximum number of pairs of the vectors run
+	// in short mode.
+	eip2537ShortMaxPairs = 2
+)
+
+// shortEIP2537Vectors returns the subset of vectors to run in short mode. If
+// pairLen is non-zero, then the vectors with more than eip2537ShortMaxPairs
+// pairs are skipped.
+func shortEIP2537Vectors(vectors []eip2537Vector, pairLen int) []eip2537Vector {
+	var res, small []eip2537Vector
+	for _, v := range vectors {
+		// the input is hex-encoded
+		if pairLen == 0 || len(v.Input)/2 <= eip2537ShortMaxPairs*pairLen {
+			small = append(small, v)
+		}
+	}
+	for i := 0; i < len(small); i += eip2537ShortStride {
+		res = append(res, small[i])
+	}
+	return res
+}
+
+func loadEIP2537Vectors(t *testing.T, name string) []eip2537Vector {
+	b, err := os.ReadFile(filepath.Join("testdata", "eip2537", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []eip2537Vector
+	if err := json.Unmarshal(b, &res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+var errInvalidInputLength = errors.New("invalid input length")
+
+func decodeFpEIP2537(b []byte) (fp.Element, error) {
+	var res fp.Element
+	if len(b) != 64 {
+		return res, errInvalidInputLength
+	}
+	for i := 0; i < 64-fp.Bytes; i++ {
+		if b[i] != 0 {
+			return res, errors.New("invalid field element top bytes")
+		}
+	}
+	if err := res.SetBytesCanonical(b[64-fp.Bytes:]); err != nil {
+		return res, fmt.Errorf("invalid fp.Element encoding: %w", err)
+	}
+	return res, nil
+}
+
+func decodeFp2EIP2537(b []byte) (bls12381.E2, error) {
+	var res bls12381.E2
+	var err error
+	if len(b) != 128 {
+		return res, errInvalidInputLength
+	}
+	if res.A0, err = decodeFpEIP2537(b[:64]); err != nil {
+		return res, err
+	}
+	if res.A1, err = decodeFpEIP2537(b[64:]); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// decodeG1EIP2537 decodes a G1 point. It does not check that the point is on
+// the curve.
+func decodeG1EIP2537(b []byte) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	var err error
+	if len(b) != 128 {
+		return res, errInvalidInputLength
+	}
+	if res.X, err = decodeFpEIP2537(b[:64]); err != nil {
+		return res, err
+	}
+	if res.Y, err = decodeFpEIP2537(b[64:]); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// decodeG2EIP2537 decodes a G2 point. It does not check that the point is on
+// the twist.
+func decodeG2EIP2537(b []byte) (bls12381.G2Affine, error) {
+	var res bls12381.G2Affine
+	var err error
+	if len(b) != 256 {
+		return res, errInvalidInputLength
+	}
+	if res.X, err = decodeFp2EIP2537(b[:128]); err != nil {
+		return res, err
+	}
+	if res.Y, err = decodeFp2EIP2537(b[128:]); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// decodeScalarEIP2537 decodes a 32-byte scalar. The scalar is reduced modulo
+// the order of the subgroup.
+func decodeScalarEIP2537(b []byte) sw_bls12381.Scalar {
+	var s fr.Element
+	s.SetBigInt(new(big.Int).SetBytes(b))
+	return sw_bls12381.NewScalar(s)
+}
+
+func runG1AddEIP2537(input, output []byte) (frontend.Circuit, frontend.Circuit, error) {
+	if len(input) != 256 {
+		return nil, nil, errInvalidInputLength
+	}
+	X0, err := decodeG1EIP2537(input[:128])
+	if err != nil {
+		return nil, nil, err
+	}
+	X1, err := decodeG1EIP2537(input[128:])
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment := &g1AddBLSCircuit{X0: sw_bls12381.NewG1Affine(X0), X1: sw_bls12381.NewG1Affine(X1)}
+	if output == nil {
+		assignment.Expected = sw_bls12381.NewG1Affine(bls12381.G1Affine{})
+		return &g1AddBLSCircuit{skipOutput: true}, assignment, nil
+	}
+	expected, err := decodeG1EIP2537(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.Expected = sw_bls12381.NewG1Affine(expected)
+	return &g1AddBLSCircuit{}, assignment, nil
+}
+
+func runG2AddEIP2537(input, output []byte) (frontend.Circuit, frontend.Circuit, error) {
+	if len(input) != 512 {
+		return nil, nil, errInvalidInputLength
+	}
+	X0, err := decodeG2EIP2537(input[:256])
+	if err != nil {
+		return nil, nil, err
+	}
+	X1, err := decodeG2EIP2537(input[256:])
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment := &g2AddBLSCircuit{X0: sw_bls12381.NewG2Affine(X0), X1: sw_bls12381.NewG2Affine(X1)}
+	if output == nil {
+		assignment.Expected = sw_bls12381.NewG2Affine(bls12381.G2Affine{})
+		return &g2AddBLSCircuit{skipOutput: true}, assignment, nil
+	}
+	expected, err := decodeG2EIP2537(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.Expected = sw_bls12381.NewG2Affine(expected)
+	return &g2AddBLSCircuit{}, assignment, nil
+}
+
+func runG1MSMEIP2537(input, output []byte) (frontend.Circuit, frontend.Circuit, error) {
+	const pairLen = 128 + 32
+	if len(input) == 0 || len(input)%pairLen != 0 {
+		return nil, nil, errInvalidInputLength
+	}
+	n := len(input) / pairLen
+	circuit, assignment := newG1MSMBLSCircuit(n), newG1MSMBLSCircuit(n)
+	for i := 0; i < n; i++ {
+		chunk := input[i*pairLen : (i+1)*pairLen]
+		P, err := decodeG1EIP2537(chunk[:128])
+		if err != nil {
+			return nil, nil, err
+		}
+		assignment.P[i] = sw_bls12381.NewG1Affine(P)
+		assignment.S[i] = decodeScalarEIP2537(chunk[128:])
+	}
+	if output == nil {
+		circuit.skipOutput = true
+		assignment.Expected = sw_bls12381.NewG1Affine(bls12381.G1Affine{})
+		return circuit, assignment, nil
+	}
+	expected, err := decodeG1EIP2537(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.Expected = sw_bls12381.NewG1Affine(expected)
+	return circuit, assignment, nil
+}
+
+func runG2MSMEIP2537(input, output []byte) (frontend.Circuit, frontend.Circuit, error) {
+	const pairLen = 256 + 32
+	if len(input) == 0 || len(input)%pairLen != 0 {
+		return nil, nil, errInvalidInputLength
+	}
+	n := len(input) / pairLen
+	circuit, assignment := newG2MSMBLSCircuit(n), newG2MSMBLSCircuit(n)
+	for i := 0; i < n; i++ {
+		chunk := input[i*pairLen : (i+1)*pairLen]
+		Q, err := decodeG2EIP2537(chunk[:256])
+		if err != nil {
+			return nil, nil, err
+		}
+		assignment.Q[i] = sw_bls12381.NewG2Affine(Q)
+		assignment.S[i] = decodeScalarEIP2537(chunk[256:])
+	}
+	if output == nil {
+		circuit.skipOutput = true
+		assignment.Expected = sw_bls12381.NewG2Affine(bls12381.G2Affine{})
+		return circuit, assignment, nil
+	}
+	expected, err := decodeG2EIP2537(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.Expected = sw_bls12381.NewG2Affine(expected)
+	return circuit, assignment, nil
+}
+
+func runPairingEIP2537(input, _ []byte) (frontend.Circuit, frontend.Circuit, error) {
+	const pairLen = 128 + 256
+	if len(input) == 0 || len(input)%pairLen != 0 {
+		return nil, nil, errInvalidInputLength
+	}
+	n := len(input) / pairLen
+	assignment := newPairBLSCircuit(n)
+	for i := 0; i < n; i++ {
+		chunk := input[i*pairLen : (i+1)*pairLen]
+		P, err := decodeG1EIP2537(chunk[:128])
+		if err != nil {
+			return nil, nil, err
+		}
+		Q, err := decodeG2EIP2537(chunk[128:])
+		if err != nil {
+			return nil, nil, err
+		}
+		assignment.P[i] = sw_bls12381.NewG1Affine(P)
+		assignment.Q[i] = sw_bls12381.NewG2Affine(Q)
+	}
+	return newPairBLSCircuit(n), assignment, nil
+}
+
+func runMapToG1EIP2537(input, output []byte) (frontend.Circuit, frontend.Circuit, error) {
+	u, err := decodeFpEIP2537(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment := &mapToG1BLSCircuit{U: emulated.ValueOf[emulated.BLS12381Fp](u)}
+	if output == nil {
+		assignment.Expected = sw_bls12381.NewG1Affine(bls12381.G1Affine{})
+		return &mapToG1BLSCircuit{skipOutput: true}, assignment, nil
+	}
+	expected, err := decodeG1EIP2537(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.Expected = sw_bls12381.NewG1Affine(expected)
+	return &mapToG1BLSCircuit{}, assignment, nil
+}
+
+func runMapToG2EIP2537(input, output []byte) (frontend.Circuit, frontend.Circuit, error) {
+	u, err := decodeFp2EIP2537(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment := &mapToG2BLSCircuit{U: fields_bls12381.FromE2(&u)}
+	if output == nil {
+		assignment.Expected = sw_bls12381.NewG2Affine(bls12381.G2Affine{})
+		return &mapToG2BLSCircuit{skipOutput: true}, assignment, nil
+	}
+	expected, err := decodeG2EIP2537(output)
+	if err != nil {
+		return nil, nil, err
+	}
+	assignment.Expected = sw_bls12381.NewG2Affine(expected)
+	return &mapToG2BLSCircuit{}, assignment, nil
+}
@@ -0,0 +1,416 @@
+package evmprecompiles
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+func randomBLSPoints() (bls12381.G1Affine, bls12381.G2Affine) {
+	_, _, g1, g2 := bls12381.Generators()
+	var s1, s2 fr.Element
+	s1.SetRandom()
+	s2.SetRandom()
+	var P bls12381.G1Affine
+	var Q bls12381.G2Affine
+	P.ScalarMultiplication(&g1, s1.BigInt(new(big.Int)))
+	Q.ScalarMultiplication(&g2, s2.BigInt(new(big.Int)))
+	return P, Q
+}
+
+// randomPointNotInG2BLS returns a point on the twist of the BLS12-381 curve
+// which is not in the prime order subgroup.
+func randomPointNotInG2BLS() bls12381.G2Affine {
+	var Q bls12381.G2Affine
+	var b bls12381.E2
+	b.A0.SetUint64(4)
+	b.A1.SetUint64(4)
+	for {
+		var rhs bls12381.E2
+		Q.X.SetRandom()
+		rhs.Square(&Q.X).Mul(&rhs, &Q.X).Add(&rhs, &b)
+		if rhs.Legendre() == 1 {
+			Q.Y.Sqrt(&rhs)
+			if !Q.IsInSubGroup() {
+				return Q
+			}
+		}
+	}
+}
+
+type g1AddBLSCircuit struct {
+	X0, X1   sw_bls12381.G1Affine
+	Expected sw_bls12381.G1Affine
+
+	skipOutput bool
+}
+
+func (c *g1AddBLSCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[emulated.BLS12381Fp, emulated.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECAddG1BLS(api, &c.X0, &c.X1)
+	if !c.skipOutput {
+		curve.AssertIsEqual(res, &c.Expected)
+	}
+	return nil
+}
+
+func TestECAddG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, _ := randomBLSPoints()
+	Q, _ := randomBLSPoints()
+	var inf bls12381.G1Affine
+	for i, tc := range [][2]bls12381.G1Affine{{P, Q}, {P, P}, {inf, Q}} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G1Affine
+			expected.Add(&tc[0], &tc[1])
+			witness := g1AddBLSCircuit{
+				X0:       sw_bls12381.NewG1Affine(tc[0]),
+				X1:       sw_bls12381.NewG1Affine(tc[1]),
+				Expected: sw_bls12381.NewG1Affine(expected),
+			}
+			err := test.IsSolved(&g1AddBLSCircuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+	assert.Run(func(assert *test.Assert) {
+		// the addition formula does not depend on the curve equation, so only
+		// the on-curve check fails.
+		offCurve := P
+		offCurve.Y.Double(&offCurve.Y)
+		var expected bls12381.G1Affine
+		expected.Add(&offCurve, &Q)
+		witness := g1AddBLSCircuit{
+			X0:       sw_bls12381.NewG1Affine(offCurve),
+			X1:       sw_bls12381.NewG1Affine(Q),
+			Expected: sw_bls12381.NewG1Affine(expected),
+		}
+		err := test.IsSolved(&g1AddBLSCircuit{}, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "off-curve")
+}
+
+type g1MSMBLSCircuit struct {
+	P        []sw_bls12381.G1Affine
+	S        []sw_bls12381.Scalar
+	Expected sw_bls12381.G1Affine
+
+	skipOutput bool
+}
+
+func newG1MSMBLSCircuit(n int) *g1MSMBLSCircuit {
+	return &g1MSMBLSCircuit{P: make([]sw_bls12381.G1Affine, n), S: make([]sw_bls12381.Scalar, n)}
+}
+
+func (c *g1MSMBLSCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[emulated.BLS12381Fp, emulated.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	P := make([]*sw_bls12381.G1Affine, len(c.P))
+	S := make([]*sw_bls12381.Scalar, len(c.S))
+	for i := range P {
+		P[i], S[i] = &c.P[i], &c.S[i]
+	}
+	res := ECMSMG1BLS(api, P, S)
+	if !c.skipOutput {
+		curve.AssertIsEqual(res, &c.Expected)
+	}
+	return nil
+}
+
+func TestECMSMG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P0, _ := randomBLSPoints()
+	P1, _ := randomBLSPoints()
+	var inf bls12381.G1Affine
+	var s0, s1 fr.Element
+	s0.SetRandom()
+	s1.SetRandom()
+	for i, tc := range [][2]bls12381.G1Affine{{P0, P1}, {P0, inf}} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G1Affine
+			_, err := expected.MultiExp(tc[:], []fr.Element{s0, s1}, ecc.MultiExpConfig{})
+			assert.NoError(err)
+			witness := g1MSMBLSCircuit{
+				P:        []sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(tc[0]), sw_bls12381.NewG1Affine(tc[1])},
+				S:        []sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1)},
+				Expected: sw_bls12381.NewG1Affine(expected),
+			}
+			err = test.IsSolved(newG1MSMBLSCircuit(2), &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+	assert.Run(func(assert *test.Assert) {
+		notInG1 := randomPointNotInG1BLS()
+		var expected bls12381.G1Affine
+		_, err := expected.MultiExp([]bls12381.G1Affine{P0, notInG1}, []fr.Element{s0, s1}, ecc.MultiExpConfig{})
+		assert.NoError(err)
+		witness := g1MSMBLSCircuit{
+			P:        []sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(P0), sw_bls12381.NewG1Affine(notInG1)},
+			S:        []sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1)},
+			Expected: sw_bls12381.NewG1Affine(expected),
+		}
+		err = test.IsSolved(newG1MSMBLSCircuit(2), &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "not-in-subgroup")
+}
+
+type g2AddBLSCircuit struct {
+	X0, X1   sw_bls12381.G2Affine
+	Expected sw_bls12381.G2Affine
+
+	skipOutput bool
+}
+
+func (c *g2AddBLSCircuit) Define(api frontend.API) error {
+	g2 := sw_bls12381.NewG2(api)
+	res := ECAddG2BLS(api, &c.X0, &c.X1)
+	if !c.skipOutput {
+		g2.AssertIsEqual(res, &c.Expected)
+	}
+	return nil
+}
+
+func TestECAddG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, P := randomBLSPoints()
+	_, Q := randomBLSPoints()
+	var inf bls12381.G2Affine
+	for i, tc := range [][2]bls12381.G2Affine{{P, Q}, {P, P}, {P, inf}} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G2Affine
+			expected.Add(&tc[0], &tc[1])
+			witness := g2AddBLSCircuit{
+				X0:       sw_bls12381.NewG2Affine(tc[0]),
+				X1:       sw_bls12381.NewG2Affine(tc[1]),
+				Expected: sw_bls12381.NewG2Affine(expected),
+			}
+			err := test.IsSolved(&g2AddBLSCircuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+	assert.Run(func(assert *test.Assert) {
+		// the addition formula does not depend on the curve equation, so only
+		// the on-twist check fails.
+		offTwist := P
+		offTwist.Y.Double(&offTwist.Y)
+		var expected bls12381.G2Affine
+		expected.Add(&offTwist, &Q)
+		witness := g2AddBLSCircuit{
+			X0:       sw_bls12381.NewG2Affine(offTwist),
+			X1:       sw_bls12381.NewG2Affine(Q),
+			Expected: sw_bls12381.NewG2Affine(expected),
+		}
+		err := test.IsSolved(&g2AddBLSCircuit{}, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "off-twist")
+}
+
+type g2MSMBLSCircuit struct {
+	Q        []sw_bls12381.G2Affine
+	S        []sw_bls12381.Scalar
+	Expected sw_bls12381.G2Affine
+
+	skipOutput bool
+}
+
+func newG2MSMBLSCircuit(n int) *g2MSMBLSCircuit {
+	return &g2MSMBLSCircuit{Q: make([]sw_bls12381.G2Affine, n), S: make([]sw_bls12381.Scalar, n)}
+}
+
+func (c *g2MSMBLSCircuit) Define(api frontend.API) error {
+	g2 := sw_bls12381.NewG2(api)
+	Q := make([]*sw_bls12381.G2Affine, len(c.Q))
+	S := make([]*sw_bls12381.Scalar, len(c.S))
+	for i := range Q {
+		Q[i], S[i] = &c.Q[i], &c.S[i]
+	}
+	res := ECMSMG2BLS(api, Q, S)
+	if !c.skipOutput {
+		g2.AssertIsEqual(res, &c.Expected)
+	}
+	return nil
+}
+
+func TestECMSMG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, Q0 := randomBLSPoints()
+	_, Q1 := randomBLSPoints()
+	var inf bls12381.G2Affine
+	var s0, s1 fr.Element
+	s0.SetRandom()
+	s1.SetRandom()
+	for i, tc := range [][2]bls12381.G2Affine{{Q0, Q1}, {inf, Q1}} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G2Affine
+			_, err := expected.MultiExp(tc[:], []fr.Element{s0, s1}, ecc.MultiExpConfig{})
+			assert.NoError(err)
+			witness := g2MSMBLSCircuit{
+				Q:        []sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(tc[0]), sw_bls12381.NewG2Affine(tc[1])},
+				S:        []sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1)},
+				Expected: sw_bls12381.NewG2Affine(expected),
+			}
+			err = test.IsSolved(newG2MSMBLSCircuit(2), &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+	assert.Run(func(assert *test.Assert) {
+		notInG2 := randomPointNotInG2BLS()
+		var expected bls12381.G2Affine
+		_, err := expected.MultiExp([]bls12381.G2Affine{Q0, notInG2}, []fr.Element{s0, s1}, ecc.MultiExpConfig{})
+		assert.NoError(err)
+		witness := g2MSMBLSCircuit{
+			Q:        []sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(Q0), sw_bls12381.NewG2Affine(notInG2)},
+			S:        []sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1)},
+			Expected: sw_bls12381.NewG2Affine(expected),
+		}
+		err = test.IsSolved(newG2MSMBLSCircuit(2), &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "not-in-subgroup")
+}
+
+type pairBLSCircuit struct {
+	P []sw_bls12381.G1Affine
+	Q []sw_bls12381.G2Affine
+}
+
+func newPairBLSCircuit(n int) *pairBLSCircuit {
+	return &pairBLSCircuit{P: make([]sw_bls12381.G1Affine, n), Q: make([]sw_bls12381.G2Affine, n)}
+}
+
+func (c *pairBLSCircuit) Define(api frontend.API) error {
+	P := make([]*sw_bls12381.G1Affine, len(c.P))
+	Q := make([]*sw_bls12381.G2Affine, len(c.Q))
+	for i := range P {
+		P[i], Q[i] = &c.P[i], &c.Q[i]
+	}
+	ECPairBLS(api, P, Q)
+	return nil
+}
+
+func TestECPairBLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, Q := randomBLSPoints()
+	P2, Q2 := randomBLSPoints()
+	var negP bls12381.G1Affine
+	negP.Neg(&P)
+	var inf bls12381.G2Affine
+	// e(P, Q) * e(-P, Q) * e(P2, 0) == 1
+	witness := pairBLSCircuit{
+		P: []sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(P), sw_bls12381.NewG1Affine(negP), sw_bls12381.NewG1Affine(P2)},
+		Q: []sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(Q), sw_bls12381.NewG2Affine(Q), sw_bls12381.NewG2Affine(inf)},
+	}
+	err := test.IsSolved(newPairBLSCircuit(3), &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// e(0, Q) * e(-P, 0) * e(P2, 0) == 1
+	var infP bls12381.G1Affine
+	witness.P[0] = sw_bls12381.NewG1Affine(infP)
+	witness.Q[1] = sw_bls12381.NewG2Affine(inf)
+	err = test.IsSolved(newPairBLSCircuit(3), &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// e(P, Q) * e(-P, Q) * e(P2, Q2) != 1
+	witness = pairBLSCircuit{
+		P: []sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(P), sw_bls12381.NewG1Affine(negP), sw_bls12381.NewG1Affine(P2)},
+		Q: []sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(Q), sw_bls12381.NewG2Affine(Q), sw_bls12381.NewG2Affine(Q2)},
+	}
+	err = test.IsSolved(newPairBLSCircuit(3), &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// e(P, Q) * e(-P, Q) * e(P', 0) with P' off the curve
+	offCurve := P2
+	offCurve.Y.Double(&offCurve.Y)
+	witness.P[2] = sw_bls12381.NewG1Affine(offCurve)
+	witness.Q[2] = sw_bls12381.NewG2Affine(inf)
+	err = test.IsSolved(newPairBLSCircuit(3), &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// e(P', Q) * e(-P', Q) * e(P2, 0) with P' not in G1
+	notInG1 := randomPointNotInG1BLS()
+	var negNotInG1 bls12381.G1Affine
+	negNotInG1.Neg(&notInG1)
+	witness = pairBLSCircuit{
+		P: []sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(notInG1), sw_bls12381.NewG1Affine(negNotInG1), sw_bls12381.NewG1Affine(P2)},
+		Q: []sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(Q), sw_bls12381.NewG2Affine(Q), sw_bls12381.NewG2Affine(inf)},
+	}
+	err = test.IsSolved(newPairBLSCircuit(3), &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// e(P, Q') * e(-P, Q') * e(P2, 0) with Q' not in G2
+	notInG2 := randomPointNotInG2BLS()
+	witness = pairBLSCircuit{
+		P: []sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(P), sw_bls12381.NewG1Affine(negP), sw_bls12381.NewG1Affine(P2)},
+		Q: []sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(notInG2), sw_bls12381.NewG2Affine(notInG2), sw_bls12381.NewG2Affine(inf)},
+	}
+	err = test.IsSolved(newPairBLSCircuit(3), &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type mapToG1BLSCircuit struct {
+	U        emulated.Element[emulated.BLS12381Fp]
+	Expected sw_bls12381.G1Affine
+
+	skipOutput bool
+}
+
+func (c *mapToG1BLSCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[emulated.BLS12381Fp, emulated.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECMapToG1BLS(api, &c.U)
+	if !c.skipOutput {
+		curve.AssertIsEqual(res, &c.Expected)
+	}
+	return nil
+}
+
+func TestECMapToG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u fp.Element
+	u.SetRandom()
+	witness := mapToG1BLSCircuit{
+		U:        emulated.ValueOf[emulated.BLS12381Fp](u),
+		Expected: sw_bls12381.NewG1Affine(bls12381.MapToG1(u)),
+	}
+	err := test.IsSolved(&mapToG1BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type mapToG2BLSCircuit struct {
+	U        fields_bls12381.E2
+	Expected sw_bls12381.G2Affine
+
+	skipOutput bool
+}
+
+func (c *mapToG2BLSCircuit) Define(api frontend.API) error {
+	g2 := sw_bls12381.NewG2(api)
+	res := ECMapToG2BLS(api, &c.U)
+	if !c.skipOutput {
+		g2.AssertIsEqual(res, &c.Expected)
+	}
+	return nil
+}
+
+func TestECMapToG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u bls12381.E2
+	u.SetRandom()
+	witness := mapToG2BLSCircuit{
+		U:        fields_bls12381.FromE2(&u),
+		Expected: sw_bls12381.NewG2Affine(bls12381.MapToG2(u)),
+	}
+	err := test.IsSolved(&mapToG2BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
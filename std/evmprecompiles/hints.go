@@ -16,7 +16,7 @@ func init() {
 
 // GetHints returns all the hints used in this package.
 func GetHints() []solver.Hint {
-	return []solver.Hint{recoverPointHint, recoverPublicKeyHint}
+	return []solver.Hint{recoverPointHint, recoverPublicKeyHint, recoverYHint}
 }
 
 func recoverPointHintArgs(v frontend.Variable, r emulated.Element[emulated.Secp256k1Fr]) []frontend.Variable {
@@ -51,6 +51,47 @@ func recoverPointHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 	return nil
 }
 
+func recoverYHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	// v -- 1 input
+	// r -- nb limbs
+	// return 1 + nb limbs: flag if r^3+7 is a quadratic residue and the square
+	// root of r^3+7 with oddity v or the square root of -(r^3+7) otherwise.
+	var emfp emulated.Secp256k1Fp
+	var emfr emulated.Secp256k1Fr
+	if len(inputs) != int(emfr.NbLimbs())+1 {
+		return fmt.Errorf("expected input %d limbs got %d", emfr.NbLimbs()+1, len(inputs))
+	}
+	if !inputs[0].IsInt64() {
+		return fmt.Errorf("first input supposed to be in [0,1]")
+	}
+	if len(outputs) != int(emfp.NbLimbs())+1 {
+		return fmt.Errorf("expected output %d limbs got %d", emfp.NbLimbs()+1, len(outputs))
+	}
+	p := emfp.Modulus()
+	v := inputs[0].Uint64()
+	r := recompose(inputs[1:], emfr.BitsPerLimb())
+	rhs := new(big.Int).Exp(r, big.NewInt(3), p)
+	rhs.Add(rhs, big.NewInt(7))
+	rhs.Mod(rhs, p)
+	y := new(big.Int)
+	if y.ModSqrt(rhs, p) != nil {
+		outputs[0].SetUint64(1)
+		if uint64(y.Bit(0)) != v {
+			y.Sub(p, y)
+		}
+	} else {
+		outputs[0].SetUint64(0)
+		rhs.Sub(p, rhs)
+		if y.ModSqrt(rhs, p) == nil {
+			return fmt.Errorf("no square root")
+		}
+	}
+	if err := decompose(y, emfp.BitsPerLimb(), outputs[1:]); err != nil {
+		return fmt.Errorf("decompose y: %w", err)
+	}
+	return nil
+}
+
 func recoverPublicKeyHintArgs(msg emulated.Element[emulated.Secp256k1Fr],
 	v frontend.Variable, r, s emulated.Element[emulated.Secp256k1Fr]) []frontend.Variable {
 	args := msg.Limbs
@@ -0,0 +1,44 @@
+// Package blake2b implements in-circuit BLAKE2b hash computation.
+//
+// The implementation follows [RFC 7693] and is compatible with
+// [golang.org/x/crypto/blake2b] for unkeyed hashing. The compression function
+// is implemented in [github.com/consensys/gnark/std/permutation/blake2].
+//
+// [RFC 7693]: https://www.rfc-editor.org/rfc/rfc7693
+package blake2b
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/internal/blake2hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2"
+)
+
+const (
+	// BlockSize is the block size of BLAKE2b in bytes.
+	BlockSize = 128
+	// number of rounds in the compression function
+	rounds = 12
+)
+
+// New256 returns a new BLAKE2b hasher computing 32-byte digest.
+func New256(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 32)
+}
+
+// New384 returns a new BLAKE2b hasher computing 48-byte digest.
+func New384(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 48)
+}
+
+// New512 returns a new BLAKE2b hasher computing 64-byte digest.
+func New512(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 64)
+}
+
+func newDigest(api frontend.API, size int) (hash.BinaryFixedLengthHasher, error) {
+	return blake2hash.New(api, blake2.IVb, size, func(uapi *uints.BinaryField[uints.U64], h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, f uints.U64) [8]uints.U64 {
+		return blake2.Blake2b(uapi, rounds, h, m, t, f)
+	})
+}
@@ -0,0 +1,129 @@
+package blake2b
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2b"
+)
+
+type blake2bCircuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *blake2bCircuit) Define(api frontend.API) error {
+	var h hash.BinaryHasher
+	var err error
+	switch len(c.Expected) {
+	case 32:
+		h, err = New256(api)
+	case 48:
+		h, err = New384(api)
+	case 64:
+		h, err = New512(api)
+	default:
+		return fmt.Errorf("unsupported digest size %d", len(c.Expected))
+	}
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != len(c.Expected) {
+		return fmt.Errorf("expected %d bytes, got %d", len(c.Expected), len(res))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlake2b(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, size := range []int{32, 48, 64} {
+		for _, l := range []int{0, 1, 128, 200} {
+			assert.Run(func(assert *test.Assert) {
+				in := make([]byte, l)
+				_, err := rand.Reader.Read(in)
+				assert.NoError(err)
+				h, err := blake2b.New(size, nil)
+				assert.NoError(err)
+				h.Write(in)
+				expected := h.Sum(nil)
+				circuit := &blake2bCircuit{
+					In:       make([]uints.U8, len(in)),
+					Expected: make([]uints.U8, len(expected)),
+				}
+				witness := &blake2bCircuit{
+					In:       uints.NewU8Array(in),
+					Expected: uints.NewU8Array(expected),
+				}
+				err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, fmt.Sprintf("size=%d/len=%d", size, l))
+		}
+	}
+}
+
+type blake2bFixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected [64]uints.U8
+}
+
+func (c *blake2bFixedLengthCircuit) Define(api frontend.API) error {
+	h, err := New512(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlake2bFixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := make([]byte, 256)
+	_, err := rand.Reader.Read(in)
+	assert.NoError(err)
+	for _, l := range []int{0, 1, 127, 128, 129, 256} {
+		assert.Run(func(assert *test.Assert) {
+			expected := blake2b.Sum512(in[:l])
+			circuit := &blake2bFixedLengthCircuit{
+				In: make([]uints.U8, len(in)),
+			}
+			witness := &blake2bFixedLengthCircuit{
+				In:     uints.NewU8Array(in),
+				Length: l,
+			}
+			copy(witness.Expected[:], uints.NewU8Array(expected[:]))
+			err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("length=%d", l))
+	}
+	// length larger than the input is not accepted
+	witness := &blake2bFixedLengthCircuit{
+		In:     uints.NewU8Array(in),
+		Length: len(in) + 1,
+	}
+	err = test.IsSolved(&blake2bFixedLengthCircuit{In: make([]uints.U8, len(in))}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,31 @@
+// Package blake2s implements in-circuit BLAKE2s hash computation.
+//
+// The implementation follows [RFC 7693] and is compatible with
+// [golang.org/x/crypto/blake2s] for unkeyed hashing. The compression function
+// is implemented in [github.com/consensys/gnark/std/permutation/blake2].
+//
+// [RFC 7693]: https://www.rfc-editor.org/rfc/rfc7693
+package blake2s
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/internal/blake2hash"
+	"github.com/consensys/gnark/std/permutation/blake2"
+)
+
+// BlockSize is the block size of BLAKE2s in bytes.
+const BlockSize = 64
+
+// New256 returns a new BLAKE2s hasher computing 32-byte digest.
+func New256(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 32)
+}
+
+func newDigest(api frontend.API, size int) (hash.BinaryFixedLengthHasher, error) {
+	var iv [8]uint64
+	for i := range iv {
+		iv[i] = uint64(blake2.IVs[i])
+	}
+	return blake2hash.New(api, iv, size, blake2.Blake2s)
+}
@@ -0,0 +1,98 @@
+package blake2s
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2s"
+)
+
+type blake2sCircuit struct {
+	In       []uints.U8
+	Expected [32]uints.U8
+}
+
+func (c *blake2sCircuit) Define(api frontend.API) error {
+	h, err := New256(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != 32 {
+		return fmt.Errorf("not 32 bytes")
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlake2s(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, l := range []int{0, 1, 64, 100} {
+		assert.Run(func(assert *test.Assert) {
+			in := make([]byte, l)
+			_, err := rand.Reader.Read(in)
+			assert.NoError(err)
+			expected := blake2s.Sum256(in)
+			witness := &blake2sCircuit{
+				In: uints.NewU8Array(in),
+			}
+			copy(witness.Expected[:], uints.NewU8Array(expected[:]))
+			err = test.IsSolved(&blake2sCircuit{In: make([]uints.U8, len(in))}, witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("len=%d", l))
+	}
+}
+
+type blake2sFixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected [32]uints.U8
+}
+
+func (c *blake2sFixedLengthCircuit) Define(api frontend.API) error {
+	h, err := New256(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlake2sFixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := make([]byte, 150)
+	_, err := rand.Reader.Read(in)
+	assert.NoError(err)
+	for _, l := range []int{0, 63, 64, 65, 150} {
+		assert.Run(func(assert *test.Assert) {
+			expected := blake2s.Sum256(in[:l])
+			witness := &blake2sFixedLengthCircuit{
+				In:     uints.NewU8Array(in),
+				Length: l,
+			}
+			copy(witness.Expected[:], uints.NewU8Array(expected[:]))
+			err := test.IsSolved(&blake2sFixedLengthCircuit{In: make([]uints.U8, len(in))}, witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("length=%d", l))
+	}
+}
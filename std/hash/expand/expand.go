@@ -0,0 +1,86 @@
+// Package expand implements the expand_message_xmd function of RFC 9380 for
+// in-circuit hashing to fields and curves.
+//
+// The function is instantiated with SHA2-256 and matches the native
+// implementation in gnark-crypto field/hash package. It is used as the first
+// step of the hash_to_field method which is in turn used for hashing to the
+// curves.
+//
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3.1
+package expand
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// bInBytes is the output size of SHA2-256.
+	bInBytes = 32
+	// rInBytes is the block size of SHA2-256.
+	rInBytes = 64
+)
+
+// ExpandMsgXmd expands msg into lenInBytes pseudo-random bytes using the
+// domain separation tag dst. The domain separation tag is fixed at circuit
+// compile time, but the message is a witness.
+func ExpandMsgXmd(api frontend.API, msg []uints.U8, dst []byte, lenInBytes int) ([]uints.U8, error) {
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes <= 0 || lenInBytes > 65535 {
+		return nil, errors.New("invalid lenInBytes")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("invalid domain size (>255 bytes)")
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, err
+	}
+	// DST_prime = DST ∥ I2OSP(len(DST), 1)
+	dstPrime := uints.NewU8Array(append(append([]byte{}, dst...), uint8(len(dst))))
+
+	// b₀ = H(Z_pad ∥ msg ∥ l_i_b_str ∥ I2OSP(0, 1) ∥ DST_prime)
+	h, err := sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(uints.NewU8Array(make([]byte, rInBytes)))
+	h.Write(msg)
+	h.Write(uints.NewU8Array([]byte{uint8(lenInBytes >> 8), uint8(lenInBytes), 0}))
+	h.Write(dstPrime)
+	b0 := h.Sum()
+
+	// b₁ = H(b₀ ∥ I2OSP(1, 1) ∥ DST_prime)
+	h, err = sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(b0)
+	h.Write([]uints.U8{uints.NewU8(1)})
+	h.Write(dstPrime)
+	bi := h.Sum()
+
+	res := make([]uints.U8, 0, ell*bInBytes)
+	res = append(res, bi...)
+	for i := 2; i <= ell; i++ {
+		// b_i = H(strxor(b₀, b_(i - 1)) ∥ I2OSP(i, 1) ∥ DST_prime)
+		strxor := make([]uints.U8, 0, bInBytes)
+		for j := 0; j < bInBytes; j += 4 {
+			x := uapi.Xor(uapi.PackMSB(b0[j:j+4]...), uapi.PackMSB(bi[j:j+4]...))
+			strxor = append(strxor, uapi.UnpackMSB(x)...)
+		}
+		h, err = sha2.New(api)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(strxor)
+		h.Write([]uints.U8{uints.NewU8(uint8(i))})
+		h.Write(dstPrime)
+		bi = h.Sum()
+		res = append(res, bi...)
+	}
+	return res[:lenInBytes], nil
+}
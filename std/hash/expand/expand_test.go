@@ -0,0 +1,62 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type expandMsgXmdCircuit struct {
+	Msg      []uints.U8
+	Expected []uints.U8
+	dst      []byte
+}
+
+func (c *expandMsgXmdCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	res, err := ExpandMsgXmd(api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestExpandMsgXmd(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for _, tc := range []struct {
+		msg        string
+		lenInBytes int
+	}{
+		{"", 32},
+		{"abc", 48},
+		{"abcdef0123456789", 128},
+	} {
+		tc := tc
+		assert.Run(func(assert *test.Assert) {
+			expected, err := hash.ExpandMsgXmd([]byte(tc.msg), dst, tc.lenInBytes)
+			assert.NoError(err)
+			circuit := expandMsgXmdCircuit{
+				Msg:      make([]uints.U8, len(tc.msg)),
+				Expected: make([]uints.U8, tc.lenInBytes),
+				dst:      dst,
+			}
+			witness := expandMsgXmdCircuit{
+				Msg:      uints.NewU8Array([]byte(tc.msg)),
+				Expected: uints.NewU8Array(expected),
+			}
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, tc.msg)
+	}
+}
@@ -0,0 +1,79 @@
+package poseidon2
+
+import (
+	"errors"
+	"fmt"
+	stdhash "hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+// NewHash returns the out-of-circuit Poseidon2 hash function over the scalar
+// field of the given curve. The hash function matches the in-circuit hasher
+// returned by [NewMerkleDamgardHasher] when compiled over the same field.
+//
+// The written bytes are interpreted as a sequence of big-endian encoded field
+// elements of [stdhash.Hash.BlockSize] bytes each. The length of every write
+// must be a multiple of the block size and the encoded elements must be
+// reduced.
+func NewHash(curve ecc.ID) (stdhash.Hash, error) {
+	params, err := poseidon2.GetDefaultParameters(curve)
+	if err != nil {
+		return nil, fmt.Errorf("default parameters: %w", err)
+	}
+	return &digest{
+		params:    params,
+		blockSize: (params.Modulus.BitLen() + 7) / 8,
+	}, nil
+}
+
+type digest struct {
+	params    *poseidon2.Parameters
+	blockSize int
+	data      []*big.Int
+}
+
+// Write appends the field elements encoded in p to the data to be hashed.
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p)%d.blockSize != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	for start := 0; start < len(p); start += d.blockSize {
+		e := new(big.Int).SetBytes(p[start : start+d.blockSize])
+		if e.Cmp(d.params.Modulus) >= 0 {
+			return 0, errors.New("input element not reduced")
+		}
+		d.data = append(d.data, e)
+	}
+	return len(p), nil
+}
+
+// Sum appends the digest of the written data to b. It does not change the
+// underlying state.
+func (d *digest) Sum(b []byte) []byte {
+	state := new(big.Int)
+	for _, e := range d.data {
+		st := []*big.Int{new(big.Int).Set(state), new(big.Int).Set(e)}
+		if err := d.params.Permute(st); err != nil {
+			panic(err)
+		}
+		state.Add(st[1], e)
+		state.Mod(state, d.params.Modulus)
+	}
+	res := make([]byte, d.blockSize)
+	state.FillBytes(res)
+	return append(b, res...)
+}
+
+// Reset resets the hash to its initial state.
+func (d *digest) Reset() {
+	d.data = nil
+}
+
+// Size returns the number of bytes Sum will append.
+func (d *digest) Size() int { return d.blockSize }
+
+// BlockSize returns the number of bytes of a single field element.
+func (d *digest) BlockSize() int { return d.blockSize }
@@ -0,0 +1,67 @@
+// Package poseidon2 implements Poseidon2 hash function in-circuit.
+//
+// The hash function is built using Merkle-Damgård construction over the
+// two-to-one compression function of the Poseidon2 permutation (see
+// [github.com/consensys/gnark/std/permutation/poseidon2]). The function is
+// registered under the name "poseidon2" in [hash.GetFieldHasher].
+//
+// The out-of-circuit counterpart of the hash function is returned by
+// [NewHash]. It implements the standard library [stdhash.Hash] interface and
+// can be used to compute Merkle trees and Fiat-Shamir transcripts which are
+// verified in-circuit.
+package poseidon2
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+func init() {
+	hash.Register("poseidon2", func(api frontend.API) (hash.FieldHasher, error) {
+		return NewMerkleDamgardHasher(api)
+	})
+}
+
+type merkleDamgardHasher struct {
+	api   frontend.API
+	perm  *poseidon2.Permutation
+	state frontend.Variable
+	data  []frontend.Variable
+}
+
+// NewMerkleDamgardHasher returns a Poseidon2 hasher with the default parameters
+// for the native field. The initial state is zero.
+func NewMerkleDamgardHasher(api frontend.API) (hash.FieldHasher, error) {
+	perm, err := poseidon2.NewPoseidon2(api)
+	if err != nil {
+		return nil, fmt.Errorf("new permutation: %w", err)
+	}
+	return &merkleDamgardHasher{
+		api:   api,
+		perm:  perm,
+		state: 0,
+	}, nil
+}
+
+// Write adds more data to the running hash.
+func (h *merkleDamgardHasher) Write(data ...frontend.Variable) {
+	h.data = append(h.data, data...)
+}
+
+// Reset empties the written data and resets the state to zero.
+func (h *merkleDamgardHasher) Reset() {
+	h.data = nil
+	h.state = 0
+}
+
+// Sum absorbs the written data into the state and returns the state.
+func (h *merkleDamgardHasher) Sum() frontend.Variable {
+	for _, d := range h.data {
+		h.state = h.perm.Compress(h.state, d)
+	}
+	h.data = nil
+	return h.state
+}
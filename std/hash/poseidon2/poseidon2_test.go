@@ -0,0 +1,81 @@
+package poseidon2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/test"
+)
+
+type poseidon2Circuit struct {
+	ExpectedResult frontend.Variable `gnark:"data,public"`
+	Data           [10]frontend.Variable
+}
+
+func (circuit *poseidon2Circuit) Define(api frontend.API) error {
+	h, err := hash.GetFieldHasher("poseidon2", api)
+	if err != nil {
+		return err
+	}
+	h.Write(circuit.Data[:]...)
+	result := h.Sum()
+	api.AssertIsEqual(result, circuit.ExpectedResult)
+	return nil
+}
+
+func TestPoseidon2All(t *testing.T) {
+	assert := test.NewAssert(t)
+	curves := []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BW6_633, ecc.BLS24_315, ecc.BLS24_317}
+
+	for _, curve := range curves {
+		var validWitness, invalidWitness poseidon2Circuit
+
+		modulus := curve.ScalarField()
+		var data [10]big.Int
+		data[0].Sub(modulus, big.NewInt(1))
+		for i := 1; i < 10; i++ {
+			data[i].Add(&data[i-1], &data[i-1]).Mod(&data[i], modulus)
+		}
+
+		h, err := NewHash(curve)
+		assert.NoError(err)
+		buf := make([]byte, h.BlockSize())
+		for i := 0; i < 10; i++ {
+			data[i].FillBytes(buf)
+			_, err := h.Write(buf)
+			assert.NoError(err)
+		}
+		expected := h.Sum(nil)
+
+		for i := 0; i < 10; i++ {
+			validWitness.Data[i] = data[i].String()
+			invalidWitness.Data[i] = data[i].String()
+		}
+		validWitness.ExpectedResult = expected
+		invalidWitness.ExpectedResult = expected
+		invalidWitness.Data[3] = 0
+
+		assert.CheckCircuit(&poseidon2Circuit{},
+			test.WithValidAssignment(&validWitness),
+			test.WithInvalidAssignment(&invalidWitness),
+			test.WithCurves(curve), test.NoFuzzing(), test.NoSerializationChecks())
+	}
+}
+
+func TestNativeHashInvalidInput(t *testing.T) {
+	assert := test.NewAssert(t)
+	h, err := NewHash(ecc.BN254)
+	assert.NoError(err)
+	// not a multiple of the block size
+	_, err = h.Write(make([]byte, h.BlockSize()+1))
+	assert.Error(err)
+	// shorter than the block size
+	_, err = h.Write([]byte{1})
+	assert.Error(err)
+	// not reduced
+	_, err = h.Write(ecc.BN254.ScalarField().Bytes())
+	assert.Error(err)
+}
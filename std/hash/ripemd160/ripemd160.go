@@ -0,0 +1,81 @@
+// Package ripemd160 implements in-circuit RIPEMD-160 hash computation.
+//
+// The implementation follows the specification in [RIPEMD-160] and is
+// compatible with [golang.org/x/crypto/ripemd160].
+//
+// [RIPEMD-160]: https://homes.esat.kuleuven.be/~bosselae/ripemd160.html
+package ripemd160
+
+import (
+	"encoding/binary"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+var _seed = uints.NewU32Array([]uint32{
+	0x67452301, 0xEFCDAB89, 0x98BADCFE, 0x10325476, 0xC3D2E1F0,
+})
+
+type digest struct {
+	uapi *uints.BinaryField[uints.U32]
+	in   []uints.U8
+}
+
+// New returns a new RIPEMD-160 hasher.
+func New(api frontend.API) (hash.BinaryHasher, error) {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest{uapi: uapi}, nil
+}
+
+// Write appends the bytes to the data to be hashed. The hash is computed only
+// when calling [digest.Sum].
+func (d *digest) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+func (d *digest) padded(bytesLen int) []uints.U8 {
+	zeroPadLen := 55 - bytesLen%64
+	if zeroPadLen < 0 {
+		zeroPadLen += 64
+	}
+	buf := make([]uints.U8, len(d.in), len(d.in)+9+zeroPadLen)
+	copy(buf, d.in)
+	buf = append(buf, uints.NewU8(0x80))
+	buf = append(buf, uints.NewU8Array(make([]uint8, zeroPadLen))...)
+	lenbuf := make([]uint8, 8)
+	binary.LittleEndian.PutUint64(lenbuf, uint64(8*bytesLen))
+	buf = append(buf, uints.NewU8Array(lenbuf)...)
+	return buf
+}
+
+// Sum pads the written data and returns its 20-byte RIPEMD-160 digest. It does
+// not change the written data, so that more data can be written and Sum called
+// again.
+func (d *digest) Sum() []uints.U8 {
+	var runningDigest [5]uints.U32
+	var buf [64]uints.U8
+	copy(runningDigest[:], _seed)
+	padded := d.padded(len(d.in))
+	for i := 0; i < len(padded)/64; i++ {
+		copy(buf[:], padded[i*64:(i+1)*64])
+		runningDigest = compress(d.uapi, runningDigest, buf)
+	}
+	var ret []uints.U8
+	for i := range runningDigest {
+		ret = append(ret, d.uapi.UnpackLSB(runningDigest[i])...)
+	}
+	return ret
+}
+
+// Reset discards the written data.
+func (d *digest) Reset() {
+	d.in = nil
+}
+
+// Size returns the length of the digest in bytes.
+func (d *digest) Size() int { return 20 }
@@ -0,0 +1,103 @@
+package ripemd160
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // used as reference implementation
+)
+
+type ripemd160Circuit struct {
+	In       []uints.U8
+	Expected [20]uints.U8
+}
+
+func (c *ripemd160Circuit) Define(api frontend.API) error {
+	h, err := New(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != 20 {
+		return fmt.Errorf("not 20 bytes")
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestRIPEMD160(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, l := range []int{0, 1, 55, 56, 64, 130} {
+		assert.Run(func(assert *test.Assert) {
+			bts := make([]byte, l)
+			for i := range bts {
+				bts[i] = byte(i)
+			}
+			h := ripemd160.New()
+			h.Write(bts)
+			dgst := h.Sum(nil)
+			witness := ripemd160Circuit{
+				In: uints.NewU8Array(bts),
+			}
+			copy(witness.Expected[:], uints.NewU8Array(dgst))
+			err := test.IsSolved(&ripemd160Circuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("len=%d", l))
+	}
+}
+
+type hash160Circuit struct {
+	In       []uints.U8
+	Expected [20]uints.U8
+}
+
+func (c *hash160Circuit) Define(api frontend.API) error {
+	h256, err := sha2.New(api)
+	if err != nil {
+		return err
+	}
+	h160, err := New(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h256.Write(c.In)
+	h160.Write(h256.Sum())
+	res := h160.Sum()
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestHash160(t *testing.T) {
+	bts := []byte("gnark hash160")
+	dgst256 := sha256.Sum256(bts)
+	h := ripemd160.New()
+	h.Write(dgst256[:])
+	dgst := h.Sum(nil)
+	witness := hash160Circuit{
+		In: uints.NewU8Array(bts),
+	}
+	copy(witness.Expected[:], uints.NewU8Array(dgst))
+	err := test.IsSolved(&hash160Circuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
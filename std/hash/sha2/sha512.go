@@ -0,0 +1,196 @@
+package sha2
+
+import (
+	"encoding/binary"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/sha2"
+)
+
+var (
+	_seed512 = uints.NewU64Array([]uint64{
+		0x6A09E667F3BCC908, 0xBB67AE8584CAA73B, 0x3C6EF372FE94F82B, 0xA54FF53A5F1D36F1, 0x510E527FADE682D1, 0x9B05688C2B3E6C1F, 0x1F83D9ABFB41BD6B, 0x5BE0CD19137E2179,
+	})
+	_seed384 = uints.NewU64Array([]uint64{
+		0xCBBB9D5DC1059ED8, 0x629A292A367CD507, 0x9159015A3070DD17, 0x152FECD8F70E5939, 0x67332667FFC00B31, 0x8EB44A8768581511, 0xDB0C2E0D64F98FA7, 0x47B5481DBEFA4FA4,
+	})
+	_seed512_256 = uints.NewU64Array([]uint64{
+		0x22312194FC2BF72C, 0x9F555FA3C84C64C2, 0x2393B86B6F53B151, 0x963877195940EABD, 0x96283EE2A88EFFE3, 0xBE5E1E2553863992, 0x2B0199FC2C85B8AA, 0x0EB72DDC81C52CA2,
+	})
+)
+
+const (
+	chunk512 = 128
+	// lenSize512 is the number of bytes used for encoding the message bit
+	// length in the padding.
+	lenSize512 = 16
+)
+
+// digest512 implements the hashes of the SHA-512 family. The variants differ
+// only in the initial hash value and the length of the truncated output.
+type digest512 struct {
+	api  frontend.API
+	uapi *uints.BinaryField[uints.U64]
+	in   []uints.U8
+	seed []uints.U64
+	size int
+}
+
+func newDigest512(api frontend.API, seed []uints.U64, size int) (hash.BinaryFixedLengthHasher, error) {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest512{api: api, uapi: uapi, seed: seed, size: size}, nil
+}
+
+// NewSHA512 returns a new SHA-512 hasher.
+func NewSHA512(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest512(api, _seed512, 64)
+}
+
+// NewSHA384 returns a new SHA-384 hasher.
+func NewSHA384(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest512(api, _seed384, 48)
+}
+
+// NewSHA512_256 returns a new SHA-512/256 hasher.
+func NewSHA512_256(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest512(api, _seed512_256, 32)
+}
+
+func (d *digest512) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+func (d *digest512) padded(bytesLen int) []uints.U8 {
+	zeroPadLen := chunk512 - lenSize512 - 1 - bytesLen%chunk512
+	if zeroPadLen < 0 {
+		zeroPadLen += chunk512
+	}
+	buf := make([]uints.U8, 0, len(d.in)+lenSize512+1+zeroPadLen)
+	buf = append(buf, d.in...)
+	buf = append(buf, uints.NewU8(0x80))
+	buf = append(buf, uints.NewU8Array(make([]uint8, zeroPadLen))...)
+	// the message length is encoded on 128 bits, we only support inputs
+	// whose bit length fits in the lower 64 bits.
+	lenbuf := make([]uint8, lenSize512)
+	binary.BigEndian.PutUint64(lenbuf[8:], uint64(8*bytesLen))
+	buf = append(buf, uints.NewU8Array(lenbuf)...)
+	return buf
+}
+
+func (d *digest512) Sum() []uints.U8 {
+	var runningDigest [8]uints.U64
+	var buf [chunk512]uints.U8
+	copy(runningDigest[:], d.seed)
+	padded := d.padded(len(d.in))
+	for i := 0; i < len(padded)/chunk512; i++ {
+		copy(buf[:], padded[i*chunk512:(i+1)*chunk512])
+		runningDigest = sha2.Permute512(d.uapi, runningDigest, buf)
+	}
+	return d.output(runningDigest)
+}
+
+// FixedLengthSum returns the digest of the first length bytes written. The
+// length must be at most the total number of bytes written, which is asserted
+// in the circuit.
+//
+// The padding is placed in-circuit depending on length, so the method always
+// computes the compression function over as many blocks as needed for the
+// full input and selects the intermediate digest after the last block of the
+// padded prefix.
+func (d *digest512) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	api := d.api
+	maxLen := len(d.in)
+	nbBlocks := (maxLen + lenSize512 + 1 + chunk512 - 1) / chunk512
+
+	// isEq[i] is 1 if i == length and 0 otherwise. As exactly one of them is
+	// set, it also asserts that length is at most maxLen.
+	isEq := make([]frontend.Variable, maxLen+1)
+	var sum frontend.Variable = 0
+	for i := range isEq {
+		isEq[i] = api.IsZero(api.Sub(length, i))
+		sum = api.Add(sum, isEq[i])
+	}
+	api.AssertIsEqual(sum, 1)
+
+	// isLast[j] is 1 if the padded message of length bytes ends in block j,
+	// i.e. if length is in [128j-16, 128j+111].
+	isLast := make([]frontend.Variable, nbBlocks)
+	for j := range isLast {
+		isLast[j] = 0
+		for i := chunk512*j - lenSize512; i < chunk512*(j+1)-lenSize512 && i <= maxLen; i++ {
+			if i >= 0 {
+				isLast[j] = api.Add(isLast[j], isEq[i])
+			}
+		}
+	}
+
+	// big-endian bytes of the message bit length. We assume the bit length
+	// fits in 64 bits and the upper bytes are zero.
+	lenBits := bits.ToBinary(api, api.Mul(length, 8), bits.WithNbDigits(64))
+	lenBytes := make([]frontend.Variable, lenSize512)
+	for i := 0; i < 8; i++ {
+		lenBytes[i] = 0
+		lenBytes[lenSize512-1-i] = bits.FromBinary(api, lenBits[8*i:8*i+8])
+	}
+
+	var runningDigest [8]uints.U64
+	var buf [chunk512]uints.U8
+	var selected [8][8]frontend.Variable
+	copy(runningDigest[:], d.seed)
+	// isLess is 1 while i < length.
+	var isLess frontend.Variable = 1
+	for j := 0; j < nbBlocks; j++ {
+		for o := 0; o < chunk512; o++ {
+			i := j*chunk512 + o
+			var v frontend.Variable = 0
+			if i <= maxLen {
+				isLess = api.Sub(isLess, isEq[i])
+				v = api.Mul(isEq[i], 0x80)
+				if i < maxLen {
+					v = api.Add(v, api.Mul(isLess, d.in[i].Val))
+				}
+			}
+			if o >= chunk512-lenSize512 {
+				v = api.Add(v, api.Mul(isLast[j], lenBytes[o-(chunk512-lenSize512)]))
+			}
+			buf[o] = d.uapi.ByteValueOf(v)
+		}
+		runningDigest = sha2.Permute512(d.uapi, runningDigest, buf)
+		for k := range runningDigest {
+			for l := range runningDigest[k] {
+				if j == 0 {
+					selected[k][l] = 0
+				}
+				selected[k][l] = api.Add(selected[k][l], api.Mul(isLast[j], runningDigest[k][l].Val))
+			}
+		}
+	}
+	for k := range runningDigest {
+		for l := range runningDigest[k] {
+			runningDigest[k][l] = d.uapi.ByteValueOf(selected[k][l])
+		}
+	}
+	return d.output(runningDigest)
+}
+
+// output returns the big-endian encoding of the digest truncated to the
+// output size of the hash.
+func (d *digest512) output(runningDigest [8]uints.U64) []uints.U8 {
+	var ret []uints.U8
+	for i := range runningDigest {
+		ret = append(ret, d.uapi.UnpackMSB(runningDigest[i])...)
+	}
+	return ret[:d.size]
+}
+
+func (d *digest512) Reset() {
+	d.in = nil
+}
+
+func (d *digest512) Size() int { return d.size }
@@ -0,0 +1,150 @@
+package sha2
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+var sha512Variants = []struct {
+	name string
+	new  func(frontend.API) (hash.BinaryFixedLengthHasher, error)
+	sum  func([]byte) []byte
+}{
+	{"SHA-512", NewSHA512, func(b []byte) []byte { r := sha512.Sum512(b); return r[:] }},
+	{"SHA-384", NewSHA384, func(b []byte) []byte { r := sha512.Sum384(b); return r[:] }},
+	{"SHA-512/256", NewSHA512_256, func(b []byte) []byte { r := sha512.Sum512_256(b); return r[:] }},
+}
+
+type sha512Circuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+
+	variant int
+}
+
+func (c *sha512Circuit) Define(api frontend.API) error {
+	h, err := sha512Variants[c.variant].new(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != len(c.Expected) {
+		return fmt.Errorf("not %d bytes", len(c.Expected))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA512(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 310)
+	for i := range bts {
+		bts[i] = byte(i)
+	}
+	for i, v := range sha512Variants {
+		i, v := i, v
+		assert.Run(func(assert *test.Assert) {
+			dgst := v.sum(bts)
+			circuit := sha512Circuit{
+				In:       make([]uints.U8, len(bts)),
+				Expected: make([]uints.U8, len(dgst)),
+				variant:  i,
+			}
+			witness := sha512Circuit{
+				In:       uints.NewU8Array(bts),
+				Expected: uints.NewU8Array(dgst),
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, v.name)
+	}
+}
+
+type sha512FixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected []uints.U8
+
+	variant int
+}
+
+func (c *sha512FixedLengthCircuit) Define(api frontend.API) error {
+	h, err := sha512Variants[c.variant].new(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	if len(res) != len(c.Expected) {
+		return fmt.Errorf("not %d bytes", len(c.Expected))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA512FixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 250)
+	for i := range bts {
+		bts[i] = byte(i)
+	}
+	for i, v := range sha512Variants {
+		i, v := i, v
+		// lengths around the block boundaries of the padded message
+		for _, length := range []int{0, 1, 111, 112, 128, 239, 240, 250} {
+			length := length
+			assert.Run(func(assert *test.Assert) {
+				dgst := v.sum(bts[:length])
+				circuit := sha512FixedLengthCircuit{
+					In:       make([]uints.U8, len(bts)),
+					Expected: make([]uints.U8, len(dgst)),
+					variant:  i,
+				}
+				witness := sha512FixedLengthCircuit{
+					In:       uints.NewU8Array(bts),
+					Length:   length,
+					Expected: uints.NewU8Array(dgst),
+				}
+				err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, v.name, fmt.Sprintf("length=%d", length))
+		}
+	}
+}
+
+func TestSHA512FixedLengthTooLong(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 10)
+	dgst := sha512.Sum512(bts)
+	circuit := sha512FixedLengthCircuit{
+		In:       make([]uints.U8, len(bts)),
+		Expected: make([]uints.U8, len(dgst)),
+	}
+	witness := sha512FixedLengthCircuit{
+		In:       uints.NewU8Array(bts),
+		Length:   11,
+		Expected: uints.NewU8Array(dgst[:]),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
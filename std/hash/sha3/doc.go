@@ -2,4 +2,8 @@
 // Keccak f-[1600] permutation function.
 //
 // Instances correspond golang.org/x/crypto/sha3, except SHA224, which is not x64 compatible.
+//
+// The hashers returned by the constructors also implement
+// [github.com/consensys/gnark/std/hash.BinaryFixedLengthHasher] for hashing
+// variable-length inputs.
 package sha3
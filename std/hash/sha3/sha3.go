@@ -1,11 +1,13 @@
 package sha3
 
 import (
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/uints"
 	"github.com/consensys/gnark/std/permutation/keccakf"
 )
 
 type digest struct {
+	api       frontend.API
 	uapi      *uints.BinaryField[uints.U64]
 	state     [25]uints.U64 // 1600 bits state: 25 x 64
 	in        []uints.U8    // input to be digested
@@ -32,6 +34,69 @@ func (d *digest) Sum() []uints.U8 {
 	return d.squeezeBlocks()
 }
 
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the total number of written bytes. The cost of
+// the method depends on the total number of written bytes and not on length.
+func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	api := d.api
+	maxLen := len(d.in)
+	// eq[k] == 1 iff length == k. Exactly one of them is set, which ensures
+	// that length <= maxLen.
+	eq := make([]frontend.Variable, maxLen+1)
+	var eqSum frontend.Variable = 0
+	for k := range eq {
+		eq[k] = api.IsZero(api.Sub(length, k))
+		eqSum = api.Add(eqSum, eq[k])
+	}
+	api.AssertIsEqual(eqSum, 1)
+	nbBlocks := maxLen/d.rate + 1
+	// isLast[i] == 1 iff the i-th block is the last one to be processed, i.e.
+	// it contains the padding.
+	isLast := make([]frontend.Variable, nbBlocks)
+	for i := range isLast {
+		isLast[i] = 0
+		for k := i * d.rate; k < (i+1)*d.rate && k <= maxLen; k++ {
+			isLast[i] = api.Add(isLast[i], eq[k])
+		}
+	}
+	// pad the input in place: the bytes after length are zeroed, the
+	// domain separation byte is put at position length and the final bit at
+	// the last byte of the last block.
+	padded := make([]uints.U8, nbBlocks*d.rate)
+	var passed frontend.Variable = 0
+	for j := range padded {
+		var v frontend.Variable = 0
+		if j < maxLen {
+			passed = api.Add(passed, eq[j])
+			v = api.Mul(d.in[j].Val, api.Sub(1, passed))
+		}
+		if j <= maxLen {
+			v = api.Add(v, api.Mul(eq[j], d.dsbyte))
+		}
+		if j%d.rate == d.rate-1 {
+			v = api.Add(v, api.Mul(isLast[j/d.rate], 0x80))
+		}
+		padded[j] = uints.U8{Val: v}
+	}
+	blocks := d.composeBlocks(padded)
+	// isActive[i] == 1 iff the i-th block is processed.
+	var isActive frontend.Variable = 1
+	for i := range blocks {
+		state := d.state
+		for j := range blocks[i] {
+			state[j] = d.uapi.Xor(state[j], blocks[i][j])
+		}
+		state = keccakf.Permute(d.uapi, state)
+		for j := range d.state {
+			for k := range d.state[j] {
+				d.state[j][k].Val = api.Select(isActive, state[j][k].Val, d.state[j][k].Val)
+			}
+		}
+		isActive = api.Sub(isActive, isLast[i])
+	}
+	return d.squeezeBlocks()
+}
+
 func (d *digest) padding() []uints.U8 {
 	padded := make([]uints.U8, len(d.in))
 	copy(padded[:], d.in[:])
@@ -88,3 +88,72 @@ func TestSHA3(t *testing.T) {
 		}, name)
 	}
 }
+
+type sha3FixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected []uints.U8
+
+	hasher string
+}
+
+func (c *sha3FixedLengthCircuit) Define(api frontend.API) error {
+	newHasher, ok := testCases[c.hasher]
+	if !ok {
+		return fmt.Errorf("hash function unknown: %s", c.hasher)
+	}
+	h, err := newHasher.zk(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+
+	fh, ok := h.(zkhash.BinaryFixedLengthHasher)
+	if !ok {
+		return fmt.Errorf("hash function %s does not support fixed length sum", c.hasher)
+	}
+	fh.Write(c.In)
+	res := fh.FixedLengthSum(c.Length)
+
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA3FixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := make([]byte, 310)
+	_, err := rand.Reader.Read(in)
+	assert.NoError(err)
+
+	for name := range testCases {
+		strategy := testCases[name]
+		rate := strategy.native().BlockSize()
+		for _, l := range []int{0, 1, rate - 2, rate - 1, rate, rate + 1, len(in)} {
+			assert.Run(func(assert *test.Assert) {
+				h := strategy.native()
+				h.Write(in[:l])
+				expected := h.Sum(nil)
+
+				circuit := &sha3FixedLengthCircuit{
+					In:       make([]uints.U8, len(in)),
+					Expected: make([]uints.U8, len(expected)),
+					hasher:   name,
+				}
+
+				witness := &sha3FixedLengthCircuit{
+					In:       uints.NewU8Array(in),
+					Length:   l,
+					Expected: uints.NewU8Array(expected),
+				}
+
+				err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, fmt.Sprintf("%s/length=%d", name, l))
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
@@ -13,8 +15,11 @@ import (
 	"github.com/consensys/gnark/std/math/bitslice"
 	"github.com/consensys/gnark/std/math/cmp"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/fixedpoint"
 	"github.com/consensys/gnark/std/rangecheck"
 	"github.com/consensys/gnark/std/selector"
+	"github.com/consensys/gnark/std/signature/eddsa"
+	"github.com/consensys/gnark/std/signature/schnorr"
 )
 
 var registerOnce sync.Once
@@ -42,4 +47,10 @@ func registerHints() {
 	solver.RegisterHint(logderivarg.GetHints()...)
 	solver.RegisterHint(bitslice.GetHints()...)
 	solver.RegisterHint(sw_emulated.GetHints()...)
+	solver.RegisterHint(sw_bls12381.GetHints()...)
+	solver.RegisterHint(sw_bn254.GetHints()...)
+	solver.RegisterHint(sw_bls12377.GetHints()...)
+	solver.RegisterHint(schnorr.GetHints()...)
+	solver.RegisterHint(eddsa.GetHints()...)
+	solver.RegisterHint(fixedpoint.GetHints()...)
 }
@@ -0,0 +1,186 @@
+// Package blake2hash implements the parts of the BLAKE2 hash functions which
+// do not depend on the word size: the parameter block, the splitting of the
+// input into message blocks, the offset counter and the final block flag, and
+// the output truncation.
+//
+// It is shared by [github.com/consensys/gnark/std/hash/blake2b] and
+// [github.com/consensys/gnark/std/hash/blake2s], which provide the word type
+// and the compression function.
+package blake2hash
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Compress is the BLAKE2 compression function F applied on the state h,
+// message block m and offset counter t. The input f is all-ones for the final
+// block and zero otherwise.
+type Compress[T uints.U32 | uints.U64] func(uapi *uints.BinaryField[T], h [8]T, m [16]T, t [2]T, f T) [8]T
+
+// Digest is an unkeyed BLAKE2 hasher over the words T. It implements
+// [github.com/consensys/gnark/std/hash.BinaryFixedLengthHasher].
+type Digest[T uints.U32 | uints.U64] struct {
+	api      frontend.API
+	uapi     *uints.BinaryField[T]
+	in       []uints.U8
+	size     int
+	wordSize int
+	iv       [8]uint64
+	compress Compress[T]
+}
+
+// New returns a new hasher computing size-byte digests. The initialization
+// vector iv is given with the words widened to 64 bits.
+func New[T uints.U32 | uints.U64](api frontend.API, iv [8]uint64, size int, compress Compress[T]) (*Digest[T], error) {
+	uapi, err := uints.New[T](api)
+	if err != nil {
+		return nil, err
+	}
+	var w T
+	wordSize := len(uapi.UnpackLSB(w))
+	return &Digest[T]{api: api, uapi: uapi, size: size, wordSize: wordSize, iv: iv, compress: compress}, nil
+}
+
+// constant returns the word v truncated to the width of T.
+func (d *Digest[T]) constant(v uint64) T {
+	bts := make([]uints.U8, d.wordSize)
+	for i := range bts {
+		bts[i] = uints.NewU8(uint8(v >> (8 * i)))
+	}
+	return d.uapi.PackLSB(bts...)
+}
+
+func (d *Digest[T]) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+// initState returns the initial chaining value, where the parameter block
+// (digest length, no key, fanout and depth 1) is mixed into the IV.
+func (d *Digest[T]) initState() [8]T {
+	var h [8]T
+	for i := range h {
+		h[i] = d.constant(d.iv[i])
+	}
+	h[0] = d.constant(d.iv[0] ^ 0x01010000 ^ uint64(d.size))
+	return h
+}
+
+// blocks splits the input into message blocks. The last block is padded with
+// zeros. There is always at least one block.
+func (d *Digest[T]) blocks(in []uints.U8) [][16]T {
+	blockSize, wordSize := 16*d.wordSize, d.wordSize
+	nbBlocks := (len(in) + blockSize - 1) / blockSize
+	if nbBlocks == 0 {
+		nbBlocks = 1
+	}
+	padded := make([]uints.U8, nbBlocks*blockSize)
+	copy(padded, in)
+	for i := len(in); i < len(padded); i++ {
+		padded[i] = uints.NewU8(0)
+	}
+	ret := make([][16]T, nbBlocks)
+	for i := range ret {
+		for j := range ret[i] {
+			ret[i][j] = d.uapi.PackLSB(padded[i*blockSize+wordSize*j : i*blockSize+wordSize*(j+1)]...)
+		}
+	}
+	return ret
+}
+
+func (d *Digest[T]) output(h [8]T) []uints.U8 {
+	var ret []uints.U8
+	for i := range h {
+		ret = append(ret, d.uapi.UnpackLSB(h[i])...)
+	}
+	return ret[:d.size]
+}
+
+func (d *Digest[T]) Sum() []uints.U8 {
+	blockSize := 16 * d.wordSize
+	h := d.initState()
+	blocks := d.blocks(d.in)
+	zero := d.constant(0)
+	for i := range blocks {
+		t := [2]T{d.constant(uint64((i + 1) * blockSize)), zero}
+		f := zero
+		if i == len(blocks)-1 {
+			t[0] = d.constant(uint64(len(d.in)))
+			f = d.constant(^uint64(0))
+		}
+		h = d.compress(d.uapi, h, blocks[i], t, f)
+	}
+	return d.output(h)
+}
+
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the total number of written bytes. The cost of
+// the method depends on the total number of written bytes and not on length.
+func (d *Digest[T]) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	api := d.api
+	blockSize := 16 * d.wordSize
+	maxLen := len(d.in)
+	// eq[k] == 1 iff length == k. Exactly one of them is set, which ensures
+	// that length <= maxLen.
+	eq := make([]frontend.Variable, maxLen+1)
+	var eqSum frontend.Variable = 0
+	for k := range eq {
+		eq[k] = api.IsZero(api.Sub(length, k))
+		eqSum = api.Add(eqSum, eq[k])
+	}
+	api.AssertIsEqual(eqSum, 1)
+	// zero out the bytes after length, so that the last block is correctly
+	// padded.
+	in := make([]uints.U8, maxLen)
+	var passed frontend.Variable = 0
+	for j := range in {
+		passed = api.Add(passed, eq[j])
+		in[j] = uints.U8{Val: api.Mul(d.in[j].Val, api.Sub(1, passed))}
+	}
+	blocks := d.blocks(in)
+	// isLast[i] == 1 iff the i-th block is the last one to be processed.
+	isLast := make([]frontend.Variable, len(blocks))
+	for i := range isLast {
+		isLast[i] = 0
+		if i == 0 {
+			isLast[i] = eq[0]
+		}
+		for k := i*blockSize + 1; k <= (i+1)*blockSize && k <= maxLen; k++ {
+			isLast[i] = api.Add(isLast[i], eq[k])
+		}
+	}
+	// isActive[i] == 1 iff the i-th block is processed.
+	isActive := make([]frontend.Variable, len(blocks))
+	var acc frontend.Variable = 0
+	for i := len(blocks) - 1; i >= 0; i-- {
+		acc = api.Add(acc, isLast[i])
+		isActive[i] = acc
+	}
+
+	h := d.initState()
+	for i := range blocks {
+		t := [2]T{
+			d.uapi.ValueOf(api.Select(isLast[i], length, (i+1)*blockSize)),
+			d.constant(0),
+		}
+		fb := make([]uints.U8, d.wordSize)
+		for j := range fb {
+			fb[j] = uints.U8{Val: api.Mul(isLast[i], 0xff)}
+		}
+		hh := d.compress(d.uapi, h, blocks[i], t, d.uapi.PackLSB(fb...))
+		for j := range h {
+			prev, next := d.uapi.UnpackLSB(h[j]), d.uapi.UnpackLSB(hh[j])
+			for k := range prev {
+				prev[k].Val = api.Select(isActive[i], next[k].Val, prev[k].Val)
+			}
+			h[j] = d.uapi.PackLSB(prev...)
+		}
+	}
+	return d.output(h)
+}
+
+func (d *Digest[T]) Reset() {
+	d.in = nil
+}
+
+func (d *Digest[T]) Size() int { return d.size }
@@ -0,0 +1,309 @@
+package emulated
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// ModMul computes a*b mod modulus. Instead of taking modulus as a constant
+// parametrized by T, it is passed as an argument. This allows to use a variable
+// modulus in the circuit. Type parameter T should be sufficiently big to fit a,
+// b and modulus. Recommended to use [emparams.Mod1e512], [emparams.Mod1e2048]
+// or [emparams.Mod1e4096].
+//
+// The modulus must be non-zero and have the same number of limbs as defined by
+// T. The result is width-constrained, but may not be strictly less than the
+// modulus. Use [Field.ModAssertIsInRange] to ensure this.
+//
+// NB! circuit complexity depends on T rather on the actual length of the modulus.
+func (f *Field[T]) ModMul(a, b *Element[T], modulus *Element[T]) *Element[T] {
+	return f.modReduceAndOp(func(a, b *Element[T], _ uint) *Element[T] {
+		return f.mulModVar(a, b, modulus)
+	}, f.mulPreCond, a, b, modulus)
+}
+
+// ModReduce reduces a modulo modulus and returns it. Instead of taking modulus
+// as a constant parametrized by T, it is passed as an argument. See
+// [Field.ModMul] for the requirements on the modulus.
+//
+// Similarly to [Field.Reduce], the result is only width-constrained. Use
+// [Field.ModAssertIsInRange] to ensure that the result is strictly less than
+// the modulus.
+func (f *Field[T]) ModReduce(a *Element[T], modulus *Element[T]) *Element[T] {
+	return f.mulModVar(a, f.One(), modulus)
+}
+
+// ModAdd computes a+b mod modulus. Instead of taking modulus as a constant
+// parametrized by T, it is passed as an argument. See [Field.ModMul] for the
+// requirements on the modulus.
+//
+// Similarly to [Field.Add], the result is not reduced, but the inputs are
+// reduced modulo modulus if the result would overflow.
+func (f *Field[T]) ModAdd(a, b *Element[T], modulus *Element[T]) *Element[T] {
+	return f.modReduceAndOp(func(a, b *Element[T], nextOverflow uint) *Element[T] {
+		return f.modAdd(a, b, nextOverflow)
+	}, f.modPreCond(f.addPreCond), a, b, modulus)
+}
+
+// ModSub computes a-b mod modulus. Instead of taking modulus as a constant
+// parametrized by T, it is passed as an argument. See [Field.ModMul] for the
+// requirements on the modulus.
+//
+// As the modulus is not known at circuit compile time, the padding for
+// avoiding the underflow is computed using a hint and it is asserted to be a
+// multiple of the modulus. This costs an additional multiplication check
+// compared to [Field.Sub].
+func (f *Field[T]) ModSub(a, b *Element[T], modulus *Element[T]) *Element[T] {
+	return f.modReduceAndOp(func(a, b *Element[T], nextOverflow uint) *Element[T] {
+		return f.modSub(a, b, modulus, nextOverflow)
+	}, f.modPreCond(f.subPreCond), a, b, modulus)
+}
+
+// ModAssertIsEqual asserts that a and b are equal modulo modulus. Instead of
+// taking modulus as a constant parametrized by T, it is passed as an argument.
+// See [Field.ModMul] for the requirements on the modulus.
+func (f *Field[T]) ModAssertIsEqual(a, b *Element[T], modulus *Element[T]) {
+	diff := f.ModSub(b, a, modulus)
+	f.modAssertIsZero(diff, modulus)
+}
+
+// ModExp computes base^exp mod modulus. Instead of taking modulus as a constant
+// parametrized by T, it is passed as an argument. See [Field.ModMul] for the
+// requirements on the modulus.
+//
+// The exponent is decomposed into nbLimbs*nbBits bits, so the cost of the
+// method depends on T rather on the actual length of the exponent. If the
+// exponent is zero, then returns one (which is not reduced modulo modulus).
+func (f *Field[T]) ModExp(base, exp, modulus *Element[T]) *Element[T] {
+	expBts := f.ToBits(exp)
+	n := len(expBts)
+	res := f.Select(expBts[0], base, f.One())
+	base = f.ModMul(base, base, modulus)
+	for i := 1; i < n-1; i++ {
+		res = f.Select(expBts[i], f.ModMul(base, res, modulus), res)
+		base = f.ModMul(base, base, modulus)
+	}
+	res = f.Select(expBts[n-1], f.ModMul(base, res, modulus), res)
+	return res
+}
+
+// ModAssertIsInRange asserts that a is strictly less than modulus as integers.
+// Contrary to [Field.AssertIsInRange], it does not perform bit decomposition of
+// the inputs, but instead asserts that there exists non-negative d such that
+// a+d+1 == modulus.
+func (f *Field[T]) ModAssertIsInRange(a *Element[T], modulus *Element[T]) {
+	f.enforceWidthConditional(a)
+	f.enforceWidthConditional(modulus)
+	nbLimbs := f.fParams.NbLimbs()
+	if uint(len(a.Limbs)) > nbLimbs || a.overflow > 0 {
+		a = f.ModReduce(a, modulus)
+	}
+	hintInputs := []frontend.Variable{
+		f.fParams.BitsPerLimb(),
+		len(a.Limbs),
+		len(modulus.Limbs),
+	}
+	hintInputs = append(hintInputs, a.Limbs...)
+	hintInputs = append(hintInputs, modulus.Limbs...)
+	dLimbs, err := f.api.Compiler().NewHint(RangeDiffHint, int(nbLimbs), hintInputs...)
+	if err != nil {
+		panic(fmt.Sprintf("range diff hint: %v", err))
+	}
+	d := f.packLimbs(dLimbs, false)
+	lhs := f.add(f.add(a, d, 1), f.One(), 2)
+	f.AssertLimbsEquality(lhs, modulus)
+}
+
+// modReduceAndOp is as [Field.reduceAndOp], but reduces the inputs using the
+// given modulus instead of the modulus defined by T.
+func (f *Field[T]) modReduceAndOp(op func(*Element[T], *Element[T], uint) *Element[T], preCond func(*Element[T], *Element[T]) (uint, error), a, b, modulus *Element[T]) *Element[T] {
+	f.enforceWidthConditional(a)
+	f.enforceWidthConditional(b)
+	var nextOverflow uint
+	var err error
+	var target overflowError
+
+	for nextOverflow, err = preCond(a, b); errors.As(err, &target); nextOverflow, err = preCond(a, b) {
+		if !target.reduceRight {
+			a = f.ModReduce(a, modulus)
+		} else {
+			b = f.ModReduce(b, modulus)
+		}
+	}
+	return op(a, b, nextOverflow)
+}
+
+// modPreCond wraps the overflow precondition of the operation with the
+// additional requirement that the result of the operation can be reduced
+// modulo the variable modulus. Otherwise we couldn't soundly perform the
+// multiplication check for the reduction.
+func (f *Field[T]) modPreCond(preCond func(*Element[T], *Element[T]) (uint, error)) func(*Element[T], *Element[T]) (uint, error) {
+	return func(a, b *Element[T]) (nextOverflow uint, err error) {
+		nextOverflow, err = preCond(a, b)
+		if err != nil {
+			return
+		}
+		nbLimbs := max(len(a.Limbs), len(b.Limbs), int(f.fParams.NbLimbs()))
+		nbResLimbs := nbMultiplicationResLimbs(nbLimbs, int(f.fParams.NbLimbs()))
+		mulOverflow := f.fParams.BitsPerLimb() + uint(bits.Len(uint(2*nbResLimbs-1))) + nextOverflow
+		if mulOverflow > f.maxOverflow() {
+			err = overflowError{op: "modreduce", nextOverflow: mulOverflow, maxOverflow: f.maxOverflow(), reduceRight: a.overflow < b.overflow}
+		}
+		return
+	}
+}
+
+// modAdd adds a and b limb-wise. Contrary to [Field.add] we do not fold
+// constant inputs as the result would be reduced modulo the modulus defined by
+// T.
+func (f *Field[T]) modAdd(a, b *Element[T], nextOverflow uint) *Element[T] {
+	nbLimbs := max(len(a.Limbs), len(b.Limbs))
+	limbs := make([]frontend.Variable, nbLimbs)
+	for i := range limbs {
+		limbs[i] = 0
+		if i < len(a.Limbs) {
+			limbs[i] = f.api.Add(limbs[i], a.Limbs[i])
+		}
+		if i < len(b.Limbs) {
+			limbs[i] = f.api.Add(limbs[i], b.Limbs[i])
+		}
+	}
+	return f.newInternalElement(limbs, nextOverflow)
+}
+
+// modSub subtracts b from a using padding which is a multiple of modulus. The
+// padding is computed by [Field.computeSubPaddingHint].
+func (f *Field[T]) modSub(a, b, modulus *Element[T], nextOverflow uint) *Element[T] {
+	nbLimbs := max(len(a.Limbs), len(b.Limbs), int(f.fParams.NbLimbs()))
+	padding := f.computeSubPaddingHint(b.overflow, uint(nbLimbs), modulus)
+	limbs := make([]frontend.Variable, nbLimbs)
+	for i := range limbs {
+		limbs[i] = padding.Limbs[i]
+		if i < len(a.Limbs) {
+			limbs[i] = f.api.Add(limbs[i], a.Limbs[i])
+		}
+		if i < len(b.Limbs) {
+			limbs[i] = f.api.Sub(limbs[i], b.Limbs[i])
+		}
+	}
+	return f.newInternalElement(limbs, nextOverflow)
+}
+
+// computeSubPaddingHint returns padding with nbLimbs limbs which is a multiple
+// of modulus and every limb is at least 2^(nbBits+overflow). The padding is
+// constructed as the constant with limbs 2^(nbBits+overflow) plus a
+// width-constrained correction term computed in a hint, and the method asserts
+// that the result is zero modulo modulus.
+func (f *Field[T]) computeSubPaddingHint(overflow, nbLimbs uint, modulus *Element[T]) *Element[T] {
+	nbBits := f.fParams.BitsPerLimb()
+	hintInputs := []frontend.Variable{
+		nbBits,
+		overflow,
+		nbLimbs,
+		len(modulus.Limbs),
+	}
+	hintInputs = append(hintInputs, modulus.Limbs...)
+	res, err := f.api.NewHint(subPaddingHint, int(f.fParams.NbLimbs()), hintInputs...)
+	if err != nil {
+		panic(fmt.Sprintf("sub padding hint: %v", err))
+	}
+	correction := f.packLimbs(res, false)
+	limbs := make([]frontend.Variable, nbLimbs)
+	for i := range limbs {
+		limbs[i] = new(big.Int).Lsh(big.NewInt(1), nbBits+overflow)
+		if i < len(correction.Limbs) {
+			limbs[i] = f.api.Add(limbs[i], correction.Limbs[i])
+		}
+	}
+	padding := f.newInternalElement(limbs, overflow+1)
+	f.modAssertIsZero(padding, modulus)
+	return padding
+}
+
+// modAssertIsZero asserts that a is zero modulo modulus.
+func (f *Field[T]) modAssertIsZero(a, modulus *Element[T]) {
+	// the reduced value is only width-constrained, but it can be zero only if
+	// a is a multiple of modulus as the multiplication check is over integers.
+	r := f.mulModVar(a, f.One(), modulus)
+	for i := range r.Limbs {
+		f.api.AssertIsEqual(r.Limbs[i], 0)
+	}
+}
+
+// VariableModulus provides the arithmetic modulo a modulus which is not known
+// at circuit compile time, for example a witness RSA modulus. It wraps the
+// Mod* methods of [Field] with a fixed modulus element. Type parameter T only
+// defines the number of limbs and the bit width of the limbs and should be
+// sufficiently big to fit the modulus. Recommended to use [emparams.Mod1e512],
+// [emparams.Mod1e2048] or [emparams.Mod1e4096].
+//
+// As for the constant-modulus [Field], the results are only width-constrained
+// unless stated otherwise. Use [VariableModulus.AssertIsInRange] to ensure
+// that an element is strictly less than the modulus.
+type VariableModulus[T FieldParams] struct {
+	f       *Field[T]
+	modulus *Element[T]
+}
+
+// NewVariableModulus returns a new [VariableModulus] for the given modulus.
+// The modulus must be non-zero and have the same number of limbs as defined by
+// T. See [Field.ModMul] for the requirements on the modulus.
+func NewVariableModulus[T FieldParams](api frontend.API, modulus *Element[T]) (*VariableModulus[T], error) {
+	f, err := NewField[T](api)
+	if err != nil {
+		return nil, fmt.Errorf("new field: %w", err)
+	}
+	return &VariableModulus[T]{f: f, modulus: modulus}, nil
+}
+
+// Modulus returns the modulus element.
+func (v *VariableModulus[T]) Modulus() *Element[T] {
+	return v.modulus
+}
+
+// Field returns the underlying field for the operations which do not depend
+// on the modulus, such as creating constants or selecting elements.
+func (v *VariableModulus[T]) Field() *Field[T] {
+	return v.f
+}
+
+// Mul computes a*b mod modulus. See [Field.ModMul].
+func (v *VariableModulus[T]) Mul(a, b *Element[T]) *Element[T] {
+	return v.f.ModMul(a, b, v.modulus)
+}
+
+// Add computes a+b mod modulus. See [Field.ModAdd].
+func (v *VariableModulus[T]) Add(a, b *Element[T]) *Element[T] {
+	return v.f.ModAdd(a, b, v.modulus)
+}
+
+// Sub computes a-b mod modulus. See [Field.ModSub].
+func (v *VariableModulus[T]) Sub(a, b *Element[T]) *Element[T] {
+	return v.f.ModSub(a, b, v.modulus)
+}
+
+// Reduce reduces a modulo modulus. See [Field.ModReduce].
+func (v *VariableModulus[T]) Reduce(a *Element[T]) *Element[T] {
+	return v.f.ModReduce(a, v.modulus)
+}
+
+// AssertIsEqual asserts that a and b are equal modulo modulus. See
+// [Field.ModAssertIsEqual].
+func (v *VariableModulus[T]) AssertIsEqual(a, b *Element[T]) {
+	v.f.ModAssertIsEqual(a, b, v.modulus)
+}
+
+// Exp computes base^exp mod modulus. See [Field.ModExp].
+func (v *VariableModulus[T]) Exp(base, exp *Element[T]) *Element[T] {
+	return v.f.ModExp(base, exp, v.modulus)
+}
+
+// AssertIsInRange asserts that a is strictly less than the modulus. See
+// [Field.ModAssertIsInRange].
+func (v *VariableModulus[T]) AssertIsInRange(a *Element[T]) {
+	v.f.ModAssertIsInRange(a, v.modulus)
+}
@@ -0,0 +1,227 @@
+package emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	"github.com/consensys/gnark/test"
+)
+
+type variableModMul[T FieldParams] struct {
+	A, B, C Element[T]
+	Modulus Element[T]
+}
+
+func (c *variableModMul[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res := f.ModMul(&c.A, &c.B, &c.Modulus)
+	f.ModAssertIsInRange(res, &c.Modulus)
+	f.AssertLimbsEquality(res, &c.C)
+	return nil
+}
+
+func TestVariableMul(t *testing.T) {
+	assert := test.NewAssert(t)
+	modulus, _ := new(big.Int).SetString("4294967311", 10)
+	a, _ := rand.Int(rand.Reader, modulus)
+	b, _ := rand.Int(rand.Reader, modulus)
+	c := new(big.Int).Mul(a, b)
+	c.Mod(c, modulus)
+	circuit := &variableModMul[emparams.Mod1e512]{}
+	assignment := &variableModMul[emparams.Mod1e512]{
+		A:       ValueOf[emparams.Mod1e512](a),
+		B:       ValueOf[emparams.Mod1e512](b),
+		C:       ValueOf[emparams.Mod1e512](c),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	unreduced := &variableModMul[emparams.Mod1e512]{
+		A:       ValueOf[emparams.Mod1e512](a),
+		B:       ValueOf[emparams.Mod1e512](b),
+		C:       ValueOf[emparams.Mod1e512](new(big.Int).Add(c, modulus)),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(assignment),
+		test.WithInvalidAssignment(unreduced),
+		test.WithCurves(ecc.BN254), test.NoFuzzing(), test.NoSerializationChecks())
+}
+
+type variableModExp[T FieldParams] struct {
+	Base, Exp, Res Element[T]
+	Modulus        Element[T]
+}
+
+func (c *variableModExp[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res := f.ModExp(&c.Base, &c.Exp, &c.Modulus)
+	res = f.ModReduce(res, &c.Modulus)
+	f.ModAssertIsInRange(res, &c.Modulus)
+	f.AssertLimbsEquality(res, &c.Res)
+	return nil
+}
+
+func TestVariableExp(t *testing.T) {
+	assert := test.NewAssert(t)
+	modulus, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 512))
+	base, _ := rand.Int(rand.Reader, modulus)
+	exp, _ := rand.Int(rand.Reader, modulus)
+	res := new(big.Int).Exp(base, exp, modulus)
+	circuit := &variableModExp[emparams.Mod1e512]{}
+	assignment := &variableModExp[emparams.Mod1e512]{
+		Base:    ValueOf[emparams.Mod1e512](base),
+		Exp:     ValueOf[emparams.Mod1e512](exp),
+		Res:     ValueOf[emparams.Mod1e512](res),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	err := test.IsSolved(circuit, assignment, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type variableModAddSub[T FieldParams] struct {
+	A, B      Element[T]
+	Sum, Diff Element[T]
+	Modulus   Element[T]
+}
+
+func (c *variableModAddSub[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	sum := f.ModAdd(&c.A, &c.B, &c.Modulus)
+	f.ModAssertIsEqual(sum, &c.Sum, &c.Modulus)
+	diff := f.ModSub(&c.A, &c.B, &c.Modulus)
+	f.ModAssertIsEqual(diff, &c.Diff, &c.Modulus)
+	// a-b+b == a
+	f.ModAssertIsEqual(f.ModAdd(diff, &c.B, &c.Modulus), &c.A, &c.Modulus)
+	// (a-b)*(a+b) == a^2-b^2
+	lhs := f.ModMul(diff, sum, &c.Modulus)
+	rhs := f.ModSub(f.ModMul(&c.A, &c.A, &c.Modulus), f.ModMul(&c.B, &c.B, &c.Modulus), &c.Modulus)
+	f.ModAssertIsEqual(lhs, rhs, &c.Modulus)
+	return nil
+}
+
+func TestVariableAddSub(t *testing.T) {
+	assert := test.NewAssert(t)
+	// we use smaller modulus to be able to assign non-reduced values
+	modulus, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 500))
+	a, _ := rand.Int(rand.Reader, modulus)
+	b, _ := rand.Int(rand.Reader, modulus)
+	sum := new(big.Int).Add(a, b)
+	sum.Mod(sum, modulus)
+	diff := new(big.Int).Sub(a, b)
+	diff.Mod(diff, modulus)
+	circuit := &variableModAddSub[emparams.Mod1e512]{}
+	assignment := &variableModAddSub[emparams.Mod1e512]{
+		A:       ValueOf[emparams.Mod1e512](a),
+		B:       ValueOf[emparams.Mod1e512](b),
+		Sum:     ValueOf[emparams.Mod1e512](sum),
+		Diff:    ValueOf[emparams.Mod1e512](new(big.Int).Add(diff, modulus)),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	err := test.IsSolved(circuit, assignment, testCurve.ScalarField())
+	assert.NoError(err)
+	assignment.Sum = ValueOf[emparams.Mod1e512](new(big.Int).Add(sum, big.NewInt(1)))
+	err = test.IsSolved(circuit, assignment, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+type variableModAssertIsEqual[T FieldParams] struct {
+	A, B    Element[T]
+	Modulus Element[T]
+}
+
+func (c *variableModAssertIsEqual[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	f.ModAssertIsEqual(&c.A, &c.B, &c.Modulus)
+	return nil
+}
+
+func TestVariableAssertIsEqual(t *testing.T) {
+	assert := test.NewAssert(t)
+	modulus, _ := new(big.Int).SetString("4294967311", 10)
+	a, _ := rand.Int(rand.Reader, modulus)
+	circuit := &variableModAssertIsEqual[emparams.Mod1e512]{}
+	assignment := &variableModAssertIsEqual[emparams.Mod1e512]{
+		A:       ValueOf[emparams.Mod1e512](a),
+		B:       ValueOf[emparams.Mod1e512](new(big.Int).Add(a, new(big.Int).Lsh(modulus, 3))),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	wrong := &variableModAssertIsEqual[emparams.Mod1e512]{
+		A:       ValueOf[emparams.Mod1e512](a),
+		B:       ValueOf[emparams.Mod1e512](new(big.Int).Add(a, big.NewInt(1))),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(assignment),
+		test.WithInvalidAssignment(wrong),
+		test.WithCurves(ecc.BN254), test.NoFuzzing(), test.NoSerializationChecks())
+}
+
+type variableModulusCircuit[T FieldParams] struct {
+	A, B     Element[T]
+	Mul, Add Element[T]
+	Sub, Exp Element[T]
+	Modulus  Element[T]
+}
+
+func (c *variableModulusCircuit[T]) Define(api frontend.API) error {
+	v, err := NewVariableModulus[T](api, &c.Modulus)
+	if err != nil {
+		return err
+	}
+	mul := v.Mul(&c.A, &c.B)
+	v.AssertIsInRange(mul)
+	v.Field().AssertLimbsEquality(mul, &c.Mul)
+	v.AssertIsEqual(v.Add(&c.A, &c.B), &c.Add)
+	v.AssertIsEqual(v.Sub(&c.A, &c.B), &c.Sub)
+	exp := v.Exp(&c.A, &c.B)
+	v.AssertIsInRange(exp)
+	v.Field().AssertLimbsEquality(exp, &c.Exp)
+	red := v.Reduce(v.Field().Add(&c.A, &c.Modulus))
+	v.AssertIsInRange(red)
+	v.Field().AssertLimbsEquality(red, &c.A)
+	return nil
+}
+
+func TestVariableModulus(t *testing.T) {
+	assert := test.NewAssert(t)
+	modulus, _ := new(big.Int).SetString("4294967311", 10)
+	a, _ := rand.Int(rand.Reader, modulus)
+	b, _ := rand.Int(rand.Reader, modulus)
+	mul := new(big.Int).Mul(a, b)
+	mul.Mod(mul, modulus)
+	add := new(big.Int).Add(a, b)
+	add.Mod(add, modulus)
+	sub := new(big.Int).Sub(a, b)
+	sub.Mod(sub, modulus)
+	exp := new(big.Int).Exp(a, b, modulus)
+	assignment := &variableModulusCircuit[emparams.Mod1e512]{
+		A:       ValueOf[emparams.Mod1e512](a),
+		B:       ValueOf[emparams.Mod1e512](b),
+		Mul:     ValueOf[emparams.Mod1e512](mul),
+		Add:     ValueOf[emparams.Mod1e512](add),
+		Sub:     ValueOf[emparams.Mod1e512](sub),
+		Exp:     ValueOf[emparams.Mod1e512](exp),
+		Modulus: ValueOf[emparams.Mod1e512](modulus),
+	}
+	wrongSub := *assignment
+	wrongSub.Sub = ValueOf[emparams.Mod1e512](new(big.Int).Add(sub, big.NewInt(1)))
+	assert.CheckCircuit(&variableModulusCircuit[emparams.Mod1e512]{},
+		test.WithValidAssignment(assignment),
+		test.WithInvalidAssignment(&wrongSub),
+		test.WithCurves(ecc.BN254), test.NoFuzzing(), test.NoSerializationChecks())
+}
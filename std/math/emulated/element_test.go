@@ -918,6 +918,11 @@ func testIsZero[T FieldParams](t *testing.T) {
 		circuit := IsZeroCircuit[T]{}
 		assert.ProverSucceeded(&circuit, &IsZeroCircuit[T]{X: ValueOf[T](X), Y: ValueOf[T](Y), Zero: 1}, test.WithCurves(testCurve), test.NoSerializationChecks(), test.WithBackends(backend.GROTH16, backend.PLONK))
 		assert.ProverSucceeded(&circuit, &IsZeroCircuit[T]{X: ValueOf[T](X), Y: ValueOf[T](0), Zero: 0}, test.WithCurves(testCurve), test.NoSerializationChecks(), test.WithBackends(backend.GROTH16, backend.PLONK))
+		if fp.NbLimbs() > 1 {
+			// non-zero element with zero lowest limb
+			X := new(big.Int).Lsh(big.NewInt(1), fp.BitsPerLimb())
+			assert.ProverSucceeded(&circuit, &IsZeroCircuit[T]{X: ValueOf[T](X), Y: ValueOf[T](0), Zero: 0}, test.WithCurves(testCurve), test.NoSerializationChecks(), test.WithBackends(backend.GROTH16, backend.PLONK))
+		}
 	}, testName[T]())
 }
 
@@ -281,3 +281,88 @@ func (fp BLS12315Fp) Modulus() *big.Int { return ecc.BLS24_315.BaseField() }
 type BLS12315Fr struct{ fourLimbPrimeField }
 
 func (fr BLS12315Fr) Modulus() *big.Int { return ecc.BLS24_315.ScalarField() }
+
+// Ed25519Fp provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed (base 16)
+//	57896044618658097711785492504343953926634992332820282019728792003956564819949 (base 10)
+//
+// This is the base field of the twisted Edwards curve Ed25519 (also
+// Curve25519 in Montgomery form).
+type Ed25519Fp struct{ fourLimbPrimeField }
+
+func (Ed25519Fp) Modulus() *big.Int { return ed25519Fp }
+
+// Ed25519Fr provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed (base 16)
+//	7237005577332262213973186563042994240857116359379907606001950938285454250989 (base 10)
+//
+// This is the order of the prime-order subgroup of the twisted Edwards curve
+// Ed25519.
+type Ed25519Fr struct{ fourLimbPrimeField }
+
+func (Ed25519Fr) Modulus() *big.Int { return ed25519Fr }
+
+// Mod1e512 provides type parametrization for emulated arithmetic:
+//   - limbs: 8
+//   - limb width: 64 bits
+//
+// The modulus for type parametrisation is 2^512-1.
+//
+// This is non-prime modulus. It is mainly targeted for using variable-modulus
+// operations (ModMul, ModExp, ModReduce) for variable modulus arithmetic.
+type Mod1e512 struct{}
+
+func (Mod1e512) NbLimbs() uint     { return 8 }
+func (Mod1e512) BitsPerLimb() uint { return 64 }
+func (Mod1e512) IsPrime() bool     { return false }
+func (Mod1e512) Modulus() *big.Int { return pow2Minus1(512) }
+
+// Mod1e2048 provides type parametrization for emulated arithmetic:
+//   - limbs: 32
+//   - limb width: 64 bits
+//
+// The modulus for type parametrisation is 2^2048-1.
+//
+// This is non-prime modulus. It is mainly targeted for using variable-modulus
+// operations (ModMul, ModExp, ModReduce) for variable modulus arithmetic.
+type Mod1e2048 struct{}
+
+func (Mod1e2048) NbLimbs() uint     { return 32 }
+func (Mod1e2048) BitsPerLimb() uint { return 64 }
+func (Mod1e2048) IsPrime() bool     { return false }
+func (Mod1e2048) Modulus() *big.Int { return pow2Minus1(2048) }
+
+// Mod1e4096 provides type parametrization for emulated arithmetic:
+//   - limbs: 64
+//   - limb width: 64 bits
+//
+// The modulus for type parametrisation is 2^4096-1.
+//
+// This is non-prime modulus. It is mainly targeted for using variable-modulus
+// operations (ModMul, ModExp, ModReduce) for variable modulus arithmetic.
+type Mod1e4096 struct{}
+
+func (Mod1e4096) NbLimbs() uint     { return 64 }
+func (Mod1e4096) BitsPerLimb() uint { return 64 }
+func (Mod1e4096) IsPrime() bool     { return false }
+func (Mod1e4096) Modulus() *big.Int { return pow2Minus1(4096) }
+
+func pow2Minus1(n uint) *big.Int {
+	r := new(big.Int).Lsh(big.NewInt(1), n)
+	return r.Sub(r, big.NewInt(1))
+}
+
+var (
+	ed25519Fp, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+	ed25519Fr, _ = new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+)
@@ -58,6 +58,7 @@ type mulCheck[T FieldParams] struct {
 	r    *Element[T] // reduced value
 	k    *Element[T] // coefficient
 	c    *Element[T] // carry
+	p    *Element[T] // modulus if non-nil, otherwise the modulus of the field
 }
 
 // evalRound1 evaluates first c(X), r(X) and k(X) at a given random point at[0].
@@ -67,6 +68,9 @@ func (mc *mulCheck[T]) evalRound1(api frontend.API, at []frontend.Variable) {
 	mc.c = mc.f.evalWithChallenge(mc.c, at)
 	mc.r = mc.f.evalWithChallenge(mc.r, at)
 	mc.k = mc.f.evalWithChallenge(mc.k, at)
+	if mc.p != nil {
+		mc.p = mc.f.evalWithChallenge(mc.p, at)
+	}
 }
 
 // evalRound2 now evaluates a and b at a given random point at[0]. However, it
@@ -79,8 +83,12 @@ func (mc *mulCheck[T]) evalRound2(api frontend.API, at []frontend.Variable) {
 
 // check checks a(ch) * b(ch) = r(ch) + k(ch) * p(ch) + (2^t - ch) c(ch). As the
 // computation of p(ch) and (2^t-ch) can be shared over all mulCheck instances,
-// then we get them already evaluated as peval and coef.
+// then we get them already evaluated as peval and coef. If the check is for a
+// variable modulus, then we use its evaluation instead of peval.
 func (mc *mulCheck[T]) check(api frontend.API, peval, coef frontend.Variable) {
+	if mc.p != nil {
+		peval = mc.p.evaluation
+	}
 	ls := api.Mul(mc.a.evaluation, mc.b.evaluation)
 	rs := api.Add(mc.r.evaluation, api.Mul(peval, mc.k.evaluation), api.Mul(mc.c.evaluation, coef))
 	api.AssertIsEqual(ls, rs)
@@ -99,14 +107,26 @@ func (mc *mulCheck[T]) cleanEvaluations() {
 	mc.k.isEvaluated = false
 	mc.c.evaluation = 0
 	mc.c.isEvaluated = false
+	if mc.p != nil {
+		mc.p.evaluation = 0
+		mc.p.isEvaluated = false
+	}
 }
 
 // mulMod returns a*b mod r. In practice it computes the result using a hint and
 // defers the actual multiplication check.
 func (f *Field[T]) mulMod(a, b *Element[T], _ uint) *Element[T] {
+	return f.mulModVar(a, b, nil)
+}
+
+// mulModVar returns a*b mod modulus. If modulus is nil, then uses the modulus
+// of the field. Otherwise the modulus is given as an element and the result is
+// only constrained to have the width of the field parameters.
+func (f *Field[T]) mulModVar(a, b, modulus *Element[T]) *Element[T] {
 	f.enforceWidthConditional(a)
 	f.enforceWidthConditional(b)
-	k, r, c, err := f.callMulHint(a, b)
+	f.enforceWidthConditional(modulus)
+	k, r, c, err := f.callMulHint(a, b, modulus)
 	if err != nil {
 		panic(err)
 	}
@@ -117,6 +137,7 @@ func (f *Field[T]) mulMod(a, b *Element[T], _ uint) *Element[T] {
 		c: c,
 		k: k,
 		r: r,
+		p: modulus,
 	}
 	f.mulChecks = append(f.mulChecks, mc)
 	return r
@@ -167,6 +188,9 @@ func (f *Field[T]) performMulChecks(api frontend.API) error {
 		toCommit = append(toCommit, f.mulChecks[i].r.Limbs...)
 		toCommit = append(toCommit, f.mulChecks[i].k.Limbs...)
 		toCommit = append(toCommit, f.mulChecks[i].c.Limbs...)
+		if f.mulChecks[i].p != nil {
+			toCommit = append(toCommit, f.mulChecks[i].p.Limbs...)
+		}
 	}
 	// we give all the inputs as inputs to obtain random verifier challenge.
 	multicommit.WithCommitment(api, func(api frontend.API, commitment frontend.Variable) error {
@@ -209,8 +233,9 @@ func (f *Field[T]) performMulChecks(api frontend.API) error {
 	return nil
 }
 
-// callMulHint uses hint to compute r, k and c.
-func (f *Field[T]) callMulHint(a, b *Element[T]) (quo, rem, carries *Element[T], err error) {
+// callMulHint uses hint to compute r, k and c. If modulus is nil, then uses the
+// modulus of the field.
+func (f *Field[T]) callMulHint(a, b, modulus *Element[T]) (quo, rem, carries *Element[T], err error) {
 	// inputs is always nblimbs
 	// quotient may be larger if inputs have overflow
 	// remainder is always nblimbs
@@ -219,8 +244,15 @@ func (f *Field[T]) callMulHint(a, b *Element[T]) (quo, rem, carries *Element[T],
 	// skip error handle - it happens when we are supposed to reduce. But we
 	// already check it as a precondition. We only need the overflow here.
 	nbLimbs, nbBits := f.fParams.NbLimbs(), f.fParams.BitsPerLimb()
+	// for variable modulus we do not know its bitlength. We can only assume
+	// that it is non-zero and have to allocate the quotient for the worst case.
+	modBitLen := uint(1)
+	if modulus == nil {
+		modulus = f.Modulus()
+		modBitLen = uint(f.fParams.Modulus().BitLen())
+	}
 	nbQuoLimbs := ((2*nbLimbs-1)*nbBits + nextOverflow + 1 - //
-		uint(f.fParams.Modulus().BitLen()) + //
+		modBitLen + //
 		nbBits - 1) /
 		nbBits
 	nbRemLimbs := nbLimbs
@@ -229,7 +261,7 @@ func (f *Field[T]) callMulHint(a, b *Element[T]) (quo, rem, carries *Element[T],
 		nbBits,
 		nbLimbs,
 	}
-	hintInputs = append(hintInputs, f.Modulus().Limbs...)
+	hintInputs = append(hintInputs, modulus.Limbs...)
 	hintInputs = append(hintInputs, a.Limbs...)
 	hintInputs = append(hintInputs, b.Limbs...)
 	ret, err := f.api.NewHint(mulHint, int(nbQuoLimbs)+int(nbRemLimbs)+int(nbCarryLimbs), hintInputs...)
@@ -25,6 +25,8 @@ func GetHints() []solver.Hint {
 		RightShift,
 		SqrtHint,
 		mulHint,
+		RangeDiffHint,
+		subPaddingHint,
 	}
 }
 
@@ -294,3 +296,77 @@ func SqrtHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 		return nil
 	})
 }
+
+// RangeDiffHint computes m-a-1 for the inputs a and m and stores it in outputs.
+// Expects the inputs to be:
+//   - nbBits per limb
+//   - nbLimbs(a)
+//   - nbLimbs(m)
+//   - limbs(a)
+//   - limbs(m)
+//
+// Errors if a is not strictly less than m.
+func RangeDiffHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 3 {
+		return fmt.Errorf("input must be at least three elements")
+	}
+	nbBits := uint(inputs[0].Uint64())
+	nbALimbs := int(inputs[1].Int64())
+	nbMLimbs := int(inputs[2].Int64())
+	if len(inputs[3:]) != nbALimbs+nbMLimbs {
+		return fmt.Errorf("input length mismatch")
+	}
+	a, m := new(big.Int), new(big.Int)
+	if err := recompose(inputs[3:3+nbALimbs], nbBits, a); err != nil {
+		return fmt.Errorf("recompose a: %w", err)
+	}
+	if err := recompose(inputs[3+nbALimbs:], nbBits, m); err != nil {
+		return fmt.Errorf("recompose m: %w", err)
+	}
+	d := new(big.Int).Sub(m, a)
+	d.Sub(d, big.NewInt(1))
+	if d.Sign() < 0 {
+		return fmt.Errorf("value not less than modulus")
+	}
+	if err := decompose(d, nbBits, outputs); err != nil {
+		return fmt.Errorf("decompose: %w", err)
+	}
+	return nil
+}
+
+// subPaddingHint computes the correction term d for the padding used in the
+// variable-modulus subtraction. Given the limb width nbBits, overflow and
+// number of limbs nbLimbs, the padding n has all limbs 2^(nbBits+overflow).
+// The hint returns d = -n mod m, so that n+d is a multiple of the modulus m.
+func subPaddingHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 4 {
+		return fmt.Errorf("input must be at least four elements")
+	}
+	nbBits := uint(inputs[0].Uint64())
+	overflow := uint(inputs[1].Uint64())
+	nbLimbs := int(inputs[2].Int64())
+	nbMLimbs := int(inputs[3].Int64())
+	if len(inputs[4:]) != nbMLimbs {
+		return fmt.Errorf("input length mismatch")
+	}
+	m := new(big.Int)
+	if err := recompose(inputs[4:], nbBits, m); err != nil {
+		return fmt.Errorf("recompose modulus: %w", err)
+	}
+	if m.Sign() == 0 {
+		return fmt.Errorf("modulus is zero")
+	}
+	nLimbs := make([]*big.Int, nbLimbs)
+	for i := range nLimbs {
+		nLimbs[i] = new(big.Int).Lsh(big.NewInt(1), nbBits+overflow)
+	}
+	n := new(big.Int)
+	if err := recompose(nLimbs, nbBits, n); err != nil {
+		return fmt.Errorf("recompose padding: %w", err)
+	}
+	n.Neg(n).Mod(n, m)
+	if err := decompose(n, nbBits, outputs); err != nil {
+		return fmt.Errorf("decompose: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package fixedpoint
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/bitslice"
+)
+
+// PiecewiseLinear approximates a function using linear interpolation between
+// the values of the function at equidistant points stored in a lookup table.
+//
+// The function is approximated on the interval [lo, lo+2^logWidth), which is
+// split into 2^logSegments segments of equal width. For the inputs outside of
+// the interval the value at the closest end of the interval is returned. The
+// values of the function are saturated to the representable range.
+type PiecewiseLinear struct {
+	f *Field
+	// lo is the scaled lower end of the interval.
+	lo *big.Int
+	// widthBits is the number of bits of the scaled width of the interval.
+	widthBits int
+	// segBits is the number of bits of the scaled width of a segment.
+	segBits int
+	table   *logderivlookup.Table
+}
+
+// NewPiecewiseLinear returns a new piecewise-linear approximation of fn on the
+// interval [lo, lo+2^logWidth) with 2^logSegments segments. It panics if the
+// segments are narrower than the precision of the fixed-point numbers.
+//
+// The lookup table is created once and shared between the evaluations, so it
+// is more efficient to reuse the approximation.
+func (f *Field) NewPiecewiseLinear(fn func(float64) float64, lo float64, logWidth, logSegments int) *PiecewiseLinear {
+	fb := f.cfg.FracBits
+	widthBits := logWidth + fb
+	segBits := widthBits - logSegments
+	if widthBits < 0 || logSegments < 0 || segBits < 0 {
+		panic(fmt.Sprintf("segment width 2^%d smaller than precision 2^%d", logWidth-logSegments, -fb))
+	}
+	loF := new(big.Float).SetFloat64(math.Round(math.Ldexp(lo, fb)))
+	loS, _ := loF.Int(nil)
+	n := f.cfg.nbBits()
+	maxV := new(big.Int).Sub(pow2(n-1), big.NewInt(1))
+	minV := new(big.Int).Neg(pow2(n - 1))
+	mod := f.api.Compiler().Field()
+	table := logderivlookup.New(f.api)
+	for i := 0; i <= 1<<logSegments; i++ {
+		x := new(big.Int).Add(loS, new(big.Int).Lsh(big.NewInt(int64(i)), uint(segBits)))
+		xf, _ := new(big.Float).SetInt(x).Float64()
+		y := fn(math.Ldexp(xf, -fb))
+		var v *big.Int
+		switch {
+		case math.IsNaN(y):
+			panic(fmt.Sprintf("function not defined at %f", math.Ldexp(xf, -fb)))
+		case y >= math.Ldexp(1, f.cfg.IntBits):
+			v = maxV
+		case y < -math.Ldexp(1, f.cfg.IntBits):
+			v = minV
+		default:
+			v = toScaled(f.cfg, y)
+			if v.Cmp(maxV) > 0 {
+				v = maxV
+			}
+		}
+		table.Insert(new(big.Int).Mod(v, mod))
+	}
+	return &PiecewiseLinear{
+		f:         f,
+		lo:        loS,
+		widthBits: widthBits,
+		segBits:   segBits,
+		table:     table,
+	}
+}
+
+// Eval returns the approximation of the function at a.
+func (p *PiecewiseLinear) Eval(a Value) Value {
+	f := p.f
+	n := f.cfg.nbBits()
+	// u = a - lo with |u| < 2^bound
+	u := f.api.Sub(f.checked(a), p.lo)
+	bound := new(big.Int).Add(pow2(n-1), new(big.Int).Abs(p.lo)).BitLen()
+	if bound < p.widthBits+1 {
+		bound = p.widthBits + 1
+	}
+	// clamp u to [0, 2^widthBits)
+	isBelow := f.isNegative(u, bound)
+	isAbove := f.api.Sub(1, f.isNegative(f.api.Sub(u, pow2(p.widthBits)), bound+1))
+	maxU := new(big.Int).Sub(pow2(p.widthBits), big.NewInt(1))
+	u = f.api.Select(isBelow, 0, f.api.Select(isAbove, maxU, u))
+	// the segment index and the offset in the segment
+	offset, idx := bitslice.Partition(f.api, u, uint(p.segBits), bitslice.WithNbDigits(p.widthBits))
+	vals := p.table.Lookup(idx, f.api.Add(idx, 1))
+	if p.segBits == 0 {
+		return Value{Val: vals[0], internal: true}
+	}
+	// interpolate between the values at the ends of the segment. The result
+	// is between the values, so it is representable.
+	delta := f.api.Mul(f.api.Sub(vals[1], vals[0]), offset)
+	res := f.api.Add(vals[0], f.rshift(delta, p.segBits, n+p.segBits, true))
+	return Value{Val: res, internal: true}
+}
+
+// Sigmoid returns the approximation of the logistic function 1/(1+e^-a). The
+// function is approximated with 64 segments on the interval [-8, 8), or on
+// [-2^i, 2^i) if the integer part of the numbers has i < 3 bits.
+func (f *Field) Sigmoid(a Value) Value {
+	if f.sigmoid == nil {
+		e := f.cfg.IntBits
+		if e > 3 {
+			e = 3
+		}
+		logSegments := 6
+		if logSegments > e+1+f.cfg.FracBits {
+			logSegments = e + 1 + f.cfg.FracBits
+		}
+		sigmoid := func(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
+		f.sigmoid = f.NewPiecewiseLinear(sigmoid, -math.Ldexp(1, e), e+1, logSegments)
+	}
+	return f.sigmoid.Eval(a)
+}
+
+// Exp returns the approximation of the exponential function e^a. The function
+// is approximated with 256 segments on the interval ending at i*ln(2), where i
+// is the number of bits of the integer part. The interval is wide enough that
+// the values below it round to zero and the values above it are saturated to
+// the largest representable value.
+func (f *Field) Exp(a Value) Value {
+	if f.exp == nil {
+		hi := float64(f.cfg.IntBits) * math.Ln2
+		// e^x rounds to zero for x < -(f+1)*ln(2)
+		logWidth := int(math.Ceil(math.Log2(float64(f.cfg.IntBits+f.cfg.FracBits+1) * math.Ln2)))
+		logSegments := 8
+		if logSegments > logWidth+f.cfg.FracBits {
+			logSegments = logWidth + f.cfg.FracBits
+		}
+		f.exp = f.NewPiecewiseLinear(math.Exp, hi-math.Ldexp(1, logWidth), logWidth, logSegments)
+	}
+	return f.exp.Eval(a)
+}
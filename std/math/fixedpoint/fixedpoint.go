@@ -0,0 +1,291 @@
+// Package fixedpoint implements signed fixed-point arithmetic.
+//
+// A fixed-point number x with f fractional bits is represented by the signed
+// integer X = round(x * 2^f), which is stored as a native element (negative
+// values are stored as their additive inverses in the native field). The
+// integer part has i bits excluding the sign, so the representable values are
+// in the range [-2^i, 2^i) with precision 2^-f. Both i and f are configured
+// using [Config].
+//
+// All operations which may overflow the representable range assert that the
+// result fits, i.e. the circuit is not satisfiable if an overflow occurs. The
+// results of multiplication and division are rounded to the nearest
+// representable value.
+//
+// The non-linear functions (sigmoid, exponentiation) are approximated using
+// piecewise-linear interpolation over lookup tables, see [PiecewiseLinear].
+package fixedpoint
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bitslice"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in this package. This method is
+// useful for registering all hints in the solver.
+func GetHints() []solver.Hint {
+	return []solver.Hint{divHint}
+}
+
+// Config defines the format of the fixed-point numbers.
+type Config struct {
+	// IntBits is the number of bits of the integer part, excluding the sign.
+	IntBits int
+	// FracBits is the number of bits of the fractional part.
+	FracBits int
+}
+
+// nbBits returns the number of bits of the signed integer representation.
+func (c Config) nbBits() int {
+	return c.IntBits + c.FracBits + 1
+}
+
+// Value is a fixed-point number. Outside of the package the value should be
+// assigned using [ValueOf].
+type Value struct {
+	// Val is the scaled signed integer representation of the number.
+	Val frontend.Variable
+
+	// internal indicates that Val is already range checked.
+	internal bool
+}
+
+// ValueOf returns the fixed-point representation of v in the format cfg for
+// witness assignment. The value is rounded to the nearest representable value.
+// It panics if v is not representable.
+func ValueOf(cfg Config, v float64) Value {
+	s := toScaled(cfg, v)
+	if s.IsInt64() {
+		// the negative values are reduced when assigning int64.
+		return Value{Val: s.Int64()}
+	}
+	return Value{Val: s}
+}
+
+// toScaled returns round(v * 2^f). It panics if the result is not
+// representable.
+func toScaled(cfg Config, v float64) *big.Int {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		panic("value not finite")
+	}
+	bf := new(big.Float).SetFloat64(math.Round(math.Ldexp(v, cfg.FracBits)))
+	res, _ := bf.Int(nil)
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(cfg.nbBits()-1))
+	if res.CmpAbs(bound) > 0 || res.Cmp(bound) == 0 {
+		panic(fmt.Sprintf("value %f not representable", v))
+	}
+	return res
+}
+
+// Field provides arithmetic over fixed-point numbers in a given format.
+type Field struct {
+	api      frontend.API
+	cfg      Config
+	rchecker frontend.Rangechecker
+
+	// sigmoid and exp are the approximations initialized on first use.
+	sigmoid, exp *PiecewiseLinear
+}
+
+// New returns a new [Field] for fixed-point numbers in the format cfg. It
+// returns an error if the format is invalid or the native field is too small
+// for the products of the numbers.
+func New(api frontend.API, cfg Config) (*Field, error) {
+	if cfg.IntBits < 0 || cfg.FracBits < 0 {
+		return nil, fmt.Errorf("negative number of bits")
+	}
+	if api.Compiler().FieldBitLen() <= 2*cfg.nbBits()+2 {
+		return nil, fmt.Errorf("native field too small for %d-bit fixed-point numbers", cfg.nbBits())
+	}
+	return &Field{
+		api:      api,
+		cfg:      cfg,
+		rchecker: rangecheck.New(api),
+	}, nil
+}
+
+// pow2 returns 2^n.
+func pow2(n int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(n))
+}
+
+// Constant returns the constant fixed-point number v. It panics if v is not
+// representable.
+func (f *Field) Constant(v float64) Value {
+	return Value{Val: toScaled(f.cfg, v), internal: true}
+}
+
+// FromScaled returns the fixed-point number given by the scaled integer v. It
+// asserts that v is representable.
+func (f *Field) FromScaled(v frontend.Variable) Value {
+	f.assertInRange(v)
+	return Value{Val: v, internal: true}
+}
+
+// FromInt returns the fixed-point number of the integer v. It asserts that v is
+// representable.
+func (f *Field) FromInt(v frontend.Variable) Value {
+	return f.FromScaled(f.api.Mul(v, pow2(f.cfg.FracBits)))
+}
+
+// checked returns the scaled integer of a. If the value is not internal, then
+// it is range checked.
+func (f *Field) checked(a Value) frontend.Variable {
+	if !a.internal {
+		f.assertInRange(a.Val)
+	}
+	return a.Val
+}
+
+// assertInRange asserts that v is in [-2^(n-1), 2^(n-1)).
+func (f *Field) assertInRange(v frontend.Variable) {
+	n := f.cfg.nbBits()
+	f.rchecker.Check(f.api.Add(v, pow2(n-1)), n)
+}
+
+// isNegative returns 1 if v < 0 and 0 otherwise. It assumes |v| < 2^bound.
+func (f *Field) isNegative(v frontend.Variable, bound int) frontend.Variable {
+	_, nonNeg := bitslice.Partition(f.api, f.api.Add(v, pow2(bound)), uint(bound), bitslice.WithNbDigits(bound+1))
+	return f.api.Sub(1, nonNeg)
+}
+
+// rshift returns v/2^shift rounded to the nearest integer if round is set and
+// rounded down otherwise. It assumes |v| < 2^bound and bound >= shift.
+func (f *Field) rshift(v frontend.Variable, shift, bound int, round bool) frontend.Variable {
+	if shift == 0 {
+		return v
+	}
+	// shift v to non-negative. The offset is divisible by 2^shift, so it
+	// doesn't change the lower bits.
+	w := f.api.Add(v, pow2(bound))
+	if round {
+		w = f.api.Add(w, pow2(shift-1))
+	}
+	_, upper := bitslice.Partition(f.api, w, uint(shift), bitslice.WithNbDigits(bound+2))
+	return f.api.Sub(upper, pow2(bound-shift))
+}
+
+// Add returns a+b. It asserts that the sum is representable.
+func (f *Field) Add(a, b Value) Value {
+	return f.FromScaled(f.api.Add(f.checked(a), f.checked(b)))
+}
+
+// Sub returns a-b. It asserts that the difference is representable.
+func (f *Field) Sub(a, b Value) Value {
+	return f.FromScaled(f.api.Sub(f.checked(a), f.checked(b)))
+}
+
+// Neg returns -a. It asserts that the negation is representable.
+func (f *Field) Neg(a Value) Value {
+	return f.FromScaled(f.api.Neg(f.checked(a)))
+}
+
+// Mul returns a*b rounded to the nearest representable value. It asserts that
+// the product is representable.
+func (f *Field) Mul(a, b Value) Value {
+	prod := f.api.Mul(f.checked(a), f.checked(b))
+	// |a*b| <= 2^(2n-2)
+	return f.FromScaled(f.rshift(prod, f.cfg.FracBits, 2*f.cfg.nbBits()-1, true))
+}
+
+// Div returns a/b rounded to the nearest representable value. It asserts that
+// b is not zero and that the quotient is representable.
+func (f *Field) Div(a, b Value) Value {
+	n := f.cfg.nbBits()
+	num := f.api.Mul(f.checked(a), pow2(f.cfg.FracBits))
+	bv := f.checked(b)
+	res, err := f.api.Compiler().NewHint(divHint, 1, num, bv)
+	if err != nil {
+		panic(fmt.Sprintf("div hint: %v", err))
+	}
+	q := f.FromScaled(res[0])
+	// we check that the remainder r = num - q*b satisfies -|b| <= 2r < |b|,
+	// which also implies that b is not zero.
+	absB := f.api.Select(f.isNegative(bv, n), f.api.Neg(bv), bv)
+	r2 := f.api.Mul(f.api.Sub(num, f.api.Mul(q.Val, bv)), 2)
+	f.rchecker.Check(f.api.Add(r2, absB), n+1)
+	f.rchecker.Check(f.api.Sub(f.api.Sub(absB, r2), 1), n+1)
+	return q
+}
+
+// Floor returns the largest integer not greater than a.
+func (f *Field) Floor(a Value) Value {
+	fb := f.cfg.FracBits
+	v := f.rshift(f.checked(a), fb, f.cfg.nbBits(), false)
+	return Value{Val: f.api.Mul(v, pow2(fb)), internal: true}
+}
+
+// Round returns a rounded to the nearest integer, rounding half up. It asserts
+// that the result is representable.
+func (f *Field) Round(a Value) Value {
+	fb := f.cfg.FracBits
+	v := f.rshift(f.checked(a), fb, f.cfg.nbBits(), true)
+	return f.FromInt(v)
+}
+
+// ToInt returns the integer part of a as a native element, rounding down.
+// The negative values are returned as their additive inverses in the native
+// field.
+func (f *Field) ToInt(a Value) frontend.Variable {
+	return f.rshift(f.checked(a), f.cfg.FracBits, f.cfg.nbBits(), false)
+}
+
+// IsNegative returns 1 if a < 0 and 0 otherwise.
+func (f *Field) IsNegative(a Value) frontend.Variable {
+	return f.isNegative(f.checked(a), f.cfg.nbBits()-1)
+}
+
+// Lt returns 1 if a < b and 0 otherwise.
+func (f *Field) Lt(a, b Value) frontend.Variable {
+	return f.isNegative(f.api.Sub(f.checked(a), f.checked(b)), f.cfg.nbBits())
+}
+
+// Le returns 1 if a <= b and 0 otherwise.
+func (f *Field) Le(a, b Value) frontend.Variable {
+	return f.api.Sub(1, f.Lt(b, a))
+}
+
+// Gt returns 1 if a > b and 0 otherwise.
+func (f *Field) Gt(a, b Value) frontend.Variable {
+	return f.Lt(b, a)
+}
+
+// Ge returns 1 if a >= b and 0 otherwise.
+func (f *Field) Ge(a, b Value) frontend.Variable {
+	return f.api.Sub(1, f.Lt(a, b))
+}
+
+// Select returns a if sel is 1 and b otherwise. sel must be boolean.
+func (f *Field) Select(sel frontend.Variable, a, b Value) Value {
+	return Value{Val: f.api.Select(sel, f.checked(a), f.checked(b)), internal: true}
+}
+
+// Max returns the larger of a and b.
+func (f *Field) Max(a, b Value) Value {
+	return f.Select(f.Lt(a, b), b, a)
+}
+
+// Min returns the smaller of a and b.
+func (f *Field) Min(a, b Value) Value {
+	return f.Select(f.Lt(a, b), a, b)
+}
+
+// ReLU returns max(a, 0).
+func (f *Field) ReLU(a Value) Value {
+	return f.Select(f.IsNegative(a), Value{Val: 0, internal: true}, a)
+}
+
+// AssertIsEqual asserts that a == b.
+func (f *Field) AssertIsEqual(a, b Value) {
+	f.api.AssertIsEqual(f.checked(a), f.checked(b))
+}
@@ -0,0 +1,167 @@
+package fixedpoint
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+var testConfig = Config{IntBits: 8, FracBits: 16}
+
+type arithCircuit struct {
+	A, B                 Value
+	Sum, Diff, Prod, Quo Value
+	Lt, Le               frontend.Variable
+	ReLU, Floor, Round   Value
+	Int                  frontend.Variable
+}
+
+func (c *arithCircuit) Define(api frontend.API) error {
+	f, err := New(api, testConfig)
+	if err != nil {
+		return err
+	}
+	f.AssertIsEqual(f.Add(c.A, c.B), c.Sum)
+	f.AssertIsEqual(f.Sub(c.A, c.B), c.Diff)
+	f.AssertIsEqual(f.Mul(c.A, c.B), c.Prod)
+	f.AssertIsEqual(f.Div(c.A, c.B), c.Quo)
+	api.AssertIsEqual(f.Lt(c.A, c.B), c.Lt)
+	api.AssertIsEqual(f.Le(c.A, c.B), c.Le)
+	f.AssertIsEqual(f.ReLU(c.A), c.ReLU)
+	f.AssertIsEqual(f.Floor(c.A), c.Floor)
+	f.AssertIsEqual(f.Round(c.A), c.Round)
+	api.AssertIsEqual(f.ToInt(c.A), c.Int)
+	return nil
+}
+
+// roundDiv returns num/den rounded to the nearest integer, rounding half up.
+func roundDiv(num, den *big.Int) *big.Int {
+	if den.Sign() < 0 {
+		num, den = new(big.Int).Neg(num), new(big.Int).Neg(den)
+	}
+	q := new(big.Int).Lsh(num, 1)
+	q.Add(q, den)
+	return q.Div(q, new(big.Int).Lsh(den, 1))
+}
+
+// val returns the value for the scaled integer v.
+func val(v *big.Int) Value {
+	return Value{Val: v.Int64()}
+}
+
+func arithAssignment(a, b float64) *arithCircuit {
+	fb := testConfig.FracBits
+	one := new(big.Int).Lsh(big.NewInt(1), uint(fb))
+	as, bs := toScaled(testConfig, a), toScaled(testConfig, b)
+	boolToInt := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	floor := new(big.Int).Div(as, one)
+	relu := new(big.Int)
+	if as.Sign() > 0 {
+		relu.Set(as)
+	}
+	return &arithCircuit{
+		A:     val(as),
+		B:     val(bs),
+		Sum:   val(new(big.Int).Add(as, bs)),
+		Diff:  val(new(big.Int).Sub(as, bs)),
+		Prod:  val(roundDiv(new(big.Int).Mul(as, bs), one)),
+		Quo:   val(roundDiv(new(big.Int).Mul(as, one), bs)),
+		Lt:    boolToInt(as.Cmp(bs) < 0),
+		Le:    boolToInt(as.Cmp(bs) <= 0),
+		ReLU:  val(relu),
+		Floor: val(new(big.Int).Mul(floor, one)),
+		Round: val(new(big.Int).Mul(roundDiv(as, one), one)),
+		Int:   floor.Int64(),
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	assert := test.NewAssert(t)
+	cases := [][2]float64{
+		{1.5, 2.25},
+		{-1.5, 2.25},
+		{-3.1415, -2.7182},
+		{10.0001, 0.3},
+		{0, -7.5},
+		{-0.5, 1},
+		{100.75, -0.625},
+		{0.00003, 3},
+	}
+	for i, c := range cases {
+		c := c
+		assert.Run(func(assert *test.Assert) {
+			err := test.IsSolved(&arithCircuit{}, arithAssignment(c[0], c[1]), ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	assert := test.NewAssert(t)
+	// product overflows
+	w := arithAssignment(100, 10)
+	err := test.IsSolved(&arithCircuit{}, w, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// division by zero
+	w = arithAssignment(1, 2)
+	w.B = ValueOf(testConfig, 0)
+	err = test.IsSolved(&arithCircuit{}, w, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// value not in range
+	w = arithAssignment(1, 2)
+	w.A = Value{Val: 1 << 24}
+	err = test.IsSolved(&arithCircuit{}, w, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type approxCircuit struct {
+	X                  Value
+	Sigmoid, Exp       Value
+	SigmoidTol, ExpTol float64 `gnark:"-"`
+}
+
+func (c *approxCircuit) Define(api frontend.API) error {
+	f, err := New(api, testConfig)
+	if err != nil {
+		return err
+	}
+	assertClose := func(a, b Value, tol float64) {
+		diff := f.Sub(a, b)
+		api.AssertIsEqual(f.Le(f.Max(diff, f.Neg(diff)), f.Constant(tol)), 1)
+	}
+	assertClose(f.Sigmoid(c.X), c.Sigmoid, c.SigmoidTol)
+	assertClose(f.Exp(c.X), c.Exp, c.ExpTol)
+	return nil
+}
+
+func TestApproximations(t *testing.T) {
+	assert := test.NewAssert(t)
+	maxValue := math.Ldexp(1, testConfig.IntBits) - math.Ldexp(1, -testConfig.FracBits)
+	for _, x := range []float64{-20, -8.5, -3.3, -1, -0.1, 0, 0.25, 1, 2.5, 4.9, 7.99, 30} {
+		x := x
+		assert.Run(func(assert *test.Assert) {
+			sigmoid := 1 / (1 + math.Exp(-x))
+			exp := math.Min(math.Exp(x), maxValue)
+			circuit := approxCircuit{SigmoidTol: 0.005, ExpTol: 0.02 * math.Max(exp, 1)}
+			witness := approxCircuit{
+				X:          ValueOf(testConfig, x),
+				SigmoidTol: circuit.SigmoidTol,
+				ExpTol:     circuit.ExpTol,
+				Sigmoid:    ValueOf(testConfig, sigmoid),
+				Exp:        ValueOf(testConfig, exp),
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("x=%f", x))
+	}
+}
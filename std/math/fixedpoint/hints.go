@@ -0,0 +1,42 @@
+package fixedpoint
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// toSigned returns v as a signed integer, where the values larger than half of
+// the modulus are considered negative.
+func toSigned(mod, v *big.Int) *big.Int {
+	half := new(big.Int).Rsh(mod, 1)
+	if v.Cmp(half) > 0 {
+		return new(big.Int).Sub(v, mod)
+	}
+	return new(big.Int).Set(v)
+}
+
+// divHint computes the quotient of the signed integers num and den rounded to
+// the nearest integer. If den is zero, then the quotient is zero.
+func divHint(mod *big.Int, inputs, outputs []*big.Int) error {
+	if len(inputs) != 2 || len(outputs) != 1 {
+		return fmt.Errorf("expecting 2 inputs and 1 output")
+	}
+	num := toSigned(mod, inputs[0])
+	den := toSigned(mod, inputs[1])
+	if den.Sign() == 0 {
+		outputs[0].SetUint64(0)
+		return nil
+	}
+	// q = sign(den) * floor((2*num + |den|) / (2*|den|))
+	absDen := new(big.Int).Abs(den)
+	q := new(big.Int).Lsh(num, 1)
+	q.Add(q, absDen)
+	// Div is Euclidean division, which equals to floor as the divisor is
+	// positive.
+	q.Div(q, new(big.Int).Lsh(absDen, 1))
+	if den.Sign() < 0 {
+		q.Neg(q)
+	}
+	outputs[0].Mod(q, mod)
+	return nil
+}
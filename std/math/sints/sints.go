@@ -0,0 +1,300 @@
+// Package sints implements fixed-width signed integer arithmetic.
+//
+// The integers are stored in two's complement representation, i.e. an n-bit
+// integer x is stored as a native element in the range [0, 2^n), where the
+// negative values -2^(n-1) <= x < 0 are stored as 2^n + x. This matches the
+// representation of signed integers in most instruction set architectures
+// (WASM, RISC-V) and allows to reinterpret the values as unsigned integers
+// without any constraints.
+//
+// The operations wrap around modulo 2^n as in the instruction set
+// architectures. Additionally, for addition, subtraction and multiplication we
+// provide methods which also return an overflow flag.
+//
+// The width of the integers is given by the type parameter of [Field], which
+// is one of [W8], [W16], [W32] or [W64].
+package sints
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bitslice"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// Width defines the bit-width of the signed integers.
+type Width interface {
+	NbBits() int
+}
+
+// W8 is the width of 8-bit signed integers.
+type W8 struct{}
+
+// W16 is the width of 16-bit signed integers.
+type W16 struct{}
+
+// W32 is the width of 32-bit signed integers.
+type W32 struct{}
+
+// W64 is the width of 64-bit signed integers.
+type W64 struct{}
+
+func (W8) NbBits() int  { return 8 }
+func (W16) NbBits() int { return 16 }
+func (W32) NbBits() int { return 32 }
+func (W64) NbBits() int { return 64 }
+
+// Int is a signed integer of width W in two's complement representation.
+// Outside of the package the value should be assigned using the constructors
+// [NewI8], [NewI16], [NewI32] or [NewI64].
+type Int[W Width] struct {
+	// Val is the two's complement representation of the integer.
+	Val frontend.Variable
+
+	// internal indicates that Val is already range checked.
+	internal bool
+}
+
+// I8 is a 8-bit signed integer.
+type I8 = Int[W8]
+
+// I16 is a 16-bit signed integer.
+type I16 = Int[W16]
+
+// I32 is a 32-bit signed integer.
+type I32 = Int[W32]
+
+// I64 is a 64-bit signed integer.
+type I64 = Int[W64]
+
+// NewI8 returns a constant 8-bit signed integer.
+func NewI8(v int8) I8 { return I8{Val: uint8(v), internal: true} }
+
+// NewI16 returns a constant 16-bit signed integer.
+func NewI16(v int16) I16 { return I16{Val: uint16(v), internal: true} }
+
+// NewI32 returns a constant 32-bit signed integer.
+func NewI32(v int32) I32 { return I32{Val: uint32(v), internal: true} }
+
+// NewI64 returns a constant 64-bit signed integer.
+func NewI64(v int64) I64 { return I64{Val: uint64(v), internal: true} }
+
+// Field provides arithmetic over signed integers of width W.
+type Field[W Width] struct {
+	api      frontend.API
+	rchecker frontend.Rangechecker
+	nbBits   int
+}
+
+// New returns a new [Field] for signed integers of width W. It returns an
+// error if the native field is too small to contain the product of two
+// integers.
+func New[W Width](api frontend.API) (*Field[W], error) {
+	var w W
+	nbBits := w.NbBits()
+	if api.Compiler().FieldBitLen() <= 2*nbBits+1 {
+		return nil, fmt.Errorf("native field too small for %d-bit integers", nbBits)
+	}
+	return &Field[W]{
+		api:      api,
+		rchecker: rangecheck.New(api),
+		nbBits:   nbBits,
+	}, nil
+}
+
+// checked returns the two's complement representation of a. If the value is
+// not internal, then it is range checked.
+func (f *Field[W]) checked(a Int[W]) frontend.Variable {
+	if !a.internal {
+		f.rchecker.Check(a.Val, f.nbBits)
+	}
+	return a.Val
+}
+
+// pow2 returns 2^n.
+func pow2(n int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(n))
+}
+
+// sign returns the sign bit of a.
+func (f *Field[W]) sign(a Int[W]) frontend.Variable {
+	_, msb := bitslice.Partition(f.api, f.checked(a), uint(f.nbBits-1), bitslice.WithNbDigits(f.nbBits))
+	return msb
+}
+
+// wrap reduces v modulo 2^n, where v has at most nbDigits bits.
+func (f *Field[W]) wrap(v frontend.Variable, nbDigits int) Int[W] {
+	lower, _ := bitslice.Partition(f.api, v, uint(f.nbBits), bitslice.WithNbDigits(nbDigits))
+	return Int[W]{Val: lower, internal: true}
+}
+
+// ValueOf returns the signed integer of the native element v. The native
+// element is interpreted as signed, i.e. the values in the range (p/2, p) are
+// negative. The method asserts that v fits in W.
+func (f *Field[W]) ValueOf(v frontend.Variable) Int[W] {
+	// shift the range [-2^(n-1), 2^(n-1)) to [0, 2^n), which also flips the
+	// sign bit.
+	shifted := f.api.Add(v, pow2(f.nbBits-1))
+	f.rchecker.Check(shifted, f.nbBits)
+	lower, msb := bitslice.Partition(f.api, shifted, uint(f.nbBits-1), bitslice.WithNbDigits(f.nbBits))
+	return Int[W]{
+		Val:      f.api.Add(lower, f.api.Mul(pow2(f.nbBits-1), f.api.Sub(1, msb))),
+		internal: true,
+	}
+}
+
+// ToValue returns a as a native element. The negative values are returned as
+// their additive inverses in the native field.
+func (f *Field[W]) ToValue(a Int[W]) frontend.Variable {
+	return f.api.Sub(f.checked(a), f.api.Mul(f.sign(a), pow2(f.nbBits)))
+}
+
+// FromUnsigned reinterprets the unsigned n-bit integer v as a signed integer.
+// It asserts that v is in the range [0, 2^n).
+func (f *Field[W]) FromUnsigned(v frontend.Variable) Int[W] {
+	f.rchecker.Check(v, f.nbBits)
+	return Int[W]{Val: v, internal: true}
+}
+
+// Add returns a+b modulo 2^n.
+func (f *Field[W]) Add(a, b Int[W]) Int[W] {
+	res, _ := f.AddWithOverflow(a, b)
+	return res
+}
+
+// AddWithOverflow returns a+b modulo 2^n and the overflow flag, which is 1 if
+// the sum doesn't fit in W and 0 otherwise.
+func (f *Field[W]) AddWithOverflow(a, b Int[W]) (Int[W], frontend.Variable) {
+	res := f.wrap(f.api.Add(f.checked(a), f.checked(b)), f.nbBits+1)
+	// the sum overflows iff the signs of the inputs are equal and differ from
+	// the sign of the result.
+	sa, sb, sr := f.sign(a), f.sign(b), f.sign(res)
+	overflow := f.api.And(f.api.Sub(1, f.api.Xor(sa, sb)), f.api.Xor(sa, sr))
+	return res, overflow
+}
+
+// Sub returns a-b modulo 2^n.
+func (f *Field[W]) Sub(a, b Int[W]) Int[W] {
+	res, _ := f.SubWithOverflow(a, b)
+	return res
+}
+
+// SubWithOverflow returns a-b modulo 2^n and the overflow flag, which is 1 if
+// the difference doesn't fit in W and 0 otherwise.
+func (f *Field[W]) SubWithOverflow(a, b Int[W]) (Int[W], frontend.Variable) {
+	diff := f.api.Add(f.api.Sub(f.checked(a), f.checked(b)), pow2(f.nbBits))
+	res := f.wrap(diff, f.nbBits+1)
+	// the difference overflows iff the signs of the inputs differ and the sign
+	// of the result differs from the sign of a.
+	sa, sb, sr := f.sign(a), f.sign(b), f.sign(res)
+	overflow := f.api.And(f.api.Xor(sa, sb), f.api.Xor(sa, sr))
+	return res, overflow
+}
+
+// Neg returns -a modulo 2^n. The negation of -2^(n-1) is -2^(n-1).
+func (f *Field[W]) Neg(a Int[W]) Int[W] {
+	return f.Sub(Int[W]{Val: 0, internal: true}, a)
+}
+
+// Mul returns a*b modulo 2^n.
+func (f *Field[W]) Mul(a, b Int[W]) Int[W] {
+	res, _ := f.MulWithOverflow(a, b)
+	return res
+}
+
+// MulWithOverflow returns a*b modulo 2^n and the overflow flag, which is 1 if
+// the product doesn't fit in W and 0 otherwise.
+func (f *Field[W]) MulWithOverflow(a, b Int[W]) (Int[W], frontend.Variable) {
+	n := f.nbBits
+	// the signed product is in the range [-2^(2n-2)+2^(n-1), 2^(2n-2)], so
+	// after shifting by 2^(2n-1) it is non-negative and fits in 2n bits. As
+	// 2^n divides the shift, the lower n bits are the wrapped product.
+	prod := f.api.Mul(f.ToValue(a), f.ToValue(b))
+	prod = f.api.Add(prod, pow2(2*n-1))
+	lower, upper := bitslice.Partition(f.api, prod, uint(n), bitslice.WithNbDigits(2*n))
+	res := Int[W]{Val: lower, internal: true}
+	// the product fits in W iff the upper bits are the sign extension of the
+	// result. Accounting for the shift, it means upper = 2^(n-1) - sign(res).
+	fits := f.api.IsZero(f.api.Sub(f.api.Add(upper, f.sign(res)), pow2(n-1)))
+	return res, f.api.Sub(1, fits)
+}
+
+// Rshift returns a arithmetically shifted right by c bits, i.e. the sign bit
+// is shifted in from the left. It panics if c is negative.
+func (f *Field[W]) Rshift(a Int[W], c int) Int[W] {
+	if c < 0 {
+		panic("negative shift")
+	}
+	n := f.nbBits
+	if c >= n {
+		c = n - 1
+	}
+	if c == 0 {
+		return Int[W]{Val: f.checked(a), internal: true}
+	}
+	_, upper := bitslice.Partition(f.api, f.checked(a), uint(c), bitslice.WithNbDigits(n))
+	// fill the top c bits with the sign bit.
+	fill := new(big.Int).Sub(pow2(n), pow2(n-c))
+	return Int[W]{
+		Val:      f.api.Add(upper, f.api.Mul(f.sign(a), fill)),
+		internal: true,
+	}
+}
+
+// Lshift returns a shifted left by c bits modulo 2^n. It panics if c is
+// negative.
+func (f *Field[W]) Lshift(a Int[W], c int) Int[W] {
+	if c < 0 {
+		panic("negative shift")
+	}
+	if c >= f.nbBits {
+		return Int[W]{Val: 0, internal: true}
+	}
+	return f.wrap(f.api.Mul(f.checked(a), pow2(c)), f.nbBits+c)
+}
+
+// Lt returns 1 if a < b and 0 otherwise.
+func (f *Field[W]) Lt(a, b Int[W]) frontend.Variable {
+	// ToValue(a) - ToValue(b) + 2^n is in the range (0, 2^(n+1)) and the bit n
+	// is set iff a >= b.
+	diff := f.api.Add(f.api.Sub(f.ToValue(a), f.ToValue(b)), pow2(f.nbBits))
+	_, geq := bitslice.Partition(f.api, diff, uint(f.nbBits), bitslice.WithNbDigits(f.nbBits+1))
+	return f.api.Sub(1, geq)
+}
+
+// Le returns 1 if a <= b and 0 otherwise.
+func (f *Field[W]) Le(a, b Int[W]) frontend.Variable {
+	return f.api.Sub(1, f.Lt(b, a))
+}
+
+// Gt returns 1 if a > b and 0 otherwise.
+func (f *Field[W]) Gt(a, b Int[W]) frontend.Variable {
+	return f.Lt(b, a)
+}
+
+// Ge returns 1 if a >= b and 0 otherwise.
+func (f *Field[W]) Ge(a, b Int[W]) frontend.Variable {
+	return f.api.Sub(1, f.Lt(a, b))
+}
+
+// IsNegative returns 1 if a < 0 and 0 otherwise.
+func (f *Field[W]) IsNegative(a Int[W]) frontend.Variable {
+	return f.sign(a)
+}
+
+// IsZero returns 1 if a == 0 and 0 otherwise.
+func (f *Field[W]) IsZero(a Int[W]) frontend.Variable {
+	return f.api.IsZero(f.checked(a))
+}
+
+// Select returns a if sel is 1 and b otherwise. sel must be boolean.
+func (f *Field[W]) Select(sel frontend.Variable, a, b Int[W]) Int[W] {
+	return Int[W]{Val: f.api.Select(sel, f.checked(a), f.checked(b)), internal: true}
+}
+
+// AssertIsEqual asserts that a == b.
+func (f *Field[W]) AssertIsEqual(a, b Int[W]) {
+	f.api.AssertIsEqual(f.checked(a), f.checked(b))
+}
@@ -0,0 +1,157 @@
+package sints
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type arithCircuit[W Width] struct {
+	A, B                     Int[W]
+	Sum, Diff, Prod          Int[W]
+	SumOvf, DiffOvf, ProdOvf frontend.Variable
+	Lt, Le, Gt, Ge           frontend.Variable
+	ValA                     frontend.Variable
+	Shift                    int
+	Rshift, Lshift           Int[W]
+}
+
+func (c *arithCircuit[W]) Define(api frontend.API) error {
+	f, err := New[W](api)
+	if err != nil {
+		return err
+	}
+	sum, sumOvf := f.AddWithOverflow(c.A, c.B)
+	f.AssertIsEqual(sum, c.Sum)
+	api.AssertIsEqual(sumOvf, c.SumOvf)
+	diff, diffOvf := f.SubWithOverflow(c.A, c.B)
+	f.AssertIsEqual(diff, c.Diff)
+	api.AssertIsEqual(diffOvf, c.DiffOvf)
+	prod, prodOvf := f.MulWithOverflow(c.A, c.B)
+	f.AssertIsEqual(prod, c.Prod)
+	api.AssertIsEqual(prodOvf, c.ProdOvf)
+	api.AssertIsEqual(f.Lt(c.A, c.B), c.Lt)
+	api.AssertIsEqual(f.Le(c.A, c.B), c.Le)
+	api.AssertIsEqual(f.Gt(c.A, c.B), c.Gt)
+	api.AssertIsEqual(f.Ge(c.A, c.B), c.Ge)
+	api.AssertIsEqual(f.ToValue(c.A), c.ValA)
+	f.AssertIsEqual(f.ValueOf(c.ValA), c.A)
+	f.AssertIsEqual(f.Rshift(c.A, c.Shift), c.Rshift)
+	f.AssertIsEqual(f.Lshift(c.A, c.Shift), c.Lshift)
+	return nil
+}
+
+// assignment computes the expected results of the operations using big
+// integers. The integers are wrapped to n bits.
+func assignment[W Width](a, b int64, shift int) *arithCircuit[W] {
+	var w W
+	n := w.NbBits()
+	mod := new(big.Int).Lsh(big.NewInt(1), uint(n))
+	half := new(big.Int).Rsh(mod, 1)
+	// wrap returns the two's complement representation and whether v doesn't
+	// fit in n bits.
+	wrap := func(v *big.Int) (Int[W], int) {
+		ovf := 0
+		if v.Cmp(half) >= 0 || v.Cmp(new(big.Int).Neg(half)) < 0 {
+			ovf = 1
+		}
+		return Int[W]{Val: new(big.Int).Mod(v, mod)}, ovf
+	}
+	boolToInt := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	ba, bb := big.NewInt(a), big.NewInt(b)
+	res := &arithCircuit[W]{Shift: shift, ValA: a}
+	res.A, _ = wrap(ba)
+	res.B, _ = wrap(bb)
+	res.Sum, res.SumOvf = wrap(new(big.Int).Add(ba, bb))
+	res.Diff, res.DiffOvf = wrap(new(big.Int).Sub(ba, bb))
+	res.Prod, res.ProdOvf = wrap(new(big.Int).Mul(ba, bb))
+	res.Lt, res.Le = boolToInt(a < b), boolToInt(a <= b)
+	res.Gt, res.Ge = boolToInt(a > b), boolToInt(a >= b)
+	res.Rshift, _ = wrap(new(big.Int).Rsh(ba, uint(shift)))
+	res.Lshift, _ = wrap(new(big.Int).Lsh(ba, uint(shift)))
+	return res
+}
+
+func testArithmetic[W Width](assert *test.Assert, lo, hi int64) {
+	values := []int64{0, 1, -1, 2, -3, 100, -100, lo, hi, lo + 1, hi - 1, lo / 3, hi / 5}
+	for _, a := range values {
+		for _, b := range values {
+			for _, shift := range []int{0, 1, 7} {
+				err := test.IsSolved(&arithCircuit[W]{Shift: shift}, assignment[W](a, b, shift), ecc.BN254.ScalarField())
+				assert.NoError(err, fmt.Sprintf("a=%d b=%d shift=%d", a, b, shift))
+			}
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		testArithmetic[W8](assert, math.MinInt8, math.MaxInt8)
+	}, "i8")
+	assert.Run(func(assert *test.Assert) {
+		testArithmetic[W16](assert, math.MinInt16, math.MaxInt16)
+	}, "i16")
+	assert.Run(func(assert *test.Assert) {
+		testArithmetic[W32](assert, math.MinInt32, math.MaxInt32)
+	}, "i32")
+	assert.Run(func(assert *test.Assert) {
+		testArithmetic[W64](assert, math.MinInt64, math.MaxInt64)
+	}, "i64")
+}
+
+type rangeCircuit struct {
+	A I8
+}
+
+func (c *rangeCircuit) Define(api frontend.API) error {
+	f, err := New[W8](api)
+	if err != nil {
+		return err
+	}
+	f.AssertIsEqual(f.Neg(f.Neg(c.A)), c.A)
+	return nil
+}
+
+func TestRange(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&rangeCircuit{}, &rangeCircuit{A: NewI8(-128)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&rangeCircuit{}, &rangeCircuit{A: I8{Val: 256}}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type valueOfCircuit struct {
+	V frontend.Variable
+}
+
+func (c *valueOfCircuit) Define(api frontend.API) error {
+	f, err := New[W8](api)
+	if err != nil {
+		return err
+	}
+	f.ValueOf(c.V)
+	return nil
+}
+
+func TestValueOfOutOfRange(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, v := range []int{-129, 128, 1000} {
+		err := test.IsSolved(&valueOfCircuit{}, &valueOfCircuit{V: v}, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}
+	for _, v := range []int{-128, 127, 0} {
+		err := test.IsSolved(&valueOfCircuit{}, &valueOfCircuit{V: v}, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
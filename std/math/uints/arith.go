@@ -0,0 +1,321 @@
+package uints
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bitslice"
+)
+
+// For arithmetic operations we group the bytes into limbs of at most 8 bytes.
+// This allows to compute the products of the limbs and accumulate them in the
+// native field without overflowing it even for U256.
+
+// limbSize returns the number of bytes in a limb of T.
+func limbSize[T Long]() int {
+	var r T
+	if len(r) < 8 {
+		return len(r)
+	}
+	return 8
+}
+
+// nbLimbs returns the number of limbs of T.
+func nbLimbs[T Long]() int {
+	var r T
+	return len(r) / limbSize[T]()
+}
+
+// limb returns the k-th little-endian limb of a.
+func (bf *BinaryField[T]) limb(a T, k int) frontend.Variable {
+	ls := limbSize[T]()
+	return bf.recompose(bf.UnpackLSB(a)[k*ls : (k+1)*ls])
+}
+
+// fromLimbs decomposes the limbs into bytes. The limbs must be already
+// range-checked to the limb width.
+func (bf *BinaryField[T]) fromLimbs(limbs []frontend.Variable) T {
+	var r T
+	ls := limbSize[T]()
+	for k := range limbs {
+		bts := bf.bytesOf(limbs[k], ls)
+		for i := range bts {
+			r[k*ls+i] = bts[i]
+		}
+	}
+	return r
+}
+
+// propagate propagates the carries over the columns cols, where every column
+// has at most colBits bits. It returns the range-checked limbs and the final
+// carry.
+func (bf *BinaryField[T]) propagate(cols []frontend.Variable, colBits int) (limbs []frontend.Variable, carry frontend.Variable) {
+	ls := 8 * limbSize[T]()
+	limbs = make([]frontend.Variable, len(cols))
+	carry = 0
+	carryBits := 0
+	for k := range cols {
+		v := bf.api.Add(cols[k], carry)
+		nbBits := colBits + 1
+		if carryBits > colBits {
+			nbBits = carryBits + 1
+		}
+		limbs[k], carry = bitslice.Partition(bf.api, v, uint(ls), bitslice.WithNbDigits(nbBits))
+		carryBits = nbBits - ls
+	}
+	return limbs, carry
+}
+
+// AddWithCarry returns a+b modulo 2^(8*len(T)) and the carry bit.
+func (bf *BinaryField[T]) AddWithCarry(a, b T) (T, frontend.Variable) {
+	cols := make([]frontend.Variable, nbLimbs[T]())
+	for k := range cols {
+		cols[k] = bf.api.Add(bf.limb(a, k), bf.limb(b, k))
+	}
+	limbs, carry := bf.propagate(cols, 8*limbSize[T]()+1)
+	return bf.fromLimbs(limbs), carry
+}
+
+// SubWithBorrow returns a-b modulo 2^(8*len(T)) and the borrow bit, which is 1
+// if a < b and 0 otherwise.
+func (bf *BinaryField[T]) SubWithBorrow(a, b T) (T, frontend.Variable) {
+	limbs, borrow := bf.sub(a, b)
+	return bf.fromLimbs(limbs), borrow
+}
+
+// Sub returns a-b modulo 2^(8*len(T)).
+func (bf *BinaryField[T]) Sub(a, b T) T {
+	res, _ := bf.SubWithBorrow(a, b)
+	return res
+}
+
+// sub computes the limbs of a-b modulo 2^(8*len(T)) and the borrow bit. We
+// compute a + ^b + 1, where ^b is the complement of b, which carries only if
+// a >= b.
+func (bf *BinaryField[T]) sub(a, b T) (limbs []frontend.Variable, borrow frontend.Variable) {
+	ls := limbSize[T]()
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(8*ls))
+	mask.Sub(mask, big.NewInt(1))
+	cols := make([]frontend.Variable, nbLimbs[T]())
+	for k := range cols {
+		cols[k] = bf.api.Add(bf.limb(a, k), bf.api.Sub(mask, bf.limb(b, k)))
+	}
+	cols[0] = bf.api.Add(cols[0], 1)
+	limbs, carry := bf.propagate(cols, 8*ls+1)
+	return limbs, bf.api.Sub(1, carry)
+}
+
+// Mul returns a*b modulo 2^(8*len(T)).
+func (bf *BinaryField[T]) Mul(a, b T) T {
+	n := nbLimbs[T]()
+	cols := make([]frontend.Variable, n)
+	for k := range cols {
+		cols[k] = 0
+		for i := 0; i <= k; i++ {
+			cols[k] = bf.api.MulAcc(cols[k], bf.limb(a, i), bf.limb(b, k-i))
+		}
+	}
+	limbs, _ := bf.propagate(cols, 16*limbSize[T]()+bits.Len(uint(n)))
+	return bf.fromLimbs(limbs)
+}
+
+// assertMulAdd asserts that x*y+z == w as integers, i.e. that the product
+// doesn't overflow.
+func (bf *BinaryField[T]) assertMulAdd(x, y, z, w T) {
+	n := nbLimbs[T]()
+	ls := 8 * limbSize[T]()
+	shiftInv := new(big.Int).Lsh(big.NewInt(1), uint(ls))
+	shiftInv.ModInverse(shiftInv, bf.api.Compiler().Field())
+	colBits := 2*ls + bits.Len(uint(n)) + 1
+	var carry frontend.Variable = 0
+	for k := 0; k < 2*n-1; k++ {
+		v := carry
+		for i := 0; i <= k && i < n; i++ {
+			if k-i >= n {
+				continue
+			}
+			v = bf.api.MulAcc(v, bf.limb(x, i), bf.limb(y, k-i))
+		}
+		if k < n {
+			v = bf.api.Add(v, bf.limb(z, k))
+			v = bf.api.Sub(v, bf.limb(w, k))
+		}
+		// v must be divisible by 2^ls and the quotient is the next carry. If
+		// not, then the carry is large and fails the range check.
+		carry = bf.api.Mul(v, shiftInv)
+		bf.rchecker.Check(carry, colBits-ls+1)
+	}
+	bf.api.AssertIsEqual(carry, 0)
+}
+
+// DivMod returns the quotient a/b and remainder a%b. If b is zero, then both
+// the quotient and remainder are zero as for the DIV and MOD opcodes in EVM.
+func (bf *BinaryField[T]) DivMod(a, b T) (q, r T) {
+	n := len(a)
+	inputs := []frontend.Variable{n}
+	for i := 0; i < n; i++ {
+		inputs = append(inputs, a[i].Val)
+	}
+	for i := 0; i < n; i++ {
+		inputs = append(inputs, b[i].Val)
+	}
+	res, err := bf.api.Compiler().NewHint(divModHint, 2*n, inputs...)
+	if err != nil {
+		panic(fmt.Sprintf("div mod hint: %v", err))
+	}
+	for i := 0; i < n; i++ {
+		q[i] = bf.ByteValueOf(res[i])
+		r[i] = bf.ByteValueOf(res[n+i])
+	}
+	// if b is zero, then we check 0 = q * 1 + r with r < 1, so q = r = 0.
+	isZero := bf.IsZero(b)
+	var aa, bb T
+	for i := 0; i < n; i++ {
+		aa[i] = U8{Val: bf.api.Mul(a[i].Val, bf.api.Sub(1, isZero))}
+		bb[i] = b[i]
+	}
+	bb[0] = U8{Val: bf.api.Add(b[0].Val, isZero)}
+	bf.assertMulAdd(q, bb, r, aa)
+	bf.api.AssertIsEqual(bf.Lt(r, bb), 1)
+	return q, r
+}
+
+// Exp returns a^e modulo 2^(8*len(T)). It uses square-and-multiply algorithm
+// over all bits of e.
+func (bf *BinaryField[T]) Exp(a, e T) T {
+	var one T
+	for i := 0; i < len(one); i++ {
+		one[i] = NewU8(0)
+	}
+	one[0] = NewU8(1)
+	res := one
+	for i := len(e) - 1; i >= 0; i-- {
+		eBits := bf.api.ToBinary(e[i].Val, 8)
+		for j := 7; j >= 0; j-- {
+			res = bf.Mul(res, res)
+			res = bf.Select(eBits[j], bf.Mul(res, a), res)
+		}
+	}
+	return res
+}
+
+// Lt returns 1 if a < b and 0 otherwise.
+func (bf *BinaryField[T]) Lt(a, b T) frontend.Variable {
+	_, borrow := bf.sub(a, b)
+	return borrow
+}
+
+// Gt returns 1 if a > b and 0 otherwise.
+func (bf *BinaryField[T]) Gt(a, b T) frontend.Variable {
+	return bf.Lt(b, a)
+}
+
+// Slt returns 1 if a < b when interpreted as two's complement signed integers
+// and 0 otherwise.
+func (bf *BinaryField[T]) Slt(a, b T) frontend.Variable {
+	return bf.Lt(bf.flipSign(a), bf.flipSign(b))
+}
+
+// Sgt returns 1 if a > b when interpreted as two's complement signed integers
+// and 0 otherwise.
+func (bf *BinaryField[T]) Sgt(a, b T) frontend.Variable {
+	return bf.Slt(b, a)
+}
+
+// flipSign flips the most significant bit of a. This maps the two's complement
+// signed integers to unsigned integers while preserving the order.
+func (bf *BinaryField[T]) flipSign(a T) T {
+	n := len(a)
+	a[n-1] = U8{Val: bf.xorT.Query(a[n-1].Val, 0x80)[0]}
+	return a
+}
+
+// msb returns the most significant bit of the byte a.
+func (bf *BinaryField[T]) msb(a U8) frontend.Variable {
+	return bf.api.Div(bf.andT.Query(a.Val, 0x80)[0], 0x80)
+}
+
+// IsZero returns 1 if a is zero and 0 otherwise.
+func (bf *BinaryField[T]) IsZero(a T) frontend.Variable {
+	// the sum of bytes doesn't overflow the native field.
+	var sum frontend.Variable = 0
+	for i := 0; i < len(a); i++ {
+		sum = bf.api.Add(sum, a[i].Val)
+	}
+	return bf.api.IsZero(sum)
+}
+
+// Select returns a if sel is 1 and b otherwise. sel must be boolean.
+func (bf *BinaryField[T]) Select(sel frontend.Variable, a, b T) T {
+	var r T
+	for i := 0; i < len(r); i++ {
+		r[i] = U8{Val: bf.api.Select(sel, a[i].Val, b[i].Val)}
+	}
+	return r
+}
+
+// Lshift returns a shifted left by c bits. The bits shifted out are discarded.
+func (bf *BinaryField[T]) Lshift(a T, c int) T {
+	n := len(a)
+	shiftBl := c / 8
+	shiftBt := c % 8
+	var ret T
+	for i := 0; i < n; i++ {
+		ret[i] = NewU8(0)
+	}
+	if shiftBl >= n {
+		return ret
+	}
+	// lower are the bits which stay in the byte and upper the bits which move
+	// to the next byte.
+	partitioned := make([][2]frontend.Variable, n-shiftBl)
+	for i := range partitioned {
+		lower := bf.andT.Query(a[i].Val, 1<<(8-shiftBt)-1)[0]
+		upper := bf.api.Div(bf.api.Sub(a[i].Val, lower), 1<<(8-shiftBt))
+		partitioned[i] = [2]frontend.Variable{lower, upper}
+	}
+	for i := range partitioned {
+		v := bf.api.Mul(partitioned[i][0], 1<<shiftBt)
+		if i > 0 {
+			v = bf.api.Add(v, partitioned[i-1][1])
+		}
+		ret[i+shiftBl] = U8{Val: v}
+	}
+	return ret
+}
+
+// SignExtend extends the two's complement signed integer of b+1 bytes in a to
+// the full width as for the SIGNEXTEND opcode in EVM. If b >= len(T)-1, then
+// returns a.
+func (bf *BinaryField[T]) SignExtend(a T, b frontend.Variable) T {
+	n := len(a)
+	// isEq[k] = 1 if b == k.
+	isEq := make([]frontend.Variable, n)
+	var sign frontend.Variable = 0
+	for k := range isEq {
+		isEq[k] = bf.api.IsZero(bf.api.Sub(b, k))
+		sign = bf.api.MulAcc(sign, isEq[k], bf.msb(a[k]))
+	}
+	ext := bf.api.Mul(sign, 0xff)
+	var ret T
+	// isAbove is 1 if k > b.
+	var isAbove frontend.Variable = 0
+	for k := 0; k < n; k++ {
+		ret[k] = U8{Val: bf.api.Select(isAbove, ext, a[k].Val)}
+		isAbove = bf.api.Add(isAbove, isEq[k])
+	}
+	return ret
+}
+
+// Byte returns the i-th byte of a in big-endian order as for the BYTE opcode
+// in EVM. If i >= len(T), then returns zero.
+func (bf *BinaryField[T]) Byte(a T, i frontend.Variable) U8 {
+	n := len(a)
+	var res frontend.Variable = 0
+	for k := 0; k < n; k++ {
+		res = bf.api.MulAcc(res, bf.api.IsZero(bf.api.Sub(i, k)), a[n-1-k].Val)
+	}
+	return U8{Val: res}
+}
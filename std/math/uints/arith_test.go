@@ -0,0 +1,249 @@
+package uints
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+var mod256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+func randU256(t *testing.T) *big.Int {
+	v, err := rand.Int(rand.Reader, mod256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// signed256 returns the two's complement signed value of v.
+func signed256(v *big.Int) *big.Int {
+	if v.Bit(255) == 1 {
+		return new(big.Int).Sub(v, mod256)
+	}
+	return v
+}
+
+type arith256Circuit struct {
+	A, B                U256
+	Sum, Diff, Prod     U256
+	Borrow, Lt, Gt, Slt frontend.Variable
+	Quo, Rem            U256
+	IsZero              frontend.Variable
+}
+
+func (c *arith256Circuit) Define(api frontend.API) error {
+	uapi, err := New[U256](api)
+	if err != nil {
+		return err
+	}
+	uapi.AssertEq(uapi.Add(c.A, c.B), c.Sum)
+	diff, borrow := uapi.SubWithBorrow(c.A, c.B)
+	uapi.AssertEq(diff, c.Diff)
+	api.AssertIsEqual(borrow, c.Borrow)
+	uapi.AssertEq(uapi.Mul(c.A, c.B), c.Prod)
+	api.AssertIsEqual(uapi.Lt(c.A, c.B), c.Lt)
+	api.AssertIsEqual(uapi.Gt(c.A, c.B), c.Gt)
+	api.AssertIsEqual(uapi.Slt(c.A, c.B), c.Slt)
+	q, r := uapi.DivMod(c.A, c.B)
+	uapi.AssertEq(q, c.Quo)
+	uapi.AssertEq(r, c.Rem)
+	api.AssertIsEqual(uapi.IsZero(c.B), c.IsZero)
+	return nil
+}
+
+func arith256Assignment(a, b *big.Int) *arith256Circuit {
+	boolToInt := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	sum := new(big.Int).Add(a, b)
+	sum.Mod(sum, mod256)
+	diff := new(big.Int).Sub(a, b)
+	diff.Mod(diff, mod256)
+	prod := new(big.Int).Mul(a, b)
+	prod.Mod(prod, mod256)
+	quo, rem := new(big.Int), new(big.Int)
+	if b.Sign() != 0 {
+		quo.QuoRem(a, b, rem)
+	}
+	return &arith256Circuit{
+		A:      NewU256(a),
+		B:      NewU256(b),
+		Sum:    NewU256(sum),
+		Diff:   NewU256(diff),
+		Prod:   NewU256(prod),
+		Borrow: boolToInt(a.Cmp(b) < 0),
+		Lt:     boolToInt(a.Cmp(b) < 0),
+		Gt:     boolToInt(a.Cmp(b) > 0),
+		Slt:    boolToInt(signed256(a).Cmp(signed256(b)) < 0),
+		Quo:    NewU256(quo),
+		Rem:    NewU256(rem),
+		IsZero: boolToInt(b.Sign() == 0),
+	}
+}
+
+func TestArithmetic256(t *testing.T) {
+	assert := test.NewAssert(t)
+	maxU256 := new(big.Int).Sub(mod256, big.NewInt(1))
+	cases := [][2]*big.Int{
+		{randU256(t), randU256(t)},
+		{randU256(t), new(big.Int).Rsh(randU256(t), 128)},
+		{new(big.Int).Rsh(randU256(t), 200), randU256(t)},
+		{randU256(t), big.NewInt(0)},
+		{big.NewInt(0), randU256(t)},
+		{maxU256, maxU256},
+		{maxU256, big.NewInt(1)},
+		{big.NewInt(7), big.NewInt(7)},
+	}
+	for i, c := range cases {
+		c := c
+		assert.Run(func(assert *test.Assert) {
+			err := test.IsSolved(&arith256Circuit{}, arith256Assignment(c[0], c[1]), ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("case-%d", i))
+	}
+	// wrong quotient and remainder
+	a, b := randU256(t), new(big.Int).Rsh(randU256(t), 128)
+	w := arith256Assignment(a, b)
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	q.Sub(q, big.NewInt(1))
+	r.Add(r, b)
+	w.Quo, w.Rem = NewU256(q), NewU256(r)
+	err := test.IsSolved(&arith256Circuit{}, w, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type shiftCircuit struct {
+	In    U256
+	Out   U256
+	Shift int
+}
+
+func (c *shiftCircuit) Define(api frontend.API) error {
+	uapi, err := New[U256](api)
+	if err != nil {
+		return err
+	}
+	uapi.AssertEq(uapi.Lshift(c.In, c.Shift), c.Out)
+	return nil
+}
+
+func TestLshift(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := randU256(t)
+	for _, shift := range []int{0, 3, 8, 17, 64, 255, 256} {
+		shift := shift
+		assert.Run(func(assert *test.Assert) {
+			out := new(big.Int).Lsh(in, uint(shift))
+			out.Mod(out, mod256)
+			err := test.IsSolved(&shiftCircuit{Shift: shift}, &shiftCircuit{In: NewU256(in), Out: NewU256(out), Shift: shift}, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("shift-%d", shift))
+	}
+}
+
+type evmCircuit struct {
+	A         U256
+	B         frontend.Variable
+	SignExt   U256
+	Byte      U8
+	E, ExpOut U256
+}
+
+func (c *evmCircuit) Define(api frontend.API) error {
+	uapi, err := New[U256](api)
+	if err != nil {
+		return err
+	}
+	uapi.AssertEq(uapi.SignExtend(c.A, c.B), c.SignExt)
+	uapi.ByteAssertEq(uapi.Byte(c.A, c.B), c.Byte)
+	uapi.AssertEq(uapi.Exp(c.A, c.E), c.ExpOut)
+	return nil
+}
+
+func TestEVMOps(t *testing.T) {
+	assert := test.NewAssert(t)
+	a := randU256(t)
+	a.SetBit(a, 7, 1)
+	a.SetBit(a, 15, 0)
+	e := big.NewInt(0x1234567)
+	expOut := new(big.Int).Exp(a, e, mod256)
+	for _, b := range []int{0, 1, 5, 30, 31, 32, 1000} {
+		b := b
+		assert.Run(func(assert *test.Assert) {
+			signExt := new(big.Int).Set(a)
+			if b < 31 {
+				nbBits := uint(8*b + 8)
+				signExt.Mod(a, new(big.Int).Lsh(big.NewInt(1), nbBits))
+				if a.Bit(int(nbBits-1)) == 1 {
+					signExt.Sub(signExt, new(big.Int).Lsh(big.NewInt(1), nbBits))
+					signExt.Mod(signExt, mod256)
+				}
+			}
+			var bt uint8
+			if b < 32 {
+				bt = a.FillBytes(make([]byte, 32))[b]
+			}
+			err := test.IsSolved(&evmCircuit{}, &evmCircuit{
+				A:       NewU256(a),
+				B:       b,
+				SignExt: NewU256(signExt),
+				Byte:    NewU8(bt),
+				E:       NewU256(e),
+				ExpOut:  NewU256(expOut),
+			}, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("b-%d", b))
+	}
+}
+
+type narrowCircuit struct {
+	A16, B16, Sum16  U16
+	A128, B128, P128 U128
+	In               frontend.Variable
+	Out              U128
+}
+
+func (c *narrowCircuit) Define(api frontend.API) error {
+	u16, err := New[U16](api)
+	if err != nil {
+		return err
+	}
+	u16.AssertEq(u16.Add(c.A16, c.B16), c.Sum16)
+	u128, err := New[U128](api)
+	if err != nil {
+		return err
+	}
+	u128.AssertEq(u128.Mul(c.A128, c.B128), c.P128)
+	u128.AssertEq(u128.ValueOf(c.In), c.Out)
+	api.AssertIsEqual(u128.ToValue(c.Out), c.In)
+	return nil
+}
+
+func TestU16U128(t *testing.T) {
+	assert := test.NewAssert(t)
+	mod128 := new(big.Int).Lsh(big.NewInt(1), 128)
+	a, b := new(big.Int).Rsh(randU256(t), 128), new(big.Int).Rsh(randU256(t), 128)
+	p := new(big.Int).Mul(a, b)
+	p.Mod(p, mod128)
+	in := new(big.Int).Rsh(randU256(t), 130)
+	err := test.IsSolved(&narrowCircuit{}, &narrowCircuit{
+		A16:   NewU16(0xfff0),
+		B16:   NewU16(0x0123),
+		Sum16: NewU16(0x0113),
+		A128:  NewU128(a),
+		B128:  NewU128(b),
+		P128:  NewU128(p),
+		In:    in,
+		Out:   NewU128(in),
+	}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
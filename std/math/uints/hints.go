@@ -15,7 +15,9 @@ func GetHints() []solver.Hint {
 	return []solver.Hint{
 		andHint,
 		xorHint,
+		orHint,
 		toBytes,
+		divModHint,
 	}
 }
 
@@ -24,6 +26,11 @@ func xorHint(_ *big.Int, inputs, outputs []*big.Int) error {
 	return nil
 }
 
+func orHint(_ *big.Int, inputs, outputs []*big.Int) error {
+	outputs[0].Or(inputs[0], inputs[1])
+	return nil
+}
+
 func andHint(_ *big.Int, inputs, outputs []*big.Int) error {
 	outputs[0].And(inputs[0], inputs[1])
 	return nil
@@ -38,10 +45,10 @@ func toBytes(m *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 	}
 	nbLimbs := int(inputs[0].Uint64())
 	if len(outputs) != nbLimbs {
-		return fmt.Errorf("output must be 8 elements")
+		return fmt.Errorf("output must be %d elements", nbLimbs)
 	}
-	if !inputs[1].IsUint64() {
-		return fmt.Errorf("input must be 64 bits")
+	if inputs[1].BitLen() > 8*nbLimbs {
+		return fmt.Errorf("input must be %d bits", 8*nbLimbs)
 	}
 	base := new(big.Int).Lsh(big.NewInt(1), uint(8))
 	tmp := new(big.Int).Set(inputs[1])
@@ -51,3 +58,32 @@ func toBytes(m *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 	}
 	return nil
 }
+
+// divModHint computes the quotient and remainder of the integers given by
+// little-endian bytes. If the divisor is zero, then both are zero.
+func divModHint(_ *big.Int, inputs, outputs []*big.Int) error {
+	if len(inputs) < 1 {
+		return fmt.Errorf("expecting at least one input")
+	}
+	nbBytes := int(inputs[0].Int64())
+	if len(inputs) != 1+2*nbBytes || len(outputs) != 2*nbBytes {
+		return fmt.Errorf("expecting %d inputs and %d outputs", 1+2*nbBytes, 2*nbBytes)
+	}
+	a, b := new(big.Int), new(big.Int)
+	for i := nbBytes - 1; i >= 0; i-- {
+		a.Lsh(a, 8).Add(a, inputs[1+i])
+		b.Lsh(b, 8).Add(b, inputs[1+nbBytes+i])
+	}
+	q, r := new(big.Int), new(big.Int)
+	if b.Sign() != 0 {
+		q.QuoRem(a, b, r)
+	}
+	mask := big.NewInt(0xff)
+	for i := 0; i < nbBytes; i++ {
+		outputs[i].And(q, mask)
+		outputs[nbBytes+i].And(r, mask)
+		q.Rsh(q, 8)
+		r.Rsh(r, 8)
+	}
+	return nil
+}
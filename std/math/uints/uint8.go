@@ -11,8 +11,8 @@
 // inefficients circuits.
 //
 // This package performs boolean operations using lookup tables on bytes. So,
-// long integers are split into 4 or 8 bytes and we perform the operations
-// bytewise. In the lookup tables, we store results for all possible 2^8×2^8
+// long integers are split into 2, 4, 8, 16 or 32 bytes and we perform the
+// operations bytewise. In the lookup tables, we store results for all possible 2^8×2^8
 // inputs. With this approach, every bytewise operation costs as single lookup,
 // which depending on the backend is relatively cheap (one to three
 // constraints).
@@ -24,6 +24,8 @@ package uints
 
 import (
 	"fmt"
+	"math/big"
+	"math/bits"
 
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/internal/logderivprecomp"
@@ -43,8 +45,6 @@ import (
 // TODO: maybe can store everything in a single table? Later! Or if we have a
 // lot of queries then makes sense to extract into separate table?
 
-// TODO: in ValueOf ensure consistency
-
 // TODO: distinguish between when we set constant in-circuit or witness
 // assignment. For constant we don't have to range check but for witness
 // assignment we have to.
@@ -77,16 +77,21 @@ func (e *U8) GnarkInitHook() {
 	}
 }
 
+type U256 [32]U8
+type U128 [16]U8
 type U64 [8]U8
 type U32 [4]U8
+type U16 [2]U8
 
-type Long interface{ U32 | U64 }
+type Long interface {
+	U16 | U32 | U64 | U128 | U256
+}
 
-type BinaryField[T U32 | U64] struct {
-	api        frontend.API
-	xorT, andT *logderivprecomp.Precomputed
-	rchecker   frontend.Rangechecker
-	allOne     U8
+type BinaryField[T Long] struct {
+	api             frontend.API
+	xorT, andT, orT *logderivprecomp.Precomputed
+	rchecker        frontend.Rangechecker
+	allOne          U8
 }
 
 func New[T Long](api frontend.API) (*BinaryField[T], error) {
@@ -98,11 +103,16 @@ func New[T Long](api frontend.API) (*BinaryField[T], error) {
 	if err != nil {
 		return nil, fmt.Errorf("new and table: %w", err)
 	}
+	orT, err := logderivprecomp.New(api, orHint, []uint{8})
+	if err != nil {
+		return nil, fmt.Errorf("new or table: %w", err)
+	}
 	rchecker := rangecheck.New(api)
 	bf := &BinaryField[T]{
 		api:      api,
 		xorT:     xorT,
 		andT:     andT,
+		orT:      orT,
 		rchecker: rchecker,
 	}
 	// TODO: this is const. add way to init constants
@@ -116,6 +126,13 @@ func NewU8(v uint8) U8 {
 	return U8{Val: v, internal: true}
 }
 
+func NewU16(v uint16) U16 {
+	return [2]U8{
+		NewU8(uint8((v >> (0 * 8)) & 0xff)),
+		NewU8(uint8((v >> (1 * 8)) & 0xff)),
+	}
+}
+
 func NewU32(v uint32) U32 {
 	return [4]U8{
 		NewU8(uint8((v >> (0 * 8)) & 0xff)),
@@ -138,6 +155,30 @@ func NewU64(v uint64) U64 {
 	}
 }
 
+// NewU128 returns a constant U128 from v. It panics if v is negative or does
+// not fit into 128 bits.
+func NewU128(v *big.Int) U128 {
+	return newLongFromBig[U128](v)
+}
+
+// NewU256 returns a constant U256 from v. It panics if v is negative or does
+// not fit into 256 bits.
+func NewU256(v *big.Int) U256 {
+	return newLongFromBig[U256](v)
+}
+
+func newLongFromBig[T Long](v *big.Int) T {
+	var r T
+	if v.Sign() < 0 || v.BitLen() > 8*len(r) {
+		panic(fmt.Sprintf("value does not fit into %d bits", 8*len(r)))
+	}
+	bts := v.FillBytes(make([]byte, len(r)))
+	for i := 0; i < len(r); i++ {
+		r[i] = NewU8(bts[len(r)-1-i])
+	}
+	return r
+}
+
 func NewU8Array(v []uint8) []U8 {
 	ret := make([]U8, len(v))
 	for i := range v {
@@ -167,26 +208,53 @@ func (bf *BinaryField[T]) ByteValueOf(a frontend.Variable) U8 {
 	return U8{Val: a, internal: true}
 }
 
+// ValueOf decomposes a into bytes and returns it as T. The value must fit into
+// T. It panics if T is not narrower than the native field, as then the
+// decomposition is not unique.
 func (bf *BinaryField[T]) ValueOf(a frontend.Variable) T {
 	var r T
-	bts, err := bf.api.Compiler().NewHint(toBytes, len(r), len(r), a)
+	bts := bf.bytesOf(a, len(r))
+	for i := range bts {
+		r[i] = bts[i]
+	}
+	return r
+}
+
+// bytesOf decomposes a into nbBytes little-endian bytes using a hint and
+// asserts that the decomposition is correct. It panics if nbBytes bytes do not
+// fit into the native field.
+func (bf *BinaryField[T]) bytesOf(a frontend.Variable, nbBytes int) []U8 {
+	if 8*nbBytes >= bf.api.Compiler().FieldBitLen() {
+		panic(fmt.Sprintf("%d-bit integer does not fit into native field", 8*nbBytes))
+	}
+	bts, err := bf.api.Compiler().NewHint(toBytes, nbBytes, nbBytes, a)
 	if err != nil {
 		panic(err)
 	}
-	// TODO: add constraint which ensures that map back to
+	r := make([]U8, nbBytes)
 	for i := range bts {
 		r[i] = bf.ByteValueOf(bts[i])
 	}
+	bf.api.AssertIsEqual(a, bf.recompose(r))
 	return r
 }
 
+// recompose returns the value of the little-endian bytes a.
+func (bf *BinaryField[T]) recompose(a []U8) frontend.Variable {
+	var res frontend.Variable = 0
+	for i := range a {
+		res = bf.api.MulAcc(res, a[i].Val, new(big.Int).Lsh(big.NewInt(1), uint(8*i)))
+	}
+	return res
+}
+
+// ToValue returns the value of a as a native element. It panics if T is not
+// narrower than the native field.
 func (bf *BinaryField[T]) ToValue(a T) frontend.Variable {
-	v := make([]frontend.Variable, len(a))
-	for i := range v {
-		v[i] = bf.api.Mul(a[i].Val, 1<<(i*8))
+	if 8*len(a) >= bf.api.Compiler().FieldBitLen() {
+		panic(fmt.Sprintf("%d-bit integer does not fit into native field", 8*len(a)))
 	}
-	vv := bf.api.Add(v[0], v[1], v[2:]...)
-	return vv
+	return bf.recompose(bf.UnpackLSB(a))
 }
 
 func (bf *BinaryField[T]) PackMSB(a ...U8) T {
@@ -240,6 +308,7 @@ func (bf *BinaryField[T]) twoArgWideFn(tbl *logderivprecomp.Precomputed, a ...T)
 
 func (bf *BinaryField[T]) And(a ...T) T { return bf.twoArgWideFn(bf.andT, a...) }
 func (bf *BinaryField[T]) Xor(a ...T) T { return bf.twoArgWideFn(bf.xorT, a...) }
+func (bf *BinaryField[T]) Or(a ...T) T  { return bf.twoArgWideFn(bf.orT, a...) }
 
 func (bf *BinaryField[T]) not(a U8) U8 {
 	ret := bf.xorT.Query(a.Val, bf.allOne.Val)
@@ -254,15 +323,18 @@ func (bf *BinaryField[T]) Not(a T) T {
 	return r
 }
 
+// Add returns the sum of the inputs modulo 2^(8*len(T)).
 func (bf *BinaryField[T]) Add(a ...T) T {
-	va := make([]frontend.Variable, len(a))
-	for i := range a {
-		va[i] = bf.ToValue(a[i])
+	cols := make([]frontend.Variable, nbLimbs[T]())
+	for k := range cols {
+		cols[k] = 0
+		for i := range a {
+			cols[k] = bf.api.Add(cols[k], bf.limb(a[i], k))
+		}
 	}
-	vres := bf.api.Add(va[0], va[1], va[2:]...)
-	res := bf.ValueOf(vres)
-	// TODO: should also check the that carry we omitted is correct.
-	return res
+	// the sum of n inputs has at most bits.Len(n) bits more than the inputs.
+	res, _ := bf.propagate(cols, 8*limbSize[T]()+bits.Len(uint(len(a))))
+	return bf.fromLimbs(res)
 }
 
 func (bf *BinaryField[T]) Lrot(a T, c int) T {
@@ -325,7 +397,7 @@ func (bf *BinaryField[T]) AssertEq(a, b T) {
 	}
 }
 
-func reslice[T U32 | U64](in []T) [][]U8 {
+func reslice[T Long](in []T) [][]U8 {
 	if len(in) == 0 {
 		panic("zero-length input")
 	}
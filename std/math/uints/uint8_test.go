@@ -1,11 +1,14 @@
 package uints
 
 import (
+	"math/big"
 	"math/bits"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/test"
 )
 
@@ -79,3 +82,99 @@ func TestRshift(t *testing.T) {
 	err = test.IsSolved(&rshiftCircuit{Shift: 11}, &rshiftCircuit{Shift: 11, In: NewU32(0x12345678), Expected: NewU32(0x12345678 >> 11)}, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type valueOfCircuit struct {
+	In       frontend.Variable
+	Expected U32
+}
+
+func (c *valueOfCircuit) Define(api frontend.API) error {
+	uapi, err := New[U32](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.ValueOf(c.In)
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+type addCircuit struct {
+	A, B     U32
+	Expected U32
+}
+
+func (c *addCircuit) Define(api frontend.API) error {
+	uapi, err := New[U32](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.Add(c.A, c.B)
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+// maliciousToBytes decomposes the input plus one, so that the returned bytes do
+// not match the input.
+func maliciousToBytes(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	in := []*big.Int{inputs[0], new(big.Int).Add(inputs[1], big.NewInt(1))}
+	in[1].Mod(in[1], new(big.Int).Lsh(big.NewInt(1), 8*uint(len(outputs))))
+	return toBytes(mod, in, outputs)
+}
+
+func isSolvedWithHint(circuit, assignment frontend.Circuit, hint solver.Hint) error {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+	if err != nil {
+		return err
+	}
+	w, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return err
+	}
+	return ccs.IsSolved(w, solver.OverrideHint(solver.GetHintID(toBytes), hint))
+}
+
+func TestValueOf(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&valueOfCircuit{}, &valueOfCircuit{In: 0x12345678, Expected: NewU32(0x12345678)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = isSolvedWithHint(&valueOfCircuit{}, &valueOfCircuit{In: 0x12345678, Expected: NewU32(0x12345678)}, toBytes)
+	assert.NoError(err)
+	// the bytes must recompose to the input
+	err = isSolvedWithHint(&valueOfCircuit{}, &valueOfCircuit{In: 0x12345678, Expected: NewU32(0x12345679)}, maliciousToBytes)
+	assert.Error(err)
+}
+
+func TestAdd(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the carry is omitted
+	err := test.IsSolved(&addCircuit{}, &addCircuit{A: NewU32(0xffffffff), B: NewU32(2), Expected: NewU32(1)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = isSolvedWithHint(&addCircuit{}, &addCircuit{A: NewU32(0xffffffff), B: NewU32(2), Expected: NewU32(1)}, toBytes)
+	assert.NoError(err)
+	// the result must not be chosen by the prover
+	err = isSolvedWithHint(&addCircuit{}, &addCircuit{A: NewU32(0x12345678), B: NewU32(1), Expected: NewU32(0x1234567a)}, maliciousToBytes)
+	assert.Error(err)
+}
+
+type orCircuit struct {
+	A, B     U32
+	Expected U32
+}
+
+func (c *orCircuit) Define(api frontend.API) error {
+	uapi, err := New[U32](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.Or(c.A, c.B)
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+func TestOr(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&orCircuit{}, &orCircuit{A: NewU32(0x12345678), B: NewU32(0x0f0f0f0f), Expected: NewU32(0x12345678 | 0x0f0f0f0f)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&orCircuit{}, &orCircuit{A: NewU32(0x12345678), B: NewU32(0x0f0f0f0f), Expected: NewU32(0x12345678 ^ 0x0f0f0f0f)}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,209 @@
+package blake2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+)
+
+// The circuits below hash the message by applying the compression function on
+// the padded message blocks, so that the result can be compared against
+// [blake2b.Sum512] and [blake2s.Sum256].
+
+type blake2bCircuit struct {
+	In       []uints.U8
+	Expected [64]uints.U8
+
+	variableRounds bool
+}
+
+func (c *blake2bCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h := uints.NewU64Array(blake2.IVb[:])
+	// parameter block: 64 bytes digest, no key, fanout and depth 1
+	h[0] = uapi.Xor(h[0], uints.NewU64(0x01010040))
+	nbBlocks := (len(c.In) + 127) / 128
+	if nbBlocks == 0 {
+		nbBlocks = 1
+	}
+	zero := uints.NewU8(0)
+	for b := 0; b < nbBlocks; b++ {
+		var m [16]uints.U64
+		for i := range m {
+			var bts [8]uints.U8
+			for j := range bts {
+				if k := 128*b + 8*i + j; k < len(c.In) {
+					bts[j] = c.In[k]
+				} else {
+					bts[j] = zero
+				}
+			}
+			m[i] = uapi.PackLSB(bts[:]...)
+		}
+		processed := 128 * (b + 1)
+		f := uints.NewU64(0)
+		if b == nbBlocks-1 {
+			processed = len(c.In)
+			f = uints.NewU64(^uint64(0))
+		}
+		t := [2]uints.U64{uints.NewU64(uint64(processed)), uints.NewU64(0)}
+		var hh [8]uints.U64
+		copy(hh[:], h)
+		if c.variableRounds {
+			hh = blake2.Blake2bVariableRounds(api, uapi, 14, 12, hh, m, t, f)
+		} else {
+			hh = blake2.Blake2b(uapi, 12, hh, m, t, f)
+		}
+		copy(h, hh[:])
+	}
+	for i := range h {
+		bts := uapi.UnpackLSB(h[i])
+		for j := range bts {
+			uapi.ByteAssertEq(bts[j], c.Expected[8*i+j])
+		}
+	}
+	return nil
+}
+
+func TestBlake2b(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, length := range []int{0, 3, 128, 200} {
+		for _, variableRounds := range []bool{false, true} {
+			assert.Run(func(assert *test.Assert) {
+				in := make([]byte, length)
+				for i := range in {
+					in[i] = byte(i * 7)
+				}
+				expected := blake2b.Sum512(in)
+				witness := blake2bCircuit{In: uints.NewU8Array(in)}
+				copy(witness.Expected[:], uints.NewU8Array(expected[:]))
+				circuit := blake2bCircuit{In: make([]uints.U8, length), variableRounds: variableRounds}
+				err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, fmt.Sprintf("length=%d/variable=%t", length, variableRounds))
+		}
+	}
+}
+
+type blake2bRoundsCircuit struct {
+	Rounds   frontend.Variable
+	H        [8]uints.U64
+	M        [16]uints.U64
+	Expected [8]uints.U64
+}
+
+func (c *blake2bRoundsCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	t := [2]uints.U64{uints.NewU64(0), uints.NewU64(0)}
+	res := blake2.Blake2bVariableRounds(api, uapi, 4, c.Rounds, c.H, c.M, t, uints.NewU64(0))
+	for i := range c.Expected {
+		uapi.AssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlake2bVariableRounds(t *testing.T) {
+	assert := test.NewAssert(t)
+	// with zero rounds, the result is h ^ IV
+	var witness blake2bRoundsCircuit
+	for i := range witness.H {
+		witness.H[i] = uints.NewU64(uint64(i))
+		witness.Expected[i] = uints.NewU64(uint64(i) ^ uint64(i) ^ blake2.IVb[i])
+	}
+	for i := range witness.M {
+		witness.M[i] = uints.NewU64(uint64(i))
+	}
+	witness.Rounds = 0
+	err := test.IsSolved(&blake2bRoundsCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	witness.Rounds = 1
+	err = test.IsSolved(&blake2bRoundsCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// more than the maximum number of rounds
+	witness.Rounds = 5
+	err = test.IsSolved(&blake2bRoundsCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type blake2sCircuit struct {
+	In       []uints.U8
+	Expected [32]uints.U8
+}
+
+func (c *blake2sCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h := uints.NewU32Array(blake2.IVs[:])
+	// parameter block: 32 bytes digest, no key, fanout and depth 1
+	h[0] = uapi.Xor(h[0], uints.NewU32(0x01010020))
+	nbBlocks := (len(c.In) + 63) / 64
+	if nbBlocks == 0 {
+		nbBlocks = 1
+	}
+	zero := uints.NewU8(0)
+	for b := 0; b < nbBlocks; b++ {
+		var m [16]uints.U32
+		for i := range m {
+			var bts [4]uints.U8
+			for j := range bts {
+				if k := 64*b + 4*i + j; k < len(c.In) {
+					bts[j] = c.In[k]
+				} else {
+					bts[j] = zero
+				}
+			}
+			m[i] = uapi.PackLSB(bts[:]...)
+		}
+		processed := 64 * (b + 1)
+		f := uints.NewU32(0)
+		if b == nbBlocks-1 {
+			processed = len(c.In)
+			f = uints.NewU32(^uint32(0))
+		}
+		t := [2]uints.U32{uints.NewU32(uint32(processed)), uints.NewU32(0)}
+		var hh [8]uints.U32
+		copy(hh[:], h)
+		hh = blake2.Blake2s(uapi, hh, m, t, f)
+		copy(h, hh[:])
+	}
+	for i := range h {
+		bts := uapi.UnpackLSB(h[i])
+		for j := range bts {
+			uapi.ByteAssertEq(bts[j], c.Expected[4*i+j])
+		}
+	}
+	return nil
+}
+
+func TestBlake2s(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, length := range []int{0, 3, 64, 100} {
+		assert.Run(func(assert *test.Assert) {
+			in := make([]byte, length)
+			for i := range in {
+				in[i] = byte(i * 7)
+			}
+			expected := blake2s.Sum256(in)
+			witness := blake2sCircuit{In: uints.NewU8Array(in)}
+			copy(witness.Expected[:], uints.NewU8Array(expected[:]))
+			circuit := blake2sCircuit{In: make([]uints.U8, length)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("length=%d", length))
+	}
+}
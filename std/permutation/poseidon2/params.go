@@ -0,0 +1,257 @@
+package poseidon2
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+var (
+	// ErrInvalidSizebuffer is returned when the length of the state given to
+	// the permutation does not match the width of the parameters.
+	ErrInvalidSizebuffer = errors.New("the size of the input should match the size of the hash buffer")
+)
+
+// Parameters describe an instance of the Poseidon2 permutation. The same
+// parameters are used for the in-circuit permutation [Permutation] and the
+// out-of-circuit permutation [Parameters.Permute].
+type Parameters struct {
+	// Width is the number of field elements in the state.
+	Width int
+
+	// DegreeSBox is the degree of the S-box x ↦ xᵈ.
+	DegreeSBox int
+
+	// NbFullRounds is the total number of full rounds. Half of them are
+	// applied before the partial rounds and half after.
+	NbFullRounds int
+
+	// NbPartialRounds is the number of partial rounds.
+	NbPartialRounds int
+
+	// RoundKeys are the round constants. RoundKeys[i] has Width elements for
+	// the full rounds and a single element for the partial rounds.
+	RoundKeys [][]*big.Int
+
+	// Modulus is the modulus of the field the permutation is defined over.
+	Modulus *big.Int
+}
+
+// NewParameters returns a new set of parameters for the field with the given
+// modulus. The round keys are generated with the Grain LFSR as in the reference
+// implementation [HKR23], so that the permutation is compatible with other
+// Poseidon2 implementations using the same parameters.
+//
+// [HKR23]: https://github.com/HorizenLabs/poseidon2
+func NewParameters(modulus *big.Int, width, degreeSBox, nbFullRounds, nbPartialRounds int) (*Parameters, error) {
+	if width != 2 && width != 3 {
+		return nil, fmt.Errorf("unsupported width %d: only 2 and 3 are supported", width)
+	}
+	if nbFullRounds%2 != 0 {
+		return nil, fmt.Errorf("number of full rounds must be even")
+	}
+	if degreeSBox < 3 {
+		return nil, fmt.Errorf("S-box degree must be at least 3")
+	}
+	// the S-box x ↦ xᵈ is a permutation only if gcd(d, p-1) = 1
+	pm1 := new(big.Int).Sub(modulus, big.NewInt(1))
+	if new(big.Int).GCD(nil, nil, big.NewInt(int64(degreeSBox)), pm1).Cmp(big.NewInt(1)) != 0 {
+		return nil, fmt.Errorf("S-box degree %d is not coprime with p-1", degreeSBox)
+	}
+	p := &Parameters{
+		Width:           width,
+		DegreeSBox:      degreeSBox,
+		NbFullRounds:    nbFullRounds,
+		NbPartialRounds: nbPartialRounds,
+		Modulus:         new(big.Int).Set(modulus),
+	}
+	p.initRoundKeys()
+	return p, nil
+}
+
+// GetDefaultParameters returns the default parameters for the scalar field of
+// the given curve. The default instance has width 2 and is used for the
+// two-to-one compression function.
+//
+// The S-box degree is the smallest d ≥ 3 such that x ↦ xᵈ is a permutation of
+// the field. The numbers of rounds are given by the round numbers script of the
+// reference implementation for 128 bits of security, including its security
+// margin of two full rounds and 7.5% more partial rounds.
+func GetDefaultParameters(curve ecc.ID) (*Parameters, error) {
+	var degreeSBox, nbFullRounds, nbPartialRounds int
+	switch curve {
+	case ecc.BN254, ecc.BLS12_381, ecc.BW6_761, ecc.BW6_633:
+		degreeSBox, nbFullRounds, nbPartialRounds = 5, 8, 56
+	case ecc.BLS24_315, ecc.BLS24_317:
+		degreeSBox, nbFullRounds, nbPartialRounds = 7, 8, 46
+	case ecc.BLS12_377:
+		degreeSBox, nbFullRounds, nbPartialRounds = 11, 8, 37
+	default:
+		return nil, fmt.Errorf("curve %s not supported", curve)
+	}
+	return NewParameters(curve.ScalarField(), 2, degreeSBox, nbFullRounds, nbPartialRounds)
+}
+
+// String returns a short description of the parameters.
+func (p *Parameters) String() string {
+	return fmt.Sprintf("Poseidon2[t=%d,rF=%d,rP=%d,d=%d]", p.Width, p.NbFullRounds, p.NbPartialRounds, p.DegreeSBox)
+}
+
+// initRoundKeys initialises the round keys with the Grain LFSR in
+// self-shrinking mode, as in the reference implementation. The full rounds have
+// Width round keys and the partial rounds a single one. The keys are sampled by
+// rejection from the output bits interpreted as big-endian integers.
+func (p *Parameters) initRoundKeys() {
+	rf := p.NbFullRounds / 2
+	nbRounds := p.NbFullRounds + p.NbPartialRounds
+	n := p.Modulus.BitLen()
+	g := newGrainLFSR(n, p.Width, p.NbFullRounds, p.NbPartialRounds)
+	next := func() *big.Int {
+		v := new(big.Int)
+		for {
+			v.SetUint64(0)
+			for i := 0; i < n; i++ {
+				v.Lsh(v, 1)
+				v.SetBit(v, 0, g.nextBit())
+			}
+			if v.Cmp(p.Modulus) < 0 {
+				return v
+			}
+		}
+	}
+	p.RoundKeys = make([][]*big.Int, nbRounds)
+	for i := 0; i < nbRounds; i++ {
+		nbKeys := p.Width
+		if i >= rf && i < rf+p.NbPartialRounds {
+			nbKeys = 1
+		}
+		p.RoundKeys[i] = make([]*big.Int, nbKeys)
+		for j := range p.RoundKeys[i] {
+			p.RoundKeys[i][j] = next()
+		}
+	}
+}
+
+// grainLFSR is the 80-bit Grain LFSR used to generate the round constants of
+// Poseidon and Poseidon2.
+type grainLFSR struct {
+	state [80]uint
+}
+
+// newGrainLFSR initialises the LFSR with the description of a prime field
+// instance with x ↦ xᵈ S-box and discards the first 160 bits.
+func newGrainLFSR(fieldSize, width, nbFullRounds, nbPartialRounds int) *grainLFSR {
+	var g grainLFSR
+	i := 0
+	push := func(v, nbBits int) {
+		for j := nbBits - 1; j >= 0; j-- {
+			g.state[i] = uint(v>>j) & 1
+			i++
+		}
+	}
+	push(1, 2) // prime field
+	push(0, 4) // S-box x ↦ xᵈ
+	push(fieldSize, 12)
+	push(width, 12)
+	push(nbFullRounds, 10)
+	push(nbPartialRounds, 10)
+	push(1<<30-1, 30)
+	for j := 0; j < 160; j++ {
+		g.step()
+	}
+	return &g
+}
+
+func (g *grainLFSR) step() uint {
+	b := g.state[62] ^ g.state[51] ^ g.state[38] ^ g.state[23] ^ g.state[13] ^ g.state[0]
+	copy(g.state[:], g.state[1:])
+	g.state[79] = b
+	return b
+}
+
+// nextBit returns the next output bit of the self-shrinking generator: the
+// bits are taken by pairs and the second bit is output only if the first one
+// is set.
+func (g *grainLFSR) nextBit() uint {
+	for {
+		b1, b2 := g.step(), g.step()
+		if b1 == 1 {
+			return b2
+		}
+	}
+}
+
+// Permute applies the Poseidon2 permutation in place on the state, out of
+// circuit. The inputs are expected to be reduced modulo [Parameters.Modulus].
+func (p *Parameters) Permute(state []*big.Int) error {
+	if len(state) != p.Width {
+		return ErrInvalidSizebuffer
+	}
+	rf := p.NbFullRounds / 2
+	p.nativeMatMulExternal(state)
+	for i := 0; i < rf; i++ {
+		p.nativeFullRound(state, p.RoundKeys[i])
+	}
+	for i := rf; i < rf+p.NbPartialRounds; i++ {
+		state[0].Add(state[0], p.RoundKeys[i][0])
+		p.nativeSBox(state[0])
+		p.nativeMatMulInternal(state)
+	}
+	for i := rf + p.NbPartialRounds; i < p.NbFullRounds+p.NbPartialRounds; i++ {
+		p.nativeFullRound(state, p.RoundKeys[i])
+	}
+	return nil
+}
+
+func (p *Parameters) nativeFullRound(state []*big.Int, keys []*big.Int) {
+	for j := range state {
+		state[j].Add(state[j], keys[j])
+		p.nativeSBox(state[j])
+	}
+	p.nativeMatMulExternal(state)
+}
+
+func (p *Parameters) nativeSBox(x *big.Int) {
+	x.Exp(x, big.NewInt(int64(p.DegreeSBox)), p.Modulus)
+}
+
+// nativeMatMulExternal multiplies the state by circ(2, 1, ..., 1).
+func (p *Parameters) nativeMatMulExternal(state []*big.Int) {
+	sum := new(big.Int)
+	for i := range state {
+		sum.Add(sum, state[i])
+	}
+	for i := range state {
+		state[i].Add(state[i], sum)
+		state[i].Mod(state[i], p.Modulus)
+	}
+}
+
+// nativeMatMulInternal multiplies the state by 𝟙 + diag(internalDiag).
+func (p *Parameters) nativeMatMulInternal(state []*big.Int) {
+	sum := new(big.Int)
+	for i := range state {
+		sum.Add(sum, state[i])
+	}
+	diag := internalDiag(p.Width)
+	for i := range state {
+		state[i].Mul(state[i], big.NewInt(int64(diag[i])))
+		state[i].Add(state[i], sum)
+		state[i].Mod(state[i], p.Modulus)
+	}
+}
+
+// internalDiag returns the diagonal of the internal matrix minus the all-one
+// matrix for small widths, as in the Poseidon2 paper.
+func internalDiag(width int) []int {
+	switch width {
+	case 2:
+		return []int{1, 2}
+	case 3:
+		return []int{1, 1, 2}
+	default:
+		panic("unsupported width")
+	}
+}
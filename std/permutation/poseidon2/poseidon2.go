@@ -0,0 +1,176 @@
+// Package poseidon2 implements the Poseidon2 permutation.
+//
+// Poseidon2 [GKS23] is an arithmetisation-oriented permutation defined directly
+// over the native scalar field. It uses a cheap external linear layer for the
+// full rounds and an even cheaper internal linear layer for the partial rounds,
+// which makes it significantly cheaper in-circuit than MiMC.
+//
+// This package exposes only the permutation primitive and the two-to-one
+// compression function built on top of it. For hashing arbitrary number of
+// field elements use [github.com/consensys/gnark/std/hash/poseidon2].
+//
+// The permutation has two constraint layouts, chosen automatically depending on
+// the builder:
+//   - for R1CS, linear combinations are free. The round constants are added and
+//     the linear layers are applied as linear expressions and only the S-boxes
+//     create constraints.
+//   - for PLONK, every addition costs a constraint. We fuse the linear layer
+//     and the addition of the round constants of the following round into a
+//     single constraint per state element.
+//
+// The out-of-circuit counterpart of the permutation is [Parameters.Permute].
+//
+// [GKS23]: https://eprint.iacr.org/2023/323
+package poseidon2
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/frontendtype"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// Permutation computes the Poseidon2 permutation in-circuit.
+type Permutation struct {
+	api    frontend.API
+	params *Parameters
+}
+
+// NewPoseidon2 returns a new Poseidon2 permutation instance with the default
+// parameters for the native field. See [GetDefaultParameters].
+func NewPoseidon2(api frontend.API) (*Permutation, error) {
+	curve := utils.FieldToCurve(api.Compiler().Field())
+	params, err := GetDefaultParameters(curve)
+	if err != nil {
+		return nil, fmt.Errorf("default parameters: %w", err)
+	}
+	return NewPoseidon2FromParameters(api, params)
+}
+
+// NewPoseidon2FromParameters returns a new Poseidon2 permutation instance with
+// the given parameters. The parameters must be defined over the native field.
+func NewPoseidon2FromParameters(api frontend.API, params *Parameters) (*Permutation, error) {
+	if params.Modulus.Cmp(api.Compiler().Field()) != 0 {
+		return nil, fmt.Errorf("parameters modulus does not match native field")
+	}
+	return &Permutation{api: api, params: params}, nil
+}
+
+// Permutation applies the permutation in place on the input state.
+func (h *Permutation) Permutation(state []frontend.Variable) error {
+	if len(state) != h.params.Width {
+		return ErrInvalidSizebuffer
+	}
+	if ft, ok := h.api.(frontendtype.FrontendTyper); ok && ft.FrontendType() == frontendtype.SCS {
+		h.permutationSCS(state)
+	} else {
+		h.permutationR1CS(state)
+	}
+	return nil
+}
+
+// Compress applies the two-to-one compression function
+//
+//	(left, right) ↦ Permutation(left, right)[1] + right.
+//
+// It requires the permutation width to be 2.
+func (h *Permutation) Compress(left, right frontend.Variable) frontend.Variable {
+	if h.params.Width != 2 {
+		panic("compression requires width 2")
+	}
+	state := []frontend.Variable{left, right}
+	if err := h.Permutation(state); err != nil {
+		panic(err)
+	}
+	return h.api.Add(state[1], right)
+}
+
+func (h *Permutation) isFullRound(round int) bool {
+	rf := h.params.NbFullRounds / 2
+	return round < rf || round >= rf+h.params.NbPartialRounds
+}
+
+// sBox returns xᵈ using square-and-multiply.
+func (h *Permutation) sBox(x frontend.Variable) frontend.Variable {
+	d := h.params.DegreeSBox
+	res := x
+	for i := bits.Len(uint(d)) - 2; i >= 0; i-- {
+		res = h.api.Mul(res, res)
+		if (d>>i)&1 == 1 {
+			res = h.api.Mul(res, x)
+		}
+	}
+	return res
+}
+
+func (h *Permutation) permutationR1CS(state []frontend.Variable) {
+	h.matMulExternal(state, nil)
+	for r := 0; r < len(h.params.RoundKeys); r++ {
+		if h.isFullRound(r) {
+			for i := range state {
+				state[i] = h.sBox(h.api.Add(state[i], h.params.RoundKeys[r][i]))
+			}
+			h.matMulExternal(state, nil)
+		} else {
+			state[0] = h.sBox(h.api.Add(state[0], h.params.RoundKeys[r][0]))
+			h.matMulInternal(state, nil)
+		}
+	}
+}
+
+func (h *Permutation) permutationSCS(state []frontend.Variable) {
+	// the round keys of the following round are added in the same constraint
+	// as the linear layer.
+	nbRounds := len(h.params.RoundKeys)
+	h.matMulExternal(state, h.params.RoundKeys[0])
+	for r := 0; r < nbRounds; r++ {
+		var next []*big.Int
+		if r+1 < nbRounds {
+			next = h.params.RoundKeys[r+1]
+		}
+		if h.isFullRound(r) {
+			for i := range state {
+				state[i] = h.sBox(state[i])
+			}
+			h.matMulExternal(state, next)
+		} else {
+			state[0] = h.sBox(state[0])
+			h.matMulInternal(state, next)
+		}
+	}
+}
+
+// matMulExternal multiplies the state by circ(2, 1, ..., 1) and adds the keys.
+// If keys has less elements than the state, then the missing keys are zero.
+func (h *Permutation) matMulExternal(state []frontend.Variable, keys []*big.Int) {
+	h.matMul(state, keys, []int{1, 1, 1})
+}
+
+// matMulInternal multiplies the state by 𝟙 + diag(internalDiag) and adds the
+// keys. If keys has less elements than the state, then the missing keys are
+// zero.
+func (h *Permutation) matMulInternal(state []frontend.Variable, keys []*big.Int) {
+	h.matMul(state, keys, internalDiag(h.params.Width))
+}
+
+func (h *Permutation) matMul(state []frontend.Variable, keys []*big.Int, diag []int) {
+	res := make([]frontend.Variable, len(state))
+	for i := range state {
+		terms := make([]frontend.Variable, 0, len(state)+1)
+		for j := range state {
+			if i == j {
+				terms = append(terms, h.api.Mul(state[j], diag[i]+1))
+			} else {
+				terms = append(terms, state[j])
+			}
+		}
+		if i < len(keys) {
+			terms = append(terms, keys[i])
+		}
+		res[i] = h.api.Add(terms[0], terms[1], terms[2:]...)
+	}
+	copy(state, res)
+}
@@ -0,0 +1,112 @@
+package poseidon2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type poseidon2Circuit struct {
+	Input  [2]frontend.Variable
+	Output [2]frontend.Variable `gnark:",public"`
+}
+
+func (c *poseidon2Circuit) Define(api frontend.API) error {
+	h, err := NewPoseidon2(api)
+	if err != nil {
+		return err
+	}
+	state := []frontend.Variable{c.Input[0], c.Input[1]}
+	if err := h.Permutation(state); err != nil {
+		return err
+	}
+	api.AssertIsEqual(state[0], c.Output[0])
+	api.AssertIsEqual(state[1], c.Output[1])
+	return nil
+}
+
+func TestPoseidon2Permutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	curves := []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BW6_633, ecc.BLS24_315, ecc.BLS24_317}
+	for _, curve := range curves {
+		params, err := GetDefaultParameters(curve)
+		assert.NoError(err)
+		in := []*big.Int{big.NewInt(1), new(big.Int).Sub(curve.ScalarField(), big.NewInt(1))}
+		out := []*big.Int{new(big.Int).Set(in[0]), new(big.Int).Set(in[1])}
+		assert.NoError(params.Permute(out))
+		assert.CheckCircuit(&poseidon2Circuit{},
+			test.WithValidAssignment(&poseidon2Circuit{Input: [2]frontend.Variable{in[0], in[1]}, Output: [2]frontend.Variable{out[0], out[1]}}),
+			test.WithInvalidAssignment(&poseidon2Circuit{Input: [2]frontend.Variable{in[1], in[0]}, Output: [2]frontend.Variable{out[0], out[1]}}),
+			test.WithCurves(curve), test.NoFuzzing(), test.NoSerializationChecks(), test.NoProverChecks())
+	}
+}
+
+func TestParametersCoprime(t *testing.T) {
+	assert := test.NewAssert(t)
+	// x ↦ x⁵ is not a permutation over the BLS12-377 scalar field.
+	_, err := NewParameters(ecc.BLS12_377.ScalarField(), 2, 5, 8, 37)
+	assert.Error(err)
+	_, err = NewParameters(ecc.BLS12_377.ScalarField(), 2, 11, 8, 37)
+	assert.NoError(err)
+}
+
+type poseidon2Width3Circuit struct {
+	params *Parameters
+	Input  [3]frontend.Variable
+	Output [3]frontend.Variable `gnark:",public"`
+}
+
+func (c *poseidon2Width3Circuit) Define(api frontend.API) error {
+	h, err := NewPoseidon2FromParameters(api, c.params)
+	if err != nil {
+		return err
+	}
+	state := c.Input[:]
+	if err := h.Permutation(state); err != nil {
+		return err
+	}
+	for i := range state {
+		api.AssertIsEqual(state[i], c.Output[i])
+	}
+	return nil
+}
+
+// TestPoseidon2KnownAnswer checks the permutation against the test vector of
+// the reference implementation for the BN254 instance with width 3
+// (https://github.com/HorizenLabs/poseidon2, poseidon2_instance_bn256.rs).
+func TestPoseidon2KnownAnswer(t *testing.T) {
+	params, err := NewParameters(ecc.BN254.ScalarField(), 3, 5, 8, 56)
+	require.NoError(t, err)
+	require.Equal(t, "0x1d066a255517b7fd8bddd3a93f7804ef7f8fcde48bb4c37a59a09a1a97052816", "0x"+params.RoundKeys[0][0].Text(16))
+
+	in := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}
+	out := []*big.Int{new(big.Int).Set(in[0]), new(big.Int).Set(in[1]), new(big.Int).Set(in[2])}
+	require.NoError(t, params.Permute(out))
+	expected := []string{
+		"0x0bb61d24daca55eebcb1929a82650f328134334da98ea4f847f760054f4a3033",
+		"0x303b6f7c86d043bfcbcc80214f26a30277a15d3f74ca654992defe7ff8d03570",
+		"0x1ed25194542b12eef8617361c3ba7c52e660b145994427cc86296242cf766ec8",
+	}
+	for i := range out {
+		e, ok := new(big.Int).SetString(expected[i], 0)
+		require.True(t, ok)
+		require.Equal(t, 0, e.Cmp(out[i]), "state[%d]", i)
+	}
+
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&poseidon2Width3Circuit{params: params},
+		test.WithValidAssignment(&poseidon2Width3Circuit{
+			Input:  [3]frontend.Variable{in[0], in[1], in[2]},
+			Output: [3]frontend.Variable{expected[0], expected[1], expected[2]},
+		}),
+		test.WithInvalidAssignment(&poseidon2Width3Circuit{
+			Input:  [3]frontend.Variable{in[0], in[2], in[1]},
+			Output: [3]frontend.Variable{expected[0], expected[1], expected[2]},
+		}),
+		test.WithCurves(ecc.BN254), test.NoFuzzing(), test.NoSerializationChecks(), test.NoProverChecks())
+}
@@ -0,0 +1,41 @@
+package groth16
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/std/recursion"
+)
+
+// GetNativeProverOptions returns Groth16 prover options for the native prover
+// to initialize the configuration suitable for in-circuit verification.
+func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
+	return func(pc *backend.ProverConfig) error {
+		htfProverHasher, err := recursion.NewShortPartitioned(outer, field)
+		if err != nil {
+			return fmt.Errorf("get hash to field: %w", err)
+		}
+		htfOpt := backend.WithProverHashToFieldFunction(htfProverHasher)
+		if err = htfOpt(pc); err != nil {
+			return fmt.Errorf("apply prover htf option: %w", err)
+		}
+		return nil
+	}
+}
+
+// GetNativeVerifierOptions returns Groth16 verifier options to initialize the
+// configuration to be compatible with in-circuit verification.
+func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
+	return func(vc *backend.VerifierConfig) error {
+		htfVerifierHasher, err := recursion.NewShortPartitioned(outer, field)
+		if err != nil {
+			return fmt.Errorf("get hash to field: %w", err)
+		}
+		htfOpt := backend.WithVerifierHashToFieldFunction(htfVerifierHasher)
+		if err = htfOpt(vc); err != nil {
+			return fmt.Errorf("apply verifier htf option: %w", err)
+		}
+		return nil
+	}
+}
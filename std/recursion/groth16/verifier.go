@@ -1,7 +1,9 @@
 package groth16
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
@@ -21,14 +23,20 @@ import (
 	groth16backend_bw6761 "github.com/consensys/gnark/backend/groth16/bw6-761"
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/bits"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/math/emulated/emparams"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/recursion"
+	"golang.org/x/exp/slices"
 )
 
 // Proof is a typed Groth16 proof of SNARK. Use [ValueOfProof] to initialize the
@@ -36,6 +44,12 @@ import (
 type Proof[G1El algebra.G1ElementT, G2El algebra.G2ElementT] struct {
 	Ar, Krs G1El
 	Bs      G2El
+
+	// Commitments are the Pedersen commitments to the committed wires, one for
+	// every call to api.Commit in the inner circuit.
+	Commitments []G1El
+	// CommitmentPok is the batched proof of knowledge of the commitments.
+	CommitmentPok G1El
 }
 
 // ValueOfProof returns the typed witness of the native proof. It returns an
@@ -52,6 +66,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bn254.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bn254.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bn254.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bn254.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bn254.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bn254.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls12377.Proof)
 		if !ok {
@@ -60,6 +79,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bls12377.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bls12377.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bls12377.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bls12377.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bls12377.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bls12377.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls12381.Proof)
 		if !ok {
@@ -68,6 +92,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bls12381.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bls12381.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bls12381.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bls12381.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bls12381.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bls12381.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls24315.Proof)
 		if !ok {
@@ -76,6 +105,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bls24315.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bls24315.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bls24315.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bls24315.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bls24315.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bls24315.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bw6761.G1Affine, sw_bw6761.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bw6761.Proof)
 		if !ok {
@@ -84,12 +118,60 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bw6761.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bw6761.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bw6761.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bw6761.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bw6761.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bw6761.NewG1Affine(tProof.CommitmentPok)
 	default:
 		return ret, fmt.Errorf("unknown parametric type combination")
 	}
 	return ret, nil
 }
 
+// PlaceholderProof returns a placeholder proof witness to be used for compiling
+// the outer circuit for witness alignment. It allocates space for the
+// commitments used in the inner circuit.
+func PlaceholderProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](ccs constraint.ConstraintSystem) Proof[G1El, G2El] {
+	commitmentWires, _ := commitmentInfo(ccs)
+	return Proof[G1El, G2El]{
+		Commitments: make([]G1El, len(commitmentWires)),
+	}
+}
+
+// commitmentInfo returns the wire indices of the commitments in the
+// constraint system and for every commitment the indices of the public inputs
+// and previous commitments committed to. The indices match the ones in the
+// native verifying key.
+func commitmentInfo(ccs constraint.ConstraintSystem) ([]int, [][]int) {
+	commitments, ok := ccs.GetCommitments().(constraint.Groth16Commitments)
+	if !ok {
+		return nil, nil
+	}
+	commitmentWires := commitments.CommitmentIndexes()
+	return commitmentWires, commitments.GetPublicAndCommitmentCommitted(commitmentWires, ccs.GetNbPublicVariables())
+}
+
+// decodeCommitmentKey extracts the G2 points of the native Pedersen verifying
+// key. The fields of the key are not exported so we go through the raw
+// serialization instead.
+func decodeCommitmentKey[D interface{ Decode(any) error }, O any](key interface {
+	WriteRawTo(io.Writer) (int64, error)
+}, newDecoder func(io.Reader, ...O) D, g, gRootSigmaNeg any) error {
+	var buf bytes.Buffer
+	if _, err := key.WriteRawTo(&buf); err != nil {
+		return fmt.Errorf("serialize: %w", err)
+	}
+	dec := newDecoder(&buf)
+	if err := dec.Decode(g); err != nil {
+		return fmt.Errorf("decode g: %w", err)
+	}
+	if err := dec.Decode(gRootSigmaNeg); err != nil {
+		return fmt.Errorf("decode gRootSigmaNeg: %w", err)
+	}
+	return nil
+}
+
 // VerifyingKey is a typed Groth16 verifying key for checking SNARK proofs. For
 // witness creation use the method [ValueOfVerifyingKey] and for stub
 // placeholder use [PlaceholderVerifyingKey].
@@ -97,6 +179,15 @@ type VerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra
 	E  GtEl
 	G1 struct{ K []G1El }
 	G2 struct{ GammaNeg, DeltaNeg G2El }
+
+	// CommitmentKey is the Pedersen verifying key for checking the proof of
+	// knowledge of the commitments. It is not used when the inner circuit does
+	// not have any commitments.
+	CommitmentKey struct{ G, GRootSigmaNeg G2El }
+	// PublicAndCommitmentCommitted are the indices of the public inputs and
+	// previous commitments committed to in every commitment. It is fixed at
+	// circuit compile time.
+	PublicAndCommitmentCommitted [][]int
 }
 
 // PlaceholderVerifyingKey returns an empty verifying key for a given compiled
@@ -104,19 +195,20 @@ type VerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra
 // public inputs and commitments used, this method allocates sufficient space
 // regardless of the actual verifying key.
 func PlaceholderVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](ccs constraint.ConstraintSystem) VerifyingKey[G1El, G2El, GtEl] {
+	commitmentWires, publicAndCommitmentCommitted := commitmentInfo(ccs)
 	return VerifyingKey[G1El, G2El, GtEl]{
 		G1: struct{ K []G1El }{
-			K: make([]G1El, ccs.GetNbPublicVariables()),
+			K: make([]G1El, ccs.GetNbPublicVariables()+len(commitmentWires)),
 		},
+		PublicAndCommitmentCommitted: publicAndCommitmentCommitted,
 	}
 }
 
+// PlaceholderVerifyingKeyFixed returns an empty verifying key for a given
+// compiled constraint system when the verifying key is given with
+// precomputations using [ValueOfVerifyingKeyFixed].
 func PlaceholderVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](ccs constraint.ConstraintSystem) VerifyingKey[G1El, G2El, GtEl] {
-	vk := VerifyingKey[G1El, G2El, GtEl]{
-		G1: struct{ K []G1El }{
-			K: make([]G1El, ccs.GetNbPublicVariables()),
-		},
-	}
+	vk := PlaceholderVerifyingKey[G1El, G2El, GtEl](ccs)
 	switch s := any(&vk).(type) {
 	case *VerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine, sw_bw6761.GTEl]:
 		s.G2 = struct {
@@ -158,6 +250,13 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bn254.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bn254.NewG2Affine(gammaNeg)
+		var cmtKey [2]bn254.G2Affine
+		if err := decodeCommitmentKey(&tVk.CommitmentKey, bn254.NewDecoder, &cmtKey[0], &cmtKey[1]); err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+		s.CommitmentKey.G = sw_bn254.NewG2Affine(cmtKey[0])
+		s.CommitmentKey.GRootSigmaNeg = sw_bn254.NewG2Affine(cmtKey[1])
+		s.PublicAndCommitmentCommitted = tVk.PublicAndCommitmentCommitted
 	case *VerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]:
 		tVk, ok := vk.(*groth16backend_bls12377.VerifyingKey)
 		if !ok {
@@ -178,6 +277,13 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bls12377.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bls12377.NewG2Affine(gammaNeg)
+		var cmtKey [2]bls12377.G2Affine
+		if err := decodeCommitmentKey(&tVk.CommitmentKey, bls12377.NewDecoder, &cmtKey[0], &cmtKey[1]); err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+		s.CommitmentKey.G = sw_bls12377.NewG2Affine(cmtKey[0])
+		s.CommitmentKey.GRootSigmaNeg = sw_bls12377.NewG2Affine(cmtKey[1])
+		s.PublicAndCommitmentCommitted = tVk.PublicAndCommitmentCommitted
 	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]:
 		tVk, ok := vk.(*groth16backend_bls12381.VerifyingKey)
 		if !ok {
@@ -198,6 +304,13 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bls12381.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bls12381.NewG2Affine(gammaNeg)
+		var cmtKey [2]bls12381.G2Affine
+		if err := decodeCommitmentKey(&tVk.CommitmentKey, bls12381.NewDecoder, &cmtKey[0], &cmtKey[1]); err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+		s.CommitmentKey.G = sw_bls12381.NewG2Affine(cmtKey[0])
+		s.CommitmentKey.GRootSigmaNeg = sw_bls12381.NewG2Affine(cmtKey[1])
+		s.PublicAndCommitmentCommitted = tVk.PublicAndCommitmentCommitted
 	case *VerifyingKey[sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT]:
 		tVk, ok := vk.(*groth16backend_bls24315.VerifyingKey)
 		if !ok {
@@ -218,6 +331,13 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bls24315.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bls24315.NewG2Affine(gammaNeg)
+		var cmtKey [2]bls24315.G2Affine
+		if err := decodeCommitmentKey(&tVk.CommitmentKey, bls24315.NewDecoder, &cmtKey[0], &cmtKey[1]); err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+		s.CommitmentKey.G = sw_bls24315.NewG2Affine(cmtKey[0])
+		s.CommitmentKey.GRootSigmaNeg = sw_bls24315.NewG2Affine(cmtKey[1])
+		s.PublicAndCommitmentCommitted = tVk.PublicAndCommitmentCommitted
 	case *VerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine, sw_bw6761.GTEl]:
 		tVk, ok := vk.(*groth16backend_bw6761.VerifyingKey)
 		if !ok {
@@ -238,15 +358,24 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bw6761.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bw6761.NewG2Affine(gammaNeg)
+		var cmtKey [2]bw6761.G2Affine
+		if err := decodeCommitmentKey(&tVk.CommitmentKey, bw6761.NewDecoder, &cmtKey[0], &cmtKey[1]); err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+		s.CommitmentKey.G = sw_bw6761.NewG2Affine(cmtKey[0])
+		s.CommitmentKey.GRootSigmaNeg = sw_bw6761.NewG2Affine(cmtKey[1])
+		s.PublicAndCommitmentCommitted = tVk.PublicAndCommitmentCommitted
 	default:
 		return ret, fmt.Errorf("unknown parametric type combination")
 	}
 	return ret, nil
 }
 
-// ValueOfVerifyingKey initializes witness from the given Groth16 verifying key.
-// It returns an error if there is a mismatch between the type parameters and
-// the provided native verifying key.
+// ValueOfVerifyingKeyFixed initializes witness from the given Groth16 verifying
+// key with precomputations for the fixed G2 elements. It returns an error if
+// there is a mismatch between the type parameters and the provided native
+// verifying key or if precomputation is not supported for the type
+// parameters. Currently only BW6-761 is supported.
 func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](vk groth16.VerifyingKey) (VerifyingKey[G1El, G2El, GtEl], error) {
 	var ret VerifyingKey[G1El, G2El, GtEl]
 	switch s := any(&ret).(type) {
@@ -350,8 +479,15 @@ func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT,
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bw6761.NewG2AffineFixed(deltaNeg)
 		s.G2.GammaNeg = sw_bw6761.NewG2AffineFixed(gammaNeg)
+		var cmtKey [2]bw6761.G2Affine
+		if err := decodeCommitmentKey(&tVk.CommitmentKey, bw6761.NewDecoder, &cmtKey[0], &cmtKey[1]); err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+		s.CommitmentKey.G = sw_bw6761.NewG2Affine(cmtKey[0])
+		s.CommitmentKey.GRootSigmaNeg = sw_bw6761.NewG2Affine(cmtKey[1])
+		s.PublicAndCommitmentCommitted = tVk.PublicAndCommitmentCommitted
 	default:
-		return ret, fmt.Errorf("unknown parametric type combination")
+		return ret, fmt.Errorf("precomputation not supported for %T", ret)
 	}
 	return ret, nil
 }
@@ -434,13 +570,18 @@ func ValueOfWitness[FR emulated.FieldParams](w witness.Witness) (Witness[FR], er
 
 // Verifier verifies Groth16 proofs.
 type Verifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
-	curve   algebra.Curve[FR, G1El]
-	pairing algebra.Pairing[G1El, G2El, GtEl]
+	api       frontend.API
+	scalarApi *emulated.Field[FR]
+	curve     algebra.Curve[FR, G1El]
+	pairing   algebra.Pairing[G1El, G2El, GtEl]
 }
 
 // NewVerifier returns a new [Verifier] instance using the curve and pairing
 // interfaces. Use methods [algebra.GetCurve] and [algebra.GetPairing] to
 // initialize the instances.
+//
+// The returned verifier does not support inner proofs with commitments. For
+// that use [NewVerifierWithCommitments] instead.
 func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](curve algebra.Curve[FR, G1El], pairing algebra.Pairing[G1El, G2El, GtEl]) *Verifier[FR, G1El, G2El, GtEl] {
 	return &Verifier[FR, G1El, G2El, GtEl]{
 		curve:   curve,
@@ -448,22 +589,93 @@ func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.
 	}
 }
 
+// NewVerifierWithCommitments returns a new [Verifier] instance which also
+// supports verifying inner proofs with Pedersen commitments. The curve and
+// pairing interfaces are initialized using [algebra.GetCurve] and
+// [algebra.GetPairing].
+func NewVerifierWithCommitments[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](api frontend.API) (*Verifier[FR, G1El, G2El, GtEl], error) {
+	curve, err := algebra.GetCurve[FR, G1El](api)
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	pairing, err := algebra.GetPairing[G1El, G2El, GtEl](api)
+	if err != nil {
+		return nil, fmt.Errorf("new pairing: %w", err)
+	}
+	f, err := emulated.NewField[FR](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalars: %w", err)
+	}
+	return &Verifier[FR, G1El, G2El, GtEl]{
+		api:       api,
+		scalarApi: f,
+		curve:     curve,
+		pairing:   pairing,
+	}, nil
+}
+
 // AssertProof asserts that the SNARK proof holds for the given witness and
 // verifying key.
+//
+// If the inner circuit uses commitments, then the native prover and verifier
+// have to be initialized with the options returned by
+// [GetNativeProverOptions] and [GetNativeVerifierOptions] so that the
+// commitments are hashed to the scalar field in a way which is efficient to
+// compute in-circuit.
 func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El, GtEl], proof Proof[G1El, G2El], witness Witness[FR]) error {
+	var fr FR
+	if len(proof.Commitments) != len(vk.PublicAndCommitmentCommitted) {
+		return fmt.Errorf("invalid number of commitments, got %d, expected %d", len(proof.Commitments), len(vk.PublicAndCommitmentCommitted))
+	}
 	inP := make([]*G1El, len(vk.G1.K)-1) // first is for the one wire, we add it manually after MSM
 	for i := range inP {
 		inP[i] = &vk.G1.K[i+1]
 	}
-	inS := make([]*emulated.Element[FR], len(witness.Public))
-	for i := range inS {
+	inS := make([]*emulated.Element[FR], len(witness.Public), len(witness.Public)+len(proof.Commitments))
+	for i := range witness.Public {
 		inS[i] = &witness.Public[i]
 	}
+
+	if len(proof.Commitments) > 0 {
+		if v.api == nil {
+			return fmt.Errorf("verifier does not support commitments, use NewVerifierWithCommitments")
+		}
+		// the commitment is hashed together with the committed public inputs
+		// from its serialized form, which does not necessarily fit into the
+		// native field even if it coincides with the scalar field. Thus we
+		// always partition the inputs, see [GetNativeProverOptions].
+		hashToField, err := recursion.NewHashPartitioned(v.api, fr.Modulus(), true)
+		if err != nil {
+			return fmt.Errorf("hash to field: %w", err)
+		}
+		for i := range vk.PublicAndCommitmentCommitted { // solveCommitmentWire
+			hashToField.Write(v.curve.MarshalG1(proof.Commitments[i])...)
+			for _, j := range vk.PublicAndCommitmentCommitted[i] {
+				// the indices also include the one wire
+				hashToField.Write(v.curve.MarshalScalar(*inS[j-1])...)
+			}
+			hashedCmt := hashToField.Sum()
+			hashToField.Reset()
+			hashedCmtBits := bits.ToBinary(v.api, hashedCmt, bits.WithNbDigits(fr.Modulus().BitLen()))
+			inS = append(inS, v.scalarApi.FromBits(hashedCmtBits...))
+		}
+		folded, err := v.foldCommitments(proof.Commitments, inS[len(witness.Public):])
+		if err != nil {
+			return fmt.Errorf("fold commitments: %w", err)
+		}
+		if err = v.pairing.PairingCheck([]*G1El{folded, &proof.CommitmentPok}, []*G2El{&vk.CommitmentKey.G, &vk.CommitmentKey.GRootSigmaNeg}); err != nil {
+			return fmt.Errorf("commitment proof of knowledge: %w", err)
+		}
+	}
+
 	kSum, err := v.curve.MultiScalarMul(inP, inS)
 	if err != nil {
 		return fmt.Errorf("multi scalar mul: %w", err)
 	}
 	kSum = v.curve.Add(kSum, &vk.G1.K[0])
+	for i := range proof.Commitments {
+		kSum = v.curve.Add(kSum, &proof.Commitments[i])
+	}
 	pairing, err := v.pairing.Pair([]*G1El{kSum, &proof.Krs, &proof.Ar}, []*G2El{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, &proof.Bs})
 	if err != nil {
 		return fmt.Errorf("pairing: %w", err)
@@ -471,3 +683,56 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El,
 	v.pairing.AssertIsEqual(pairing, &vk.E)
 	return nil
 }
+
+// foldCommitments returns the random linear combination ∑ rⁱ Cᵢ of the
+// commitments to be checked against the batched proof of knowledge. The
+// challenge r is derived as in gnark-crypto, i.e. it is the SHA2-256 digest of
+// the challenge name "r" and the serialized hashes of the commitments.
+func (v *Verifier[FR, G1El, G2El, GtEl]) foldCommitments(commitments []G1El, hashes []*emulated.Element[FR]) (*G1El, error) {
+	var fr FR
+	if len(commitments) == 1 {
+		// no need to fold
+		return &commitments[0], nil
+	}
+	uapi, err := uints.New[uints.U32](v.api)
+	if err != nil {
+		return nil, fmt.Errorf("new uints api: %w", err)
+	}
+	h, err := sha2.New(v.api)
+	if err != nil {
+		return nil, fmt.Errorf("new sha2: %w", err)
+	}
+	h.Write(uints.NewU8Array([]byte("r")))
+	for i := range hashes {
+		// the marshalled scalar is in big-endian bit order
+		bts := v.curve.MarshalScalar(*hashes[i])
+		for j := 0; j < len(bts); j += 8 {
+			b := slices.Clone(bts[j : j+8])
+			slices.Reverse(b)
+			h.Write([]uints.U8{uapi.ByteValueOf(bits.FromBinary(v.api, b))})
+		}
+	}
+	digest := h.Sum()
+	// the digest is interpreted as a big-endian integer reduced modulo r.
+	// We pad the decomposition so that it fits into the limbs of the emulated
+	// element.
+	nbBits := int(fr.NbLimbs() * fr.BitsPerLimb())
+	challengeBits := make([]frontend.Variable, 0, nbBits)
+	for i := len(digest) - 1; i >= 0; i-- {
+		challengeBits = append(challengeBits, bits.ToBinary(v.api, digest[i].Val, bits.WithNbDigits(8))...)
+	}
+	for len(challengeBits) < nbBits {
+		challengeBits = append(challengeBits, 0)
+	}
+	challenge := v.scalarApi.Reduce(v.scalarApi.FromBits(challengeBits...))
+
+	points := make([]*G1El, len(commitments))
+	scalars := make([]*emulated.Element[FR], len(commitments))
+	points[0] = &commitments[0]
+	scalars[0] = v.scalarApi.One()
+	for i := 1; i < len(commitments); i++ {
+		points[i] = &commitments[i]
+		scalars[i] = v.scalarApi.Mul(scalars[i-1], challenge)
+	}
+	return v.curve.MultiScalarMul(points, scalars)
+}
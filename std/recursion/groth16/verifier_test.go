@@ -1,6 +1,7 @@
 package groth16
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"math/big"
 	"testing"
@@ -32,7 +33,6 @@ import (
 	"github.com/consensys/gnark/test"
 )
 
-// TODO: placeholder circuits for when we have implemented commitment verification for the verifier.
 type InnerCircuitSHA2 struct {
 	PreImage [9]uints.U8
 	Digest   [32]uints.U8 `gnark:",public"`
@@ -107,6 +107,51 @@ func getInner(assert *test.Assert, field *big.Int) (constraint.ConstraintSystem,
 	return innerCcs, innerVK, innerPubWitness, innerProof
 }
 
+type InnerCircuitCommitment struct {
+	P, Q frontend.Variable
+	N    frontend.Variable `gnark:",public"`
+}
+
+func (c *InnerCircuitCommitment) Define(api frontend.API) error {
+	res := api.Mul(c.P, c.Q)
+	api.AssertIsEqual(res, c.N)
+	committer, ok := api.(frontend.Committer)
+	if !ok {
+		return fmt.Errorf("builder does not implement frontend.Committer")
+	}
+	// first commitment commits to the public input and the second commitment
+	// to the first commitment.
+	cmt1, err := committer.Commit(c.P, c.N)
+	if err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	api.AssertIsDifferent(cmt1, 0)
+	cmt2, err := committer.Commit(c.Q, cmt1)
+	if err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	api.AssertIsDifferent(cmt2, 0)
+	return nil
+}
+
+func getInnerCommitment(assert *test.Assert, field, outer *big.Int, circuit, assignment frontend.Circuit) (constraint.ConstraintSystem, groth16.VerifyingKey, witness.Witness, groth16.Proof) {
+	innerCcs, err := frontend.Compile(field, r1cs.NewBuilder, circuit)
+	assert.NoError(err)
+	innerPK, innerVK, err := groth16.Setup(innerCcs)
+	assert.NoError(err)
+
+	// inner proof
+	innerWitness, err := frontend.NewWitness(assignment, field)
+	assert.NoError(err)
+	innerProof, err := groth16.Prove(innerCcs, innerPK, innerWitness, GetNativeProverOptions(outer, field))
+	assert.NoError(err)
+	innerPubWitness, err := innerWitness.Public()
+	assert.NoError(err)
+	err = groth16.Verify(innerProof, innerVK, innerPubWitness, GetNativeVerifierOptions(outer, field))
+	assert.NoError(err)
+	return innerCcs, innerVK, innerPubWitness, innerProof
+}
+
 type OuterCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
 	Proof        Proof[G1El, G2El]
 	VerifyingKey VerifyingKey[G1El, G2El, GtEl]
@@ -175,6 +220,170 @@ func TestBLS12InBW6(t *testing.T) {
 	assert.CheckCircuit(outerCircuit, test.WithValidAssignment(outerAssignment), test.WithCurves(ecc.BW6_761))
 }
 
+type OuterCircuitCommitment[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Proof        Proof[G1El, G2El]
+	VerifyingKey VerifyingKey[G1El, G2El, GtEl]
+	InnerWitness Witness[FR]
+}
+
+func (c *OuterCircuitCommitment[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	verifier, err := NewVerifierWithCommitments[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	return verifier.AssertProof(c.VerifyingKey, c.Proof, c.InnerWitness)
+}
+
+func TestBLS12InBW6Commitment(t *testing.T) {
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerCommitment(assert, ecc.BLS12_377.ScalarField(), ecc.BW6_761.ScalarField(),
+		&InnerCircuitCommitment{}, &InnerCircuitCommitment{P: 3, Q: 5, N: 15})
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[sw_bls12377.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuitCommitment[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+		InnerWitness: PlaceholderWitness[sw_bls12377.ScalarField](innerCcs),
+		Proof:        PlaceholderProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+		VerifyingKey: PlaceholderVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](innerCcs),
+	}
+	outerAssignment := &OuterCircuitCommitment[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+		VerifyingKey: circuitVk,
+	}
+	assert.CheckCircuit(outerCircuit, test.WithValidAssignment(outerAssignment), test.WithCurves(ecc.BW6_761))
+}
+
+func TestBN254InBN254Commitment(t *testing.T) {
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerCommitment(assert, ecc.BN254.ScalarField(), ecc.BN254.ScalarField(),
+		&InnerCircuitSHA2{}, &InnerCircuitSHA2{
+			PreImage: [9]uints.U8(uints.NewU8Array([]byte("recursion"))),
+			Digest:   [32]uints.U8(uints.NewU8Array(sha256Sum([]byte("recursion")))),
+		})
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[sw_bn254.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[sw_bn254.G1Affine, sw_bn254.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuitCommitment[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]{
+		InnerWitness: PlaceholderWitness[sw_bn254.ScalarField](innerCcs),
+		Proof:        PlaceholderProof[sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		VerifyingKey: PlaceholderVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerCcs),
+	}
+	outerAssignment := &OuterCircuitCommitment[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+		VerifyingKey: circuitVk,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestBLS12InBW6CommitmentInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerCommitment(assert, ecc.BLS12_377.ScalarField(), ecc.BW6_761.ScalarField(),
+		&InnerCircuitCommitment{}, &InnerCircuitCommitment{P: 3, Q: 5, N: 15})
+	_, _, g1, _ := bls12377.Generators()
+
+	circuitVk, err := ValueOfVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[sw_bls12377.ScalarField](innerWitness)
+	assert.NoError(err)
+
+	newOuterCircuit := func() *OuterCircuitCommitment[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT] {
+		return &OuterCircuitCommitment[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+			InnerWitness: PlaceholderWitness[sw_bls12377.ScalarField](innerCcs),
+			Proof:        PlaceholderProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+			VerifyingKey: PlaceholderVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](innerCcs),
+		}
+	}
+	isSolved := func(outerCircuit frontend.Circuit, proof groth16.Proof) error {
+		circuitProof, err := ValueOfProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](proof)
+		assert.NoError(err)
+		outerAssignment := &OuterCircuitCommitment[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+			InnerWitness: circuitWitness,
+			Proof:        circuitProof,
+			VerifyingKey: circuitVk,
+		}
+		return test.IsSolved(outerCircuit, outerAssignment, ecc.BW6_761.ScalarField())
+	}
+	cloneProof := func() *groth16backend_bls12377.Proof {
+		proof := *innerProof.(*groth16backend_bls12377.Proof)
+		proof.Commitments = append([]bls12377.G1Affine(nil), proof.Commitments...)
+		return &proof
+	}
+
+	assert.Run(func(assert *test.Assert) {
+		err := isSolved(newOuterCircuit(), innerProof)
+		assert.NoError(err)
+	}, "valid")
+	assert.Run(func(assert *test.Assert) {
+		proof := cloneProof()
+		proof.Commitments[0].Add(&proof.Commitments[0], &g1)
+		err := isSolved(newOuterCircuit(), proof)
+		assert.Error(err)
+	}, "commitment")
+	assert.Run(func(assert *test.Assert) {
+		proof := cloneProof()
+		proof.CommitmentPok.Add(&proof.CommitmentPok, &g1)
+		err := isSolved(newOuterCircuit(), proof)
+		assert.Error(err)
+	}, "commitment-pok")
+	assert.Run(func(assert *test.Assert) {
+		// omit the public input from the first commitment hash. The derived
+		// commitment value does not match the one used by the prover.
+		outerCircuit := newOuterCircuit()
+		assert.NotEmpty(outerCircuit.VerifyingKey.PublicAndCommitmentCommitted[0])
+		outerCircuit.VerifyingKey.PublicAndCommitmentCommitted = [][]int{
+			{},
+			outerCircuit.VerifyingKey.PublicAndCommitmentCommitted[1],
+		}
+		err := isSolved(outerCircuit, innerProof)
+		assert.Error(err)
+	}, "public-hash")
+}
+
+func TestValueOfVerifyingKeyFixedCommitment(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		ccs, err := frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &InnerCircuitCommitment{})
+		assert.NoError(err)
+		_, vk, err := groth16.Setup(ccs)
+		assert.NoError(err)
+		vvk, err := ValueOfVerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine, sw_bw6761.GTEl](vk)
+		assert.NoError(err)
+		fvk, err := ValueOfVerifyingKeyFixed[sw_bw6761.G1Affine, sw_bw6761.G2Affine, sw_bw6761.GTEl](vk)
+		assert.NoError(err)
+		assert.Equal(vvk.CommitmentKey, fvk.CommitmentKey)
+		assert.Equal(vvk.PublicAndCommitmentCommitted, fvk.PublicAndCommitmentCommitted)
+		assert.Len(fvk.PublicAndCommitmentCommitted, 2)
+	}, "bw6761")
+	assert.Run(func(assert *test.Assert) {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &InnerCircuitCommitment{})
+		assert.NoError(err)
+		_, vk, err := groth16.Setup(ccs)
+		assert.NoError(err)
+		_, err = ValueOfVerifyingKeyFixed[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](vk)
+		assert.Error(err)
+	}, "bn254")
+}
+
+func sha256Sum(in []byte) []byte {
+	dgst := sha256.Sum256(in)
+	return dgst[:]
+}
+
 type WitnessCircut struct {
 	A emulated.Element[emparams.Secp256k1Fr] `gnark:",public"`
 }
@@ -12,15 +12,15 @@ import (
 // initialize the configuration suitable for in-circuit verification.
 func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
 	return func(pc *backend.ProverConfig) error {
-		fsProverHasher, err := recursion.NewShort(outer, field)
+		fsProverHasher, err := recursion.NewShortPartitioned(outer, field)
 		if err != nil {
 			return fmt.Errorf("get prover fs hash: %w", err)
 		}
-		kzgProverHasher, err := recursion.NewShort(outer, field)
+		kzgProverHasher, err := recursion.NewShortPartitioned(outer, field)
 		if err != nil {
 			return fmt.Errorf("get prover kzg hash: %w", err)
 		}
-		htfProverHasher, err := recursion.NewShort(outer, field)
+		htfProverHasher, err := recursion.NewShortPartitioned(outer, field)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
@@ -45,15 +45,15 @@ func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
 // configuration to be compatible with in-circuit verification.
 func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
 	return func(vc *backend.VerifierConfig) error {
-		fsVerifierHasher, err := recursion.NewShort(outer, field)
+		fsVerifierHasher, err := recursion.NewShortPartitioned(outer, field)
 		if err != nil {
 			return fmt.Errorf("get verifier fs hash: %w", err)
 		}
-		kzgVerifierHasher, err := recursion.NewShort(outer, field)
+		kzgVerifierHasher, err := recursion.NewShortPartitioned(outer, field)
 		if err != nil {
 			return fmt.Errorf("get verifier kzg hash: %w", err)
 		}
-		htfVerifierHasher, err := recursion.NewShort(outer, field)
+		htfVerifierHasher, err := recursion.NewShortPartitioned(outer, field)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
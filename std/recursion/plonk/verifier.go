@@ -247,7 +247,7 @@ func ValueOfProof[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra
 		if err != nil {
 			return ret, fmt.Errorf("z shifted opening proof value assignment: %w", err)
 		}
-		// TODO: missing bls12-381, bls24315, bn254, bls24317
+		// TODO: missing bls24317, there is no in-circuit algebra for it yet
 	default:
 		return ret, fmt.Errorf("unknown parametric type combination: %T", ret)
 	}
@@ -863,7 +863,7 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 	}
 
 	if len(vk.CommitmentConstraintIndexes) > 0 {
-		hashToField, err := recursion.NewHash(v.api, fr.Modulus(), true)
+		hashToField, err := recursion.NewHashPartitioned(v.api, fr.Modulus(), true)
 		if err != nil {
 			return nil, nil, nil, err
 		}
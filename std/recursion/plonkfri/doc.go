@@ -0,0 +1,10 @@
+// Package plonkfri implements in-circuit PLONK-FRI verifier.
+//
+// The verifier checks proofs of the [github.com/consensys/gnark/backend/plonkfri]
+// backend. As the FRI commitments are checked using native field arithmetic,
+// the inner proof must be defined over the scalar field of the outer circuit.
+// The native setup, prover and verifier have to be initialized with the options
+// returned by [GetNativeSetupOptions], [GetNativeProverOptions] and
+// [GetNativeVerifierOptions] so that the commitments and challenges are
+// computed using a hash function which is efficient in-circuit.
+package plonkfri
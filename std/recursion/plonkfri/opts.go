@@ -0,0 +1,79 @@
+package plonkfri
+
+import (
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	cryptomimc "github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// GetNativeSetupOptions returns PLONK-FRI setup options for the native setup
+// to initialize the configuration suitable for in-circuit verification.
+func GetNativeSetupOptions(field *big.Int) backend.SetupOption {
+	return func(sc *backend.SetupConfig) error {
+		ioppHasher, err := nativeHash(field)
+		if err != nil {
+			return fmt.Errorf("get iopp hash: %w", err)
+		}
+		ioppOpt := backend.WithSetupIOPPHashFunction(ioppHasher)
+		if err = ioppOpt(sc); err != nil {
+			return fmt.Errorf("apply setup iopp hash option: %w", err)
+		}
+		return nil
+	}
+}
+
+// GetNativeProverOptions returns PLONK-FRI prover options for the native
+// prover to initialize the configuration suitable for in-circuit verification.
+func GetNativeProverOptions(field *big.Int) backend.ProverOption {
+	return func(pc *backend.ProverConfig) error {
+		fsProverHasher, err := nativeHash(field)
+		if err != nil {
+			return fmt.Errorf("get prover fs hash: %w", err)
+		}
+		fsOpt := backend.WithProverChallengeHashFunction(fsProverHasher)
+		if err = fsOpt(pc); err != nil {
+			return fmt.Errorf("apply prover fs hash option: %w", err)
+		}
+		return nil
+	}
+}
+
+// GetNativeVerifierOptions returns PLONK-FRI verifier options to initialize
+// the configuration to be compatible with in-circuit verification.
+func GetNativeVerifierOptions(field *big.Int) backend.VerifierOption {
+	return func(vc *backend.VerifierConfig) error {
+		fsVerifierHasher, err := nativeHash(field)
+		if err != nil {
+			return fmt.Errorf("get verifier fs hash: %w", err)
+		}
+		fsOpt := backend.WithVerifierChallengeHashFunction(fsVerifierHasher)
+		if err = fsOpt(vc); err != nil {
+			return fmt.Errorf("apply verifier fs hash option: %w", err)
+		}
+		return nil
+	}
+}
+
+// nativeHash returns the native MiMC hash function over field. It matches the
+// in-circuit MiMC hash function.
+func nativeHash(field *big.Int) (hash.Hash, error) {
+	switch utils.FieldToCurve(field) {
+	case ecc.BN254:
+		return cryptomimc.MIMC_BN254.New(), nil
+	case ecc.BLS12_377:
+		return cryptomimc.MIMC_BLS12_377.New(), nil
+	case ecc.BLS12_381:
+		return cryptomimc.MIMC_BLS12_381.New(), nil
+	case ecc.BW6_761:
+		return cryptomimc.MIMC_BW6_761.New(), nil
+	case ecc.BLS24_315:
+		return cryptomimc.MIMC_BLS24_315.New(), nil
+	default:
+		return nil, fmt.Errorf("no default mimc for scalar field: %s", field.String())
+	}
+}
@@ -0,0 +1,766 @@
+package plonkfri
+
+import (
+	"fmt"
+	"math/big"
+	stdbits "math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fft_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	fri_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fri"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fft_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	fri_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fft_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	fri_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fri"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	fft_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	fri_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/fri"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	fft_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/fft"
+	fri_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/fri"
+	backend_plonkfri "github.com/consensys/gnark/backend/plonkfri"
+	plonkfribackend_bls12377 "github.com/consensys/gnark/backend/plonkfri/bls12-377"
+	plonkfribackend_bls12381 "github.com/consensys/gnark/backend/plonkfri/bls12-381"
+	plonkfribackend_bls24315 "github.com/consensys/gnark/backend/plonkfri/bls24-315"
+	plonkfribackend_bn254 "github.com/consensys/gnark/backend/plonkfri/bn254"
+	plonkfribackend_bw6761 "github.com/consensys/gnark/backend/plonkfri/bw6-761"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/utils"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+	"github.com/consensys/gnark/std/commitments/fri"
+	fiatshamir "github.com/consensys/gnark/std/fiat-shamir"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+// same constant as in gnark-crypto
+const rho = 8
+
+// OpeningProof is an opening of a committed polynomial at a single point. The
+// first entry of the Merkle path is the claimed value of the polynomial, the
+// Merkle root is taken from the proof of proximity of the polynomial.
+type OpeningProof struct {
+	Path []frontend.Variable
+}
+
+// Proof is a typed PLONK-FRI proof of SNARK. Use [ValueOfProof] to initialize
+// the witness from the native proof. Use [PlaceholderProof] to initialize the
+// placeholder witness for compiling the circuit.
+type Proof struct {
+
+	// Proofs of proximity of the solution vectors
+	LRO [3]fri.ProofOfProximity
+
+	// Proof of proximity of Z, the permutation polynomial
+	Z fri.ProofOfProximity
+
+	// Proofs of proximity of h1, h2, h3 such that h = h1 + X**(n+2)*h2 + X**2(n+2)*h3 is the quotient polynomial
+	H [3]fri.ProofOfProximity
+
+	// Openings of l, r, o at zeta
+	OpeningsLRO [3]OpeningProof
+
+	// Openings of Z at zeta and zeta*mu
+	OpeningsZ [2]OpeningProof
+
+	// Openings of h1, h2, h3 at zeta
+	OpeningsH [3]OpeningProof
+
+	// Openings of ql, qr, qm, qo, qk at zeta
+	OpeningsQ [5]OpeningProof
+
+	// Openings of s1, s2, s3 at zeta
+	OpeningsS [3]OpeningProof
+
+	// Openings of id1, id2, id3 at zeta
+	OpeningsId [3]OpeningProof
+}
+
+// ValueOfProof returns the typed witness of the native proof. It returns an
+// error if the native proof is over a curve which is not supported.
+func ValueOfProof(proof backend_plonkfri.Proof) (Proof, error) {
+	var ret Proof
+	switch tProof := proof.(type) {
+	case *plonkfribackend_bn254.Proof:
+		for i := range ret.LRO {
+			ret.LRO[i] = valueOfProofOfProximityBN254(tProof.LROpp[i])
+			ret.H[i] = valueOfProofOfProximityBN254(tProof.Hpp[i])
+		}
+		ret.Z = valueOfProofOfProximityBN254(tProof.Zpp)
+		setOpenings(&ret, tProof.OpeningsLROmp[:], tProof.OpeningsZmp[:], tProof.OpeningsHmp[:], tProof.OpeningsQlQrQmQoQkincompletemp[:], tProof.OpeningsS1S2S3mp[:], tProof.OpeningsId1Id2Id3mp[:],
+			func(o fri_bn254.OpeningProof) [][]byte { return o.ProofSet })
+	case *plonkfribackend_bls12377.Proof:
+		for i := range ret.LRO {
+			ret.LRO[i] = valueOfProofOfProximityBLS12377(tProof.LROpp[i])
+			ret.H[i] = valueOfProofOfProximityBLS12377(tProof.Hpp[i])
+		}
+		ret.Z = valueOfProofOfProximityBLS12377(tProof.Zpp)
+		setOpenings(&ret, tProof.OpeningsLROmp[:], tProof.OpeningsZmp[:], tProof.OpeningsHmp[:], tProof.OpeningsQlQrQmQoQkincompletemp[:], tProof.OpeningsS1S2S3mp[:], tProof.OpeningsId1Id2Id3mp[:],
+			func(o fri_bls12377.OpeningProof) [][]byte { return o.ProofSet })
+	case *plonkfribackend_bls12381.Proof:
+		for i := range ret.LRO {
+			ret.LRO[i] = valueOfProofOfProximityBLS12381(tProof.LROpp[i])
+			ret.H[i] = valueOfProofOfProximityBLS12381(tProof.Hpp[i])
+		}
+		ret.Z = valueOfProofOfProximityBLS12381(tProof.Zpp)
+		setOpenings(&ret, tProof.OpeningsLROmp[:], tProof.OpeningsZmp[:], tProof.OpeningsHmp[:], tProof.OpeningsQlQrQmQoQkincompletemp[:], tProof.OpeningsS1S2S3mp[:], tProof.OpeningsId1Id2Id3mp[:],
+			func(o fri_bls12381.OpeningProof) [][]byte { return o.ProofSet })
+	case *plonkfribackend_bw6761.Proof:
+		for i := range ret.LRO {
+			ret.LRO[i] = valueOfProofOfProximityBW6761(tProof.LROpp[i])
+			ret.H[i] = valueOfProofOfProximityBW6761(tProof.Hpp[i])
+		}
+		ret.Z = valueOfProofOfProximityBW6761(tProof.Zpp)
+		setOpenings(&ret, tProof.OpeningsLROmp[:], tProof.OpeningsZmp[:], tProof.OpeningsHmp[:], tProof.OpeningsQlQrQmQoQkincompletemp[:], tProof.OpeningsS1S2S3mp[:], tProof.OpeningsId1Id2Id3mp[:],
+			func(o fri_bw6761.OpeningProof) [][]byte { return o.ProofSet })
+	case *plonkfribackend_bls24315.Proof:
+		for i := range ret.LRO {
+			ret.LRO[i] = valueOfProofOfProximityBLS24315(tProof.LROpp[i])
+			ret.H[i] = valueOfProofOfProximityBLS24315(tProof.Hpp[i])
+		}
+		ret.Z = valueOfProofOfProximityBLS24315(tProof.Zpp)
+		setOpenings(&ret, tProof.OpeningsLROmp[:], tProof.OpeningsZmp[:], tProof.OpeningsHmp[:], tProof.OpeningsQlQrQmQoQkincompletemp[:], tProof.OpeningsS1S2S3mp[:], tProof.OpeningsId1Id2Id3mp[:],
+			func(o fri_bls24315.OpeningProof) [][]byte { return o.ProofSet })
+	default:
+		return ret, fmt.Errorf("unknown proof type: %T", proof)
+	}
+	return ret, nil
+}
+
+// PlaceholderProof returns a placeholder proof witness to be use for compiling
+// the outer circuit for witness alignment. For actual witness assignment use
+// [ValueOfProof].
+func PlaceholderProof(ccs constraint.ConstraintSystem) Proof {
+	var ret Proof
+	size := domainSize(ccs)
+	for i := range ret.LRO {
+		ret.LRO[i] = placeholderProofOfProximity(size)
+		ret.H[i] = placeholderProofOfProximity(size)
+	}
+	ret.Z = placeholderProofOfProximity(size)
+	for _, openings := range [][]OpeningProof{ret.OpeningsLRO[:], ret.OpeningsZ[:], ret.OpeningsH[:], ret.OpeningsQ[:], ret.OpeningsS[:], ret.OpeningsId[:]} {
+		for i := range openings {
+			openings[i] = placeholderOpeningProof(size)
+		}
+	}
+	return ret
+}
+
+// VerifyingKey is a typed PLONK-FRI verification key. Use
+// [ValueOfVerifyingKey] or [PlaceholderVerifyingKey] for initializing.
+type VerifyingKey struct {
+
+	// Size circuit, that is the closest power of 2 bounding above
+	// number of constraints+number of public inputs
+	Size              uint64
+	NbPublicVariables uint64
+
+	// SizeInv is the inverse of Size, Generator is the generator of the
+	// domain of size Size and GenOpening is the generator of the domain on
+	// which the proximity is tested. They are fixed at circuit compile time.
+	SizeInv    big.Int `gnark:"-"`
+	Generator  big.Int `gnark:"-"`
+	GenOpening big.Int `gnark:"-"`
+
+	// Proofs of proximity of s1, s2, s3
+	S [3]fri.ProofOfProximity
+
+	// Proofs of proximity of id1, id2, id3
+	Id [3]fri.ProofOfProximity
+
+	// Proofs of proximity of ql, qr, qm, qo, qk prepended with as many zeroes
+	// (ones for l) as there are public inputs. In particular Qk is not complete.
+	Q [5]fri.ProofOfProximity
+}
+
+// ValueOfVerifyingKey initializes witness from the given PLONK-FRI verifying
+// key. It returns an error if the native verifying key is over a curve which is
+// not supported.
+func ValueOfVerifyingKey(vk backend_plonkfri.VerifyingKey) (VerifyingKey, error) {
+	var ret VerifyingKey
+	switch tVk := vk.(type) {
+	case *plonkfribackend_bn254.VerifyingKey:
+		ret.Size = tVk.Size
+		ret.NbPublicVariables = tVk.NbPublicVariables
+		tVk.SizeInv.BigInt(&ret.SizeInv)
+		tVk.Generator.BigInt(&ret.Generator)
+		tVk.GenOpening.BigInt(&ret.GenOpening)
+		for i := range ret.S {
+			ret.S[i] = valueOfProofOfProximityBN254(tVk.Spp[i])
+			ret.Id[i] = valueOfProofOfProximityBN254(tVk.Idpp[i])
+		}
+		for i := range ret.Q {
+			ret.Q[i] = valueOfProofOfProximityBN254(tVk.Qpp[i])
+		}
+	case *plonkfribackend_bls12377.VerifyingKey:
+		ret.Size = tVk.Size
+		ret.NbPublicVariables = tVk.NbPublicVariables
+		tVk.SizeInv.BigInt(&ret.SizeInv)
+		tVk.Generator.BigInt(&ret.Generator)
+		tVk.GenOpening.BigInt(&ret.GenOpening)
+		for i := range ret.S {
+			ret.S[i] = valueOfProofOfProximityBLS12377(tVk.Spp[i])
+			ret.Id[i] = valueOfProofOfProximityBLS12377(tVk.Idpp[i])
+		}
+		for i := range ret.Q {
+			ret.Q[i] = valueOfProofOfProximityBLS12377(tVk.Qpp[i])
+		}
+	case *plonkfribackend_bls12381.VerifyingKey:
+		ret.Size = tVk.Size
+		ret.NbPublicVariables = tVk.NbPublicVariables
+		tVk.SizeInv.BigInt(&ret.SizeInv)
+		tVk.Generator.BigInt(&ret.Generator)
+		tVk.GenOpening.BigInt(&ret.GenOpening)
+		for i := range ret.S {
+			ret.S[i] = valueOfProofOfProximityBLS12381(tVk.Spp[i])
+			ret.Id[i] = valueOfProofOfProximityBLS12381(tVk.Idpp[i])
+		}
+		for i := range ret.Q {
+			ret.Q[i] = valueOfProofOfProximityBLS12381(tVk.Qpp[i])
+		}
+	case *plonkfribackend_bw6761.VerifyingKey:
+		ret.Size = tVk.Size
+		ret.NbPublicVariables = tVk.NbPublicVariables
+		tVk.SizeInv.BigInt(&ret.SizeInv)
+		tVk.Generator.BigInt(&ret.Generator)
+		tVk.GenOpening.BigInt(&ret.GenOpening)
+		for i := range ret.S {
+			ret.S[i] = valueOfProofOfProximityBW6761(tVk.Spp[i])
+			ret.Id[i] = valueOfProofOfProximityBW6761(tVk.Idpp[i])
+		}
+		for i := range ret.Q {
+			ret.Q[i] = valueOfProofOfProximityBW6761(tVk.Qpp[i])
+		}
+	case *plonkfribackend_bls24315.VerifyingKey:
+		ret.Size = tVk.Size
+		ret.NbPublicVariables = tVk.NbPublicVariables
+		tVk.SizeInv.BigInt(&ret.SizeInv)
+		tVk.Generator.BigInt(&ret.Generator)
+		tVk.GenOpening.BigInt(&ret.GenOpening)
+		for i := range ret.S {
+			ret.S[i] = valueOfProofOfProximityBLS24315(tVk.Spp[i])
+			ret.Id[i] = valueOfProofOfProximityBLS24315(tVk.Idpp[i])
+		}
+		for i := range ret.Q {
+			ret.Q[i] = valueOfProofOfProximityBLS24315(tVk.Qpp[i])
+		}
+	default:
+		return ret, fmt.Errorf("unknown verifying key type: %T", vk)
+	}
+	return ret, nil
+}
+
+// PlaceholderVerifyingKey returns placeholder of the verification key for
+// compiling the outer circuit. It returns an error if the constraint system is
+// over a curve which is not supported.
+func PlaceholderVerifyingKey(ccs constraint.ConstraintSystem) (VerifyingKey, error) {
+	var ret VerifyingKey
+	ret.Size = domainSize(ccs)
+	ret.NbPublicVariables = uint64(ccs.GetNbPublicVariables())
+	field := ccs.Field()
+	ret.SizeInv.SetUint64(ret.Size).ModInverse(&ret.SizeInv, field)
+	switch utils.FieldToCurve(field) {
+	case ecc.BN254:
+		fft_bn254.NewDomain(ret.Size).Generator.BigInt(&ret.Generator)
+		fft_bn254.NewDomain(2 * rho * ret.Size).Generator.BigInt(&ret.GenOpening)
+	case ecc.BLS12_377:
+		fft_bls12377.NewDomain(ret.Size).Generator.BigInt(&ret.Generator)
+		fft_bls12377.NewDomain(2 * rho * ret.Size).Generator.BigInt(&ret.GenOpening)
+	case ecc.BLS12_381:
+		fft_bls12381.NewDomain(ret.Size).Generator.BigInt(&ret.Generator)
+		fft_bls12381.NewDomain(2 * rho * ret.Size).Generator.BigInt(&ret.GenOpening)
+	case ecc.BW6_761:
+		fft_bw6761.NewDomain(ret.Size).Generator.BigInt(&ret.Generator)
+		fft_bw6761.NewDomain(2 * rho * ret.Size).Generator.BigInt(&ret.GenOpening)
+	case ecc.BLS24_315:
+		fft_bls24315.NewDomain(ret.Size).Generator.BigInt(&ret.Generator)
+		fft_bls24315.NewDomain(2 * rho * ret.Size).Generator.BigInt(&ret.GenOpening)
+	default:
+		return ret, fmt.Errorf("unsupported scalar field: %s", field.String())
+	}
+	for i := range ret.S {
+		ret.S[i] = placeholderProofOfProximity(ret.Size)
+		ret.Id[i] = placeholderProofOfProximity(ret.Size)
+	}
+	for i := range ret.Q {
+		ret.Q[i] = placeholderProofOfProximity(ret.Size)
+	}
+	return ret, nil
+}
+
+// Witness is a public witness to verify the SNARK proof against. For assigning
+// witness use [ValueOfWitness] and to create stub witness for compiling use
+// [PlaceholderWitness].
+type Witness struct {
+	Public []frontend.Variable
+}
+
+// ValueOfWitness assigns a outer-circuit witness from the inner circuit
+// witness.
+func ValueOfWitness(w witness.Witness) (Witness, error) {
+	var ret Witness
+	pubw, err := w.Public()
+	if err != nil {
+		return ret, fmt.Errorf("get public witness: %w", err)
+	}
+	switch vect := pubw.Vector().(type) {
+	case fr_bn254.Vector:
+		for i := range vect {
+			ret.Public = append(ret.Public, vect[i])
+		}
+	case fr_bls12377.Vector:
+		for i := range vect {
+			ret.Public = append(ret.Public, vect[i])
+		}
+	case fr_bls12381.Vector:
+		for i := range vect {
+			ret.Public = append(ret.Public, vect[i])
+		}
+	case fr_bw6761.Vector:
+		for i := range vect {
+			ret.Public = append(ret.Public, vect[i])
+		}
+	case fr_bls24315.Vector:
+		for i := range vect {
+			ret.Public = append(ret.Public, vect[i])
+		}
+	default:
+		return ret, fmt.Errorf("unknown witness vector type: %T", vect)
+	}
+	return ret, nil
+}
+
+// PlaceholderWitness creates a stub witness which can be used to allocate the
+// variables in the circuit if the actual witness is not yet known.
+func PlaceholderWitness(ccs constraint.ConstraintSystem) Witness {
+	return Witness{
+		Public: make([]frontend.Variable, ccs.GetNbPublicVariables()),
+	}
+}
+
+// Verifier verifies PLONK-FRI proofs.
+type Verifier struct {
+	api frontend.API
+}
+
+// NewVerifier returns a new [Verifier] instance.
+func NewVerifier(api frontend.API) (*Verifier, error) {
+	if _, err := mimc.NewMiMC(api); err != nil {
+		return nil, fmt.Errorf("new hash: %w", err)
+	}
+	return &Verifier{api: api}, nil
+}
+
+// AssertProof asserts that the SNARK proof holds for the given witness and
+// verifying key.
+//
+// The verifier rebuilds the Fiat-Shamir transcript of the native verifier,
+// checks the proofs of proximity of all committed polynomials, the openings at
+// the queried point and finally the gate and permutation identities at the
+// queried point.
+func (v *Verifier) AssertProof(vk VerifyingKey, proof Proof, witness Witness) error {
+	if len(witness.Public) != int(vk.NbPublicVariables) {
+		return fmt.Errorf("invalid witness size, got %d, expected %d", len(witness.Public), vk.NbPublicVariables)
+	}
+	fsHash, err := mimc.NewMiMC(v.api)
+	if err != nil {
+		return fmt.Errorf("new fs hash: %w", err)
+	}
+	ioppHash, err := mimc.NewMiMC(v.api)
+	if err != nil {
+		return fmt.Errorf("new iopp hash: %w", err)
+	}
+	field := v.api.Compiler().Field()
+	friSize := 2 * rho * vk.Size
+	logFriSize := stdbits.TrailingZeros64(friSize)
+
+	// 0 - derive the challenges with Fiat Shamir. As in the native transcript
+	// (cf backend/plonkfri), the commitments to l, r, o, z and h are the Merkle
+	// roots of the first round of their proofs of proximity.
+	fs := fiatshamir.NewTranscript(v.api, &fsHash, []string{"gamma", "beta", "alpha", "zeta"})
+	dataFiatShamir := make([]frontend.Variable, 0, len(witness.Public)+3)
+	dataFiatShamir = append(dataFiatShamir, witness.Public...)
+	dataFiatShamir = append(dataFiatShamir, merkleRoot(proof.LRO[0]), merkleRoot(proof.LRO[1]), merkleRoot(proof.LRO[2]))
+	if err := fs.Bind("gamma", dataFiatShamir); err != nil {
+		return fmt.Errorf("bind gamma: %w", err)
+	}
+	beta, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return fmt.Errorf("compute beta: %w", err)
+	}
+	gamma, err := fs.ComputeChallenge("beta")
+	if err != nil {
+		return fmt.Errorf("compute gamma: %w", err)
+	}
+	if err := fs.Bind("alpha", []frontend.Variable{merkleRoot(proof.Z)}); err != nil {
+		return fmt.Errorf("bind alpha: %w", err)
+	}
+	alpha, err := fs.ComputeChallenge("alpha")
+	if err != nil {
+		return fmt.Errorf("compute alpha: %w", err)
+	}
+	if err := fs.Bind("zeta", []frontend.Variable{merkleRoot(proof.H[0]), merkleRoot(proof.H[1]), merkleRoot(proof.H[2])}); err != nil {
+		return fmt.Errorf("bind zeta: %w", err)
+	}
+	openingChallenge, err := fs.ComputeChallenge("zeta")
+	if err != nil {
+		return fmt.Errorf("compute zeta: %w", err)
+	}
+
+	// the opening position is the challenge reduced modulo the size of the
+	// domain on which the proximity is tested. The challenge zeta is then
+	// genOpening^{position}.
+	openingChallengeBits := v.api.ToBinary(openingChallenge)
+	positionBits := openingChallengeBits[:logFriSize]
+	shiftedPosition := v.api.Add(v.api.FromBinary(positionBits...), 2*rho)
+	shiftedPositionBits := v.api.ToBinary(shiftedPosition, logFriSize+1)[:logFriSize]
+
+	// 1 - verify that the commitments are low degree polynomials
+	iopp := fri.NewRadixTwoFri(2*vk.Size, &ioppHash, *new(big.Int).ModInverse(&vk.GenOpening, field))
+	pps := []fri.ProofOfProximity{
+		vk.Q[0], vk.Q[1], vk.Q[2], vk.Q[3], vk.Q[4],
+		proof.LRO[0], proof.LRO[1], proof.LRO[2],
+		proof.Z,
+		proof.H[0], proof.H[1], proof.H[2],
+		vk.S[0], vk.S[1], vk.S[2],
+		vk.Id[0], vk.Id[1], vk.Id[2],
+	}
+	for i := range pps {
+		if err := iopp.VerifyProofOfProximity(v.api, pps[i]); err != nil {
+			return fmt.Errorf("proof of proximity %d: %w", i, err)
+		}
+	}
+
+	// 2 - verify the openings
+	position := sortedPosition(v.api, positionBits)
+	shifted := sortedPosition(v.api, shiftedPositionBits)
+	openings := []struct {
+		opening  OpeningProof
+		pp       fri.ProofOfProximity
+		position frontend.Variable
+	}{
+		{proof.OpeningsQ[0], vk.Q[0], position},
+		{proof.OpeningsQ[1], vk.Q[1], position},
+		{proof.OpeningsQ[2], vk.Q[2], position},
+		{proof.OpeningsQ[3], vk.Q[3], position},
+		{proof.OpeningsQ[4], vk.Q[4], position},
+		{proof.OpeningsLRO[0], proof.LRO[0], position},
+		{proof.OpeningsLRO[1], proof.LRO[1], position},
+		{proof.OpeningsLRO[2], proof.LRO[2], position},
+		{proof.OpeningsH[0], proof.H[0], position},
+		{proof.OpeningsH[1], proof.H[1], position},
+		{proof.OpeningsH[2], proof.H[2], position},
+		{proof.OpeningsS[0], vk.S[0], position},
+		{proof.OpeningsS[1], vk.S[1], position},
+		{proof.OpeningsS[2], vk.S[2], position},
+		{proof.OpeningsId[0], vk.Id[0], position},
+		{proof.OpeningsId[1], vk.Id[1], position},
+		{proof.OpeningsId[2], vk.Id[2], position},
+		{proof.OpeningsZ[0], proof.Z, position},
+		{proof.OpeningsZ[1], proof.Z, shifted},
+	}
+	for i := range openings {
+		// the opened polynomial is committed in the first interaction of the
+		// proof of proximity.
+		mp := merkle.MerkleProof{
+			RootHash: merkleRoot(openings[i].pp),
+			Path:     openings[i].opening.Path,
+		}
+		mp.VerifyProof(v.api, &ioppHash, openings[i].position)
+	}
+
+	// 3 - verification of the algebraic relation
+	ql := proof.OpeningsQ[0].Path[0]
+	qr := proof.OpeningsQ[1].Path[0]
+	qm := proof.OpeningsQ[2].Path[0]
+	qo := proof.OpeningsQ[3].Path[0]
+	qk := proof.OpeningsQ[4].Path[0]
+
+	l := proof.OpeningsLRO[0].Path[0]
+	r := proof.OpeningsLRO[1].Path[0]
+	o := proof.OpeningsLRO[2].Path[0]
+
+	h1 := proof.OpeningsH[0].Path[0]
+	h2 := proof.OpeningsH[1].Path[0]
+	h3 := proof.OpeningsH[2].Path[0]
+
+	s1 := proof.OpeningsS[0].Path[0]
+	s2 := proof.OpeningsS[1].Path[0]
+	s3 := proof.OpeningsS[2].Path[0]
+
+	id1 := proof.OpeningsId[0].Path[0]
+	id2 := proof.OpeningsId[1].Path[0]
+	id3 := proof.OpeningsId[2].Path[0]
+
+	z := proof.OpeningsZ[0].Path[0]
+	zshift := proof.OpeningsZ[1].Path[0]
+
+	// zeta = genOpening^{position}
+	zeta := v.fixedExp(&vk.GenOpening, positionBits)
+	zetaPowerN := zeta
+	for i := uint64(1); i < vk.Size; i <<= 1 {
+		zetaPowerN = v.api.Mul(zetaPowerN, zetaPowerN)
+	}
+	zetaPowerNMinusOne := v.api.Sub(zetaPowerN, 1)
+
+	// 3.1 (ql*l+..+qk)
+	t1 := v.api.Add(
+		v.api.Mul(l, ql),
+		v.api.Mul(r, qr),
+		v.api.Mul(qm, l, r),
+		v.api.Mul(o, qo),
+		qk,
+		v.completeQk(vk, witness.Public, zeta, zetaPowerNMinusOne),
+	)
+
+	// 3.2 (z(ux)*(l+β*s1+γ)*..-z*(l+β*id1+γ))
+	t2 := v.api.Mul(
+		v.api.Add(v.api.Mul(beta, s1), l, gamma),
+		v.api.Add(v.api.Mul(beta, s2), r, gamma),
+		v.api.Add(v.api.Mul(beta, s3), o, gamma),
+		zshift,
+	)
+	tmp := v.api.Mul(
+		v.api.Add(v.api.Mul(beta, id1), l, gamma),
+		v.api.Add(v.api.Mul(beta, id2), r, gamma),
+		v.api.Add(v.api.Mul(beta, id3), o, gamma),
+		z,
+	)
+	t2 = v.api.Sub(t2, tmp)
+
+	// 3.3 (z-1)*l1. The native verifier inverts zeta-1 with the convention
+	// that the inverse of zero is zero.
+	t3 := v.api.Mul(zetaPowerNMinusOne, v.inverseOrZero(v.api.Sub(zeta, 1)), &vk.SizeInv, v.api.Sub(z, 1))
+
+	// 3.4 (ql*l+s+qk) + α*(z(ux)*(l+β*s1+γ)*...-z*(l+β*id1+γ)..)+ α²*z*(l1-1)
+	lhs := v.api.Add(v.api.Mul(v.api.Add(v.api.Mul(t3, alpha), t2), alpha), t1)
+
+	// 4 - compute the RHS
+	zetaPowerNPlusTwo := v.api.Mul(zetaPowerN, zeta, zeta)
+	rhs := v.api.Add(v.api.Mul(v.api.Add(v.api.Mul(h3, zetaPowerNPlusTwo), h2), zetaPowerNPlusTwo), h1)
+	rhs = v.api.Mul(rhs, zetaPowerNMinusOne)
+
+	// 5 - verify the relation LHS==RHS
+	v.api.AssertIsEqual(lhs, rhs)
+
+	return nil
+}
+
+// completeQk returns ∑_{i<nb_public_inputs}w_i*L_i(ζ), where L_i(ζ) is
+// computed as ωⁱ/n * (ζⁿ-1)/(ζ-ωⁱ) with the exceptional case L_i(ωⁱ)=1.
+func (v *Verifier) completeQk(vk VerifyingKey, public []frontend.Variable, zeta, zetaPowerNMinusOne frontend.Variable) frontend.Variable {
+	field := v.api.Compiler().Field()
+	var acc, coeff big.Int
+	acc.SetUint64(1)
+	var res frontend.Variable = 0
+	for i := range public {
+		coeff.Mul(&acc, &vk.SizeInv).Mod(&coeff, field)
+		den := v.api.Sub(zeta, &acc)
+		isZero := v.api.IsZero(den)
+		li := v.api.Mul(zetaPowerNMinusOne, v.inverseOrZero(den), &coeff)
+		li = v.api.Select(isZero, 1, li)
+		res = v.api.Add(res, v.api.Mul(li, public[i]))
+		acc.Mul(&acc, &vk.Generator).Mod(&acc, field)
+	}
+	return res
+}
+
+// inverseOrZero returns 1/x if x is non-zero and 0 otherwise.
+func (v *Verifier) inverseOrZero(x frontend.Variable) frontend.Variable {
+	isZero := v.api.IsZero(x)
+	inv := v.api.Inverse(v.api.Select(isZero, 1, x))
+	return v.api.Select(isZero, 0, inv)
+}
+
+// fixedExp returns base^e where e is given by its little-endian binary
+// decomposition.
+func (v *Verifier) fixedExp(base *big.Int, e []frontend.Variable) frontend.Variable {
+	field := v.api.Compiler().Field()
+	var acc big.Int
+	acc.Set(base)
+	var res frontend.Variable = 1
+	for i := range e {
+		res = v.api.Select(e[i], v.api.Mul(res, &acc), res)
+		acc.Mul(&acc, &acc).Mod(&acc, field)
+	}
+	return res
+}
+
+// sortedPosition returns the index of the leaf of an evaluation in the Merkle
+// tree of the sorted evaluations of a polynomial, given the little-endian
+// binary decomposition of the position of the evaluation in the domain (cf
+// gnark-crypto fri). If the domain is of size n, then the evaluation at
+// position i is at index 2i if i < n/2 and 2(i-n/2)+1 otherwise.
+func sortedPosition(api frontend.API, positionBits []frontend.Variable) frontend.Variable {
+	sortedBits := make([]frontend.Variable, 0, len(positionBits))
+	sortedBits = append(sortedBits, positionBits[len(positionBits)-1])
+	sortedBits = append(sortedBits, positionBits[:len(positionBits)-1]...)
+	return api.FromBinary(sortedBits...)
+}
+
+// domainSize returns the size of the domain of the polynomials of the circuit.
+// It matches the size computed in the native setup.
+func domainSize(ccs constraint.ConstraintSystem) uint64 {
+	return ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints() + ccs.GetNbPublicVariables()))
+}
+
+// merkleRoot returns the Merkle root of the first interaction of the first
+// round of pp, which is the commitment to the evaluations of the polynomial.
+func merkleRoot(pp fri.ProofOfProximity) frontend.Variable {
+	return pp.Rounds[0].Interactions[0][0].RootHash
+}
+
+// placeholderProofOfProximity returns a placeholder for a proof of proximity
+// for a polynomial with domain of size size. The domain on which the proximity
+// is tested is of size 2*rho*size, to take into account the blinding.
+func placeholderProofOfProximity(size uint64) fri.ProofOfProximity {
+	nbSteps := stdbits.TrailingZeros64(2 * size)
+	depth := stdbits.TrailingZeros64(2 * rho * size)
+	rounds := make([]fri.Round, 1)
+	rounds[0].Interactions = make([][2]merkle.MerkleProof, nbSteps)
+	for i := range rounds[0].Interactions {
+		for j := range rounds[0].Interactions[i] {
+			rounds[0].Interactions[i][j].Path = make([]frontend.Variable, depth-i+1)
+		}
+	}
+	return fri.ProofOfProximity{Rounds: rounds}
+}
+
+// placeholderOpeningProof returns a placeholder for an opening of a polynomial
+// with domain of size size.
+func placeholderOpeningProof(size uint64) OpeningProof {
+	depth := stdbits.TrailingZeros64(2 * rho * size)
+	return OpeningProof{Path: make([]frontend.Variable, depth+1)}
+}
+
+// nativeMerkleProof is the curve-independent part of the Merkle proofs of the
+// native proofs of proximity.
+type nativeMerkleProof struct {
+	root     []byte
+	proofSet [][]byte
+}
+
+// valueOfRound returns the witness of a round of a native proof of proximity.
+// In the native proof, only one of the two Merkle proofs of an interaction
+// contains the full Merkle path, the other one only contains the leaf and its
+// hash as the rest of the path is shared.
+func valueOfRound(interactions [][2]nativeMerkleProof, evaluation frontend.Variable) fri.Round {
+	ret := fri.Round{
+		Interactions: make([][2]merkle.MerkleProof, len(interactions)),
+		Evaluation:   evaluation,
+	}
+	for i := range interactions {
+		full := 0
+		if len(interactions[i][1].proofSet) > len(interactions[i][0].proofSet) {
+			full = 1
+		}
+		n := len(interactions[i][full].proofSet)
+		for j := range interactions[i] {
+			ret.Interactions[i][j].RootHash = interactions[i][j].root
+			ret.Interactions[i][j].Path = make([]frontend.Variable, n)
+			ret.Interactions[i][j].Path[0] = interactions[i][j].proofSet[0]
+			ret.Interactions[i][j].Path[1] = interactions[i][j].proofSet[1]
+			for k := 2; k < n; k++ {
+				ret.Interactions[i][j].Path[k] = interactions[i][full].proofSet[k]
+			}
+		}
+	}
+	return ret
+}
+
+// setOpenings assigns the openings of the proof from the native openings.
+func setOpenings[O any](ret *Proof, lro, z, h, q, s, id []O, proofSet func(O) [][]byte) {
+	for _, openings := range []struct {
+		dst []OpeningProof
+		src []O
+	}{
+		{ret.OpeningsLRO[:], lro},
+		{ret.OpeningsZ[:], z},
+		{ret.OpeningsH[:], h},
+		{ret.OpeningsQ[:], q},
+		{ret.OpeningsS[:], s},
+		{ret.OpeningsId[:], id},
+	} {
+		for i := range openings.dst {
+			ps := proofSet(openings.src[i])
+			openings.dst[i].Path = make([]frontend.Variable, len(ps))
+			for j := range ps {
+				openings.dst[i].Path[j] = ps[j]
+			}
+		}
+	}
+}
+
+func valueOfProofOfProximityBN254(pp fri_bn254.ProofOfProximity) fri.ProofOfProximity {
+	ret := fri.ProofOfProximity{Rounds: make([]fri.Round, len(pp.Rounds))}
+	for i, r := range pp.Rounds {
+		interactions := make([][2]nativeMerkleProof, len(r.Interactions))
+		for j := range r.Interactions {
+			for k := range r.Interactions[j] {
+				interactions[j][k] = nativeMerkleProof{r.Interactions[j][k].MerkleRoot, r.Interactions[j][k].ProofSet}
+			}
+		}
+		ret.Rounds[i] = valueOfRound(interactions, r.Evaluation)
+	}
+	return ret
+}
+
+func valueOfProofOfProximityBLS12377(pp fri_bls12377.ProofOfProximity) fri.ProofOfProximity {
+	ret := fri.ProofOfProximity{Rounds: make([]fri.Round, len(pp.Rounds))}
+	for i, r := range pp.Rounds {
+		interactions := make([][2]nativeMerkleProof, len(r.Interactions))
+		for j := range r.Interactions {
+			for k := range r.Interactions[j] {
+				interactions[j][k] = nativeMerkleProof{r.Interactions[j][k].MerkleRoot, r.Interactions[j][k].ProofSet}
+			}
+		}
+		ret.Rounds[i] = valueOfRound(interactions, r.Evaluation)
+	}
+	return ret
+}
+
+func valueOfProofOfProximityBLS12381(pp fri_bls12381.ProofOfProximity) fri.ProofOfProximity {
+	ret := fri.ProofOfProximity{Rounds: make([]fri.Round, len(pp.Rounds))}
+	for i, r := range pp.Rounds {
+		interactions := make([][2]nativeMerkleProof, len(r.Interactions))
+		for j := range r.Interactions {
+			for k := range r.Interactions[j] {
+				interactions[j][k] = nativeMerkleProof{r.Interactions[j][k].MerkleRoot, r.Interactions[j][k].ProofSet}
+			}
+		}
+		ret.Rounds[i] = valueOfRound(interactions, r.Evaluation)
+	}
+	return ret
+}
+
+func valueOfProofOfProximityBW6761(pp fri_bw6761.ProofOfProximity) fri.ProofOfProximity {
+	ret := fri.ProofOfProximity{Rounds: make([]fri.Round, len(pp.Rounds))}
+	for i, r := range pp.Rounds {
+		interactions := make([][2]nativeMerkleProof, len(r.Interactions))
+		for j := range r.Interactions {
+			for k := range r.Interactions[j] {
+				interactions[j][k] = nativeMerkleProof{r.Interactions[j][k].MerkleRoot, r.Interactions[j][k].ProofSet}
+			}
+		}
+		ret.Rounds[i] = valueOfRound(interactions, r.Evaluation)
+	}
+	return ret
+}
+
+func valueOfProofOfProximityBLS24315(pp fri_bls24315.ProofOfProximity) fri.ProofOfProximity {
+	ret := fri.ProofOfProximity{Rounds: make([]fri.Round, len(pp.Rounds))}
+	for i, r := range pp.Rounds {
+		interactions := make([][2]nativeMerkleProof, len(r.Interactions))
+		for j := range r.Interactions {
+			for k := range r.Interactions[j] {
+				interactions[j][k] = nativeMerkleProof{r.Interactions[j][k].MerkleRoot, r.Interactions[j][k].ProofSet}
+			}
+		}
+		ret.Rounds[i] = valueOfRound(interactions, r.Evaluation)
+	}
+	return ret
+}
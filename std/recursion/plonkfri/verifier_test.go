@@ -0,0 +1,130 @@
+package plonkfri
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	native_plonkfri "github.com/consensys/gnark/backend/plonkfri"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+)
+
+type InnerCircuit struct {
+	P, Q frontend.Variable
+	N    frontend.Variable `gnark:",public"`
+}
+
+func (c *InnerCircuit) Define(api frontend.API) error {
+	res := api.Mul(c.P, c.Q)
+	api.AssertIsEqual(res, c.N)
+	return nil
+}
+
+type OuterCircuit struct {
+	Proof        Proof
+	VerifyingKey VerifyingKey
+	InnerWitness Witness `gnark:",public"`
+}
+
+func (c *OuterCircuit) Define(api frontend.API) error {
+	verifier, err := NewVerifier(api)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	return verifier.AssertProof(c.VerifyingKey, c.Proof, c.InnerWitness)
+}
+
+func getInner(assert *test.Assert, field *big.Int) (constraint.ConstraintSystem, native_plonkfri.VerifyingKey, witness.Witness, native_plonkfri.Proof) {
+	innerCcs, err := frontend.Compile(field, scs.NewBuilder, &InnerCircuit{})
+	assert.NoError(err)
+	innerPK, innerVK, err := native_plonkfri.Setup(innerCcs, GetNativeSetupOptions(field))
+	assert.NoError(err)
+
+	// inner proof
+	innerAssignment := &InnerCircuit{
+		P: 3,
+		Q: 5,
+		N: 15,
+	}
+	innerWitness, err := frontend.NewWitness(innerAssignment, field)
+	assert.NoError(err)
+	innerProof, err := native_plonkfri.Prove(innerCcs, innerPK, innerWitness, GetNativeProverOptions(field))
+	assert.NoError(err)
+	innerPubWitness, err := innerWitness.Public()
+	assert.NoError(err)
+	err = native_plonkfri.Verify(innerProof, innerVK, innerPubWitness, GetNativeVerifierOptions(field))
+	assert.NoError(err)
+	return innerCcs, innerVK, innerPubWitness, innerProof
+}
+
+func testRecursion(t *testing.T, curve ecc.ID) {
+	assert := test.NewAssert(t)
+	field := curve.ScalarField()
+	innerCcs, innerVK, innerWitness, innerProof := getInner(assert, field)
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey(innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness(innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof(innerProof)
+	assert.NoError(err)
+
+	placeholderVk, err := PlaceholderVerifyingKey(innerCcs)
+	assert.NoError(err)
+	outerCircuit := &OuterCircuit{
+		Proof:        PlaceholderProof(innerCcs),
+		VerifyingKey: placeholderVk,
+		InnerWitness: PlaceholderWitness(innerCcs),
+	}
+	outerAssignment := &OuterCircuit{
+		Proof:        circuitProof,
+		VerifyingKey: circuitVk,
+		InnerWitness: circuitWitness,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, field)
+	assert.NoError(err)
+}
+
+func TestBN254InBN254(t *testing.T) {
+	testRecursion(t, ecc.BN254)
+}
+
+func TestBLS12377InBLS12377(t *testing.T) {
+	testRecursion(t, ecc.BLS12_377)
+}
+
+func TestInvalidProof(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+	innerCcs, innerVK, innerWitness, innerProof := getInner(assert, field)
+
+	circuitVk, err := ValueOfVerifyingKey(innerVK)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof(innerProof)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness(innerWitness)
+	assert.NoError(err)
+	// tamper with the public input
+	circuitWitness.Public[0] = 16
+
+	placeholderVk, err := PlaceholderVerifyingKey(innerCcs)
+	assert.NoError(err)
+	outerCircuit := &OuterCircuit{
+		Proof:        PlaceholderProof(innerCcs),
+		VerifyingKey: placeholderVk,
+		InnerWitness: PlaceholderWitness(innerCcs),
+	}
+	outerAssignment := &OuterCircuit{
+		Proof:        circuitProof,
+		VerifyingKey: circuitVk,
+		InnerWitness: circuitWitness,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, field)
+	assert.Error(err)
+}
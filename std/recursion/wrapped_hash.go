@@ -31,6 +31,38 @@ type shortNativeHash struct {
 // the circuit being recursed). The hash function is based on MiMC and
 // partitions the excess bits to not overflow the target field.
 func NewShort(current, target *big.Int) (hash.Hash, error) {
+	hh, bitBlockSize, err := newNativeMiMC(current)
+	if err != nil {
+		return nil, err
+	}
+	if target.Cmp(current) == 0 {
+		return hh, nil
+	}
+	return newShortFromParam(hh, bitBlockSize, shortOutSize(current, target)), nil
+}
+
+// NewShortPartitioned returns a native hash function similar to [NewShort],
+// but which always partitions the inputs, even when the current and target
+// fields coincide. It allows hashing arbitrary byte strings which do not
+// necessarily encode elements in the current field, such as serialized
+// curve points. The in-circuit counterpart is [NewHashPartitioned].
+func NewShortPartitioned(current, target *big.Int) (hash.Hash, error) {
+	hh, bitBlockSize, err := newNativeMiMC(current)
+	if err != nil {
+		return nil, err
+	}
+	return newShortFromParam(hh, bitBlockSize, shortOutSize(current, target)), nil
+}
+
+func shortOutSize(current, target *big.Int) int {
+	nbBits := target.BitLen()
+	if nbBits > current.BitLen() {
+		nbBits = current.BitLen()
+	}
+	return nbBits
+}
+
+func newNativeMiMC(current *big.Int) (hash.Hash, int, error) {
 	var h cryptomimc.Hash
 	var bitBlockSize int
 	switch current.String() {
@@ -62,17 +94,9 @@ func NewShort(current, target *big.Int) (hash.Hash, error) {
 		h = cryptomimc.MIMC_BW6_756
 		bitBlockSize = ecc.BW6_756.ScalarField().BitLen()
 	default:
-		return nil, fmt.Errorf("no default mimc for scalar field: %s", current.String())
+		return nil, 0, fmt.Errorf("no default mimc for scalar field: %s", current.String())
 	}
-	hh := h.New()
-	if target.Cmp(current) == 0 {
-		return hh, nil
-	}
-	nbBits := target.BitLen()
-	if nbBits > current.BitLen() {
-		nbBits = current.BitLen()
-	}
-	return newShortFromParam(hh, bitBlockSize, nbBits), nil
+	return h.New(), bitBlockSize, nil
 }
 
 func newShortFromParam(hf hash.Hash, bitBlockSize, outSize int) hash.Hash {
@@ -175,11 +199,29 @@ func NewHash(api frontend.API, target *big.Int, bitmode bool) (stdhash.FieldHash
 	return newHashFromParameter(api, &h, nbBits, bitmode), nil
 }
 
+// NewHashPartitioned returns a circuit hash function similar to [NewHash], but
+// which always partitions the inputs, even when the native and target fields
+// coincide. It is the in-circuit counterpart of [NewShortPartitioned].
+func NewHashPartitioned(api frontend.API, target *big.Int, bitmode bool) (stdhash.FieldHasher, error) {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return nil, fmt.Errorf("get mimc: %w", err)
+	}
+	nbBits := target.BitLen()
+	if nbBits > api.Compiler().FieldBitLen() {
+		nbBits = api.Compiler().FieldBitLen()
+	}
+	return newHashFromParameter(api, &h, nbBits, bitmode), nil
+}
+
 // NewTranscript returns a new Fiat-Shamir transcript for computing bound
-// challenges. It uses hasher returned by [NewHash] internally and configures
-// the transcript to be compatible with gnark-crypto Fiat-Shamir transcript.
+// challenges. It uses hasher returned by [NewHashPartitioned] internally and
+// configures the transcript to be compatible with gnark-crypto Fiat-Shamir
+// transcript using the hasher returned by [NewShortPartitioned]. The inputs are
+// written in bitmode, so they are partitioned even when the native and target
+// fields coincide.
 func NewTranscript(api frontend.API, target *big.Int, challenges []string) (*fiatshamir.Transcript, error) {
-	h, err := NewHash(api, target, true)
+	h, err := NewHashPartitioned(api, target, true)
 	if err != nil {
 		return nil, fmt.Errorf("new hash: %w", err)
 	}
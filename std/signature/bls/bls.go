@@ -0,0 +1,278 @@
+/*
+Package bls implements BLS signature verification over pairing-friendly curves.
+
+The package is generic over the [algebra.Curve] and [algebra.Pairing]
+interfaces and supports both variants of the signature scheme:
+  - minimal-pubkey-size (min-pk): public keys are in G1 and signatures and
+    hashed messages are in G2;
+  - minimal-signature-size (min-sig): public keys are in G2 and signatures and
+    hashed messages are in G1.
+
+Messages are hashed to the curve in-circuit using the hash_to_curve method of
+RFC 9380 with expand_message_xmd instantiated with SHA2-256. The domain
+separation tag is fixed at compile time.
+
+Currently the following curves are supported:
+  - BN254 (emulated, [sw_bn254]),
+  - BLS12-381 (emulated, [sw_bls12381]),
+  - BLS12-377 (native over the BW6-761 scalar field, [sw_bls12377]).
+
+The verifier checks that the signature is in the prime-order subgroup. The
+public keys are assumed to have been validated beforehand. When using
+aggregated signatures over the same message, the public keys must additionally
+come with a proof of possession to prevent rogue key attacks. Aggregated
+signatures over different messages are checked with the basic scheme, where
+the messages must be pairwise distinct. The distinctness is asserted
+in-circuit.
+
+See [BLS] for the signature scheme.
+
+[BLS]: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+*/
+package bls
+
+import (
+	"fmt"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Verifier verifies BLS signatures over the curve defined by the type
+// parameters.
+type Verifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	api     frontend.API
+	curve   algebra.Curve[FR, G1El]
+	pairing algebra.Pairing[G1El, G2El, GtEl]
+
+	// g1Neg is the negated generator of G1 and g2 the generator of G2.
+	g1Neg *G1El
+	g2    *G2El
+
+	hashToG1     func(msg []uints.U8, dst []byte) (*G1El, error)
+	hashToG2     func(msg []uints.U8, dst []byte) (*G2El, error)
+	assertIsOnG1 func(*G1El)
+	assertIsOnG2 func(*G2El)
+	// addG2 adds two points in G2 handling the edge cases, as
+	// [algebra.Curve] only provides the G1 arithmetic.
+	addG2 func(p, q *G2El) *G2El
+}
+
+// NewVerifier returns a new BLS signature verifier. It returns an error if the
+// type parametrisation does not correspond to a supported curve.
+func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](api frontend.API) (*Verifier[FR, G1El, G2El, GtEl], error) {
+	curve, err := algebra.GetCurve[FR, G1El](api)
+	if err != nil {
+		return nil, fmt.Errorf("get curve: %w", err)
+	}
+	pairing, err := algebra.GetPairing[G1El, G2El, GtEl](api)
+	if err != nil {
+		return nil, fmt.Errorf("get pairing: %w", err)
+	}
+	ret := &Verifier[FR, G1El, G2El, GtEl]{
+		api:     api,
+		curve:   curve,
+		pairing: pairing,
+	}
+	switch s := any(ret).(type) {
+	case *Verifier[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]:
+		pr, err := sw_bn254.NewPairing(api)
+		if err != nil {
+			return nil, fmt.Errorf("new pairing: %w", err)
+		}
+		g1, err := sw_bn254.NewG1(api)
+		if err != nil {
+			return nil, fmt.Errorf("new G1: %w", err)
+		}
+		g2 := sw_bn254.NewG2(api)
+		_, _, g1Gen, g2Gen := bn254.Generators()
+		g1Gen.Neg(&g1Gen)
+		g1Neg := sw_bn254.NewG1Affine(g1Gen)
+		g2Fixed := sw_bn254.NewG2AffineFixed(g2Gen)
+		s.g1Neg, s.g2 = &g1Neg, &g2Fixed
+		s.hashToG1, s.hashToG2 = g1.HashToG1, g2.HashToG2
+		s.assertIsOnG1, s.assertIsOnG2 = pr.AssertIsOnG1, pr.AssertIsOnG2
+		s.addG2 = g2.AddUnified
+	case *Verifier[sw_bls12381.ScalarField, sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]:
+		pr, err := sw_bls12381.NewPairing(api)
+		if err != nil {
+			return nil, fmt.Errorf("new pairing: %w", err)
+		}
+		g1, err := sw_bls12381.NewG1(api)
+		if err != nil {
+			return nil, fmt.Errorf("new G1: %w", err)
+		}
+		g2 := sw_bls12381.NewG2(api)
+		_, _, g1Gen, g2Gen := bls12381.Generators()
+		g1Gen.Neg(&g1Gen)
+		g1Neg := sw_bls12381.NewG1Affine(g1Gen)
+		g2Fixed := sw_bls12381.NewG2AffineFixed(g2Gen)
+		s.g1Neg, s.g2 = &g1Neg, &g2Fixed
+		s.hashToG1, s.hashToG2 = g1.HashToG1, g2.HashToG2
+		s.assertIsOnG1, s.assertIsOnG2 = pr.AssertIsOnG1, pr.AssertIsOnG2
+		s.addG2 = g2.AddUnified
+	case *Verifier[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]:
+		pr := sw_bls12377.NewPairing(api)
+		_, _, g1Gen, g2Gen := bls12377.Generators()
+		g1Gen.Neg(&g1Gen)
+		g1Neg := sw_bls12377.NewG1Affine(g1Gen)
+		g2Fixed := sw_bls12377.NewG2AffineFixed(g2Gen)
+		s.g1Neg, s.g2 = &g1Neg, &g2Fixed
+		s.hashToG1 = func(msg []uints.U8, dst []byte) (*sw_bls12377.G1Affine, error) {
+			res, err := sw_bls12377.HashToG1(api, msg, dst)
+			return &res, err
+		}
+		s.hashToG2 = func(msg []uints.U8, dst []byte) (*sw_bls12377.G2Affine, error) {
+			res, err := sw_bls12377.HashToG2(api, msg, dst)
+			return &res, err
+		}
+		s.assertIsOnG1, s.assertIsOnG2 = pr.AssertIsOnG1, pr.AssertIsOnG2
+		s.addG2 = func(p, q *sw_bls12377.G2Affine) *sw_bls12377.G2Affine {
+			res := sw_bls12377.G2Affine{P: p.P}
+			res.P.AddUnified(api, q.P)
+			return &res
+		}
+	default:
+		return nil, fmt.Errorf("unknown type parametrisation")
+	}
+	return ret, nil
+}
+
+// VerifyMinPk asserts that sig is a valid signature of msg for the public key
+// pk in the min-pk variant, i.e. that e(pk, H(msg)) = e(g1, sig). dst is the
+// domain separation tag used for hashing the message to G2.
+func (v *Verifier[FR, G1El, G2El, GtEl]) VerifyMinPk(pk *G1El, sig *G2El, msg []uints.U8, dst []byte) error {
+	return v.AggregateVerifyMinPk([]*G1El{pk}, sig, [][]uints.U8{msg}, dst)
+}
+
+// VerifyMinSig asserts that sig is a valid signature of msg for the public key
+// pk in the min-sig variant, i.e. that e(H(msg), pk) = e(sig, g2). dst is the
+// domain separation tag used for hashing the message to G1.
+func (v *Verifier[FR, G1El, G2El, GtEl]) VerifyMinSig(pk *G2El, sig *G1El, msg []uints.U8, dst []byte) error {
+	return v.AggregateVerifyMinSig([]*G2El{pk}, sig, [][]uints.U8{msg}, dst)
+}
+
+// FastAggregateVerifyMinPk asserts that the aggregated signature sig is valid
+// for the same message msg signed by all the public keys pks in the min-pk
+// variant. The public keys are aggregated in-circuit, so the verification cost
+// is the same as for a single signature apart from the point additions.
+func (v *Verifier[FR, G1El, G2El, GtEl]) FastAggregateVerifyMinPk(pks []*G1El, sig *G2El, msg []uints.U8, dst []byte) error {
+	if len(pks) == 0 {
+		return fmt.Errorf("no public keys")
+	}
+	aggPk := pks[0]
+	for i := 1; i < len(pks); i++ {
+		aggPk = v.curve.AddUnified(aggPk, pks[i])
+	}
+	return v.VerifyMinPk(aggPk, sig, msg, dst)
+}
+
+// FastAggregateVerifyMinSig asserts that the aggregated signature sig is valid
+// for the same message msg signed by all the public keys pks in the min-sig
+// variant. The public keys are aggregated in-circuit with G2 additions, so the
+// verification cost is the same as for a single signature apart from the
+// point additions.
+func (v *Verifier[FR, G1El, G2El, GtEl]) FastAggregateVerifyMinSig(pks []*G2El, sig *G1El, msg []uints.U8, dst []byte) error {
+	if len(pks) == 0 {
+		return fmt.Errorf("no public keys")
+	}
+	aggPk := pks[0]
+	for i := 1; i < len(pks); i++ {
+		aggPk = v.addG2(aggPk, pks[i])
+	}
+	return v.VerifyMinSig(aggPk, sig, msg, dst)
+}
+
+// AggregateVerifyMinPk asserts that the aggregated signature sig is valid for
+// the messages msgs signed by the corresponding public keys pks in the min-pk
+// variant, i.e. that ∏ e(pk_i, H(msg_i)) = e(g1, sig). It returns an error if
+// the numbers of public keys and messages mismatch.
+//
+// As per the basic scheme, it also asserts that the messages are pairwise
+// distinct. Otherwise, a rogue public key could be used to forge an aggregated
+// signature. Use [Verifier.FastAggregateVerifyMinPk] with proofs of
+// possession for signatures over the same message.
+func (v *Verifier[FR, G1El, G2El, GtEl]) AggregateVerifyMinPk(pks []*G1El, sig *G2El, msgs [][]uints.U8, dst []byte) error {
+	if len(pks) == 0 {
+		return fmt.Errorf("no public keys")
+	}
+	if len(pks) != len(msgs) {
+		return fmt.Errorf("mismatching number of public keys (%d) and messages (%d)", len(pks), len(msgs))
+	}
+	v.assertIsOnG2(sig)
+	v.assertDistinct(msgs)
+	P := make([]*G1El, len(pks)+1)
+	Q := make([]*G2El, len(pks)+1)
+	for i := range pks {
+		h, err := v.hashToG2(msgs[i], dst)
+		if err != nil {
+			return fmt.Errorf("hash to G2: %w", err)
+		}
+		P[i], Q[i] = pks[i], h
+	}
+	P[len(pks)], Q[len(pks)] = v.g1Neg, sig
+	if err := v.pairing.PairingCheck(P, Q); err != nil {
+		return fmt.Errorf("pairing check: %w", err)
+	}
+	return nil
+}
+
+// AggregateVerifyMinSig asserts that the aggregated signature sig is valid for
+// the messages msgs signed by the corresponding public keys pks in the min-sig
+// variant, i.e. that ∏ e(H(msg_i), pk_i) = e(sig, g2). It returns an error if
+// the numbers of public keys and messages mismatch.
+//
+// As per the basic scheme, it also asserts that the messages are pairwise
+// distinct. Otherwise, a rogue public key could be used to forge an aggregated
+// signature. Use [Verifier.FastAggregateVerifyMinSig] with proofs of
+// possession for signatures over the same message.
+func (v *Verifier[FR, G1El, G2El, GtEl]) AggregateVerifyMinSig(pks []*G2El, sig *G1El, msgs [][]uints.U8, dst []byte) error {
+	if len(pks) == 0 {
+		return fmt.Errorf("no public keys")
+	}
+	if len(pks) != len(msgs) {
+		return fmt.Errorf("mismatching number of public keys (%d) and messages (%d)", len(pks), len(msgs))
+	}
+	v.assertIsOnG1(sig)
+	v.assertDistinct(msgs)
+	P := make([]*G1El, len(pks)+1)
+	Q := make([]*G2El, len(pks)+1)
+	for i := range pks {
+		h, err := v.hashToG1(msgs[i], dst)
+		if err != nil {
+			return fmt.Errorf("hash to G1: %w", err)
+		}
+		P[i], Q[i] = h, pks[i]
+	}
+	P[len(pks)], Q[len(pks)] = v.curve.Neg(sig), v.g2
+	if err := v.pairing.PairingCheck(P, Q); err != nil {
+		return fmt.Errorf("pairing check: %w", err)
+	}
+	return nil
+}
+
+// assertDistinct asserts that the messages msgs are pairwise distinct.
+// Messages of different lengths are distinct by construction.
+func (v *Verifier[FR, G1El, G2El, GtEl]) assertDistinct(msgs [][]uints.U8) {
+	for i := range msgs {
+		for j := i + 1; j < len(msgs); j++ {
+			if len(msgs[i]) != len(msgs[j]) {
+				continue
+			}
+			// isEqual is 1 iff all the bytes are equal
+			var isEqual frontend.Variable = 1
+			for k := range msgs[i] {
+				isEqual = v.api.Mul(isEqual, v.api.IsZero(v.api.Sub(msgs[i][k].Val, msgs[j][k].Val)))
+			}
+			v.api.AssertIsEqual(isEqual, 0)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package bls
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+var (
+	dstMinPk  = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+	dstMinSig = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
+	msg1      = []byte("hello, world")
+	msg2      = []byte("goodbye, world")
+)
+
+type minPkCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pk  G1El
+	Sig G2El
+	Msg []uints.U8
+}
+
+func (c *minPkCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	return v.VerifyMinPk(&c.Pk, &c.Sig, c.Msg, dstMinPk)
+}
+
+type minSigCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pk  G2El
+	Sig G1El
+	Msg []uints.U8
+}
+
+func (c *minSigCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	return v.VerifyMinSig(&c.Pk, &c.Sig, c.Msg, dstMinSig)
+}
+
+type fastAggregateMinPkCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pks [2]G1El
+	Sig G2El
+	Msg []uints.U8
+}
+
+func (c *fastAggregateMinPkCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	return v.FastAggregateVerifyMinPk([]*G1El{&c.Pks[0], &c.Pks[1]}, &c.Sig, c.Msg, dstMinPk)
+}
+
+type fastAggregateMinSigCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pks [2]G2El
+	Sig G1El
+	Msg []uints.U8
+}
+
+func (c *fastAggregateMinSigCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	return v.FastAggregateVerifyMinSig([]*G2El{&c.Pks[0], &c.Pks[1]}, &c.Sig, c.Msg, dstMinSig)
+}
+
+type aggregateMinPkCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pks        [2]G1El
+	Sig        G2El
+	Msg1, Msg2 []uints.U8
+}
+
+func (c *aggregateMinPkCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	return v.AggregateVerifyMinPk([]*G1El{&c.Pks[0], &c.Pks[1]}, &c.Sig, [][]uints.U8{c.Msg1, c.Msg2}, dstMinPk)
+}
+
+type aggregateMinSigCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pks        [2]G2El
+	Sig        G1El
+	Msg1, Msg2 []uints.U8
+}
+
+func (c *aggregateMinSigCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	return v.AggregateVerifyMinSig([]*G2El{&c.Pks[0], &c.Pks[1]}, &c.Sig, [][]uints.U8{c.Msg1, c.Msg2}, dstMinSig)
+}
+
+func randomKey(assert *test.Assert, order *big.Int) *big.Int {
+	sk, err := rand.Int(rand.Reader, order)
+	assert.NoError(err)
+	return sk
+}
+
+func TestVerifyBLS12377(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, g2 := bls12377.Generators()
+	var sk [2]*big.Int
+	var pk1 [2]bls12377.G1Affine
+	var pk2 [2]bls12377.G2Affine
+	for i := range sk {
+		sk[i] = randomKey(assert, ecc.BLS12_377.ScalarField())
+		pk1[i].ScalarMultiplication(&g1, sk[i])
+		pk2[i].ScalarMultiplication(&g2, sk[i])
+	}
+	h2, err := bls12377.HashToG2(msg1, dstMinPk)
+	assert.NoError(err)
+	h2b, err := bls12377.HashToG2(msg2, dstMinPk)
+	assert.NoError(err)
+	h1, err := bls12377.HashToG1(msg1, dstMinSig)
+	assert.NoError(err)
+	h1b, err := bls12377.HashToG1(msg2, dstMinSig)
+	assert.NoError(err)
+	// min-pk signatures: single, aggregated over the same message and over
+	// distinct messages
+	var sigPk, sigPkFast, sigPkAgg bls12377.G2Affine
+	sigPk.ScalarMultiplication(&h2, sk[0])
+	sigPkFast.ScalarMultiplication(&h2, sk[1])
+	sigPkFast.Add(&sigPkFast, &sigPk)
+	sigPkAgg.ScalarMultiplication(&h2b, sk[1])
+	sigPkAgg.Add(&sigPkAgg, &sigPk)
+	// min-sig signatures
+	var sigSig, sigSigFast, sigSigAgg bls12377.G1Affine
+	sigSig.ScalarMultiplication(&h1, sk[0])
+	sigSigFast.ScalarMultiplication(&h1, sk[1])
+	sigSigFast.Add(&sigSigFast, &sigSig)
+	sigSigAgg.ScalarMultiplication(&h1b, sk[1])
+	sigSigAgg.Add(&sigSigAgg, &sigSig)
+
+	type (
+		FR   = sw_bls12377.ScalarField
+		G1El = sw_bls12377.G1Affine
+		G2El = sw_bls12377.G2Affine
+		GtEl = sw_bls12377.GT
+	)
+	field := ecc.BW6_761.ScalarField()
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&minPkCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &minPkCircuit[FR, G1El, G2El, GtEl]{
+			Pk:  sw_bls12377.NewG1Affine(pk1[0]),
+			Sig: sw_bls12377.NewG2Affine(sigPk),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "min-pk")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&minPkCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg2))}, &minPkCircuit[FR, G1El, G2El, GtEl]{
+			Pk:  sw_bls12377.NewG1Affine(pk1[0]),
+			Sig: sw_bls12377.NewG2Affine(sigPk),
+			Msg: uints.NewU8Array(msg2),
+		}, field)
+		assert.Error(err)
+	}, "min-pk/wrong-message")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&minSigCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &minSigCircuit[FR, G1El, G2El, GtEl]{
+			Pk:  sw_bls12377.NewG2Affine(pk2[0]),
+			Sig: sw_bls12377.NewG1Affine(sigSig),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "min-sig")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&fastAggregateMinPkCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &fastAggregateMinPkCircuit[FR, G1El, G2El, GtEl]{
+			Pks: [2]G1El{sw_bls12377.NewG1Affine(pk1[0]), sw_bls12377.NewG1Affine(pk1[1])},
+			Sig: sw_bls12377.NewG2Affine(sigPkFast),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "fast-aggregate-min-pk")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&fastAggregateMinSigCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &fastAggregateMinSigCircuit[FR, G1El, G2El, GtEl]{
+			Pks: [2]G2El{sw_bls12377.NewG2Affine(pk2[0]), sw_bls12377.NewG2Affine(pk2[1])},
+			Sig: sw_bls12377.NewG1Affine(sigSigFast),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "fast-aggregate-min-sig")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&fastAggregateMinSigCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &fastAggregateMinSigCircuit[FR, G1El, G2El, GtEl]{
+			Pks: [2]G2El{sw_bls12377.NewG2Affine(pk2[0]), sw_bls12377.NewG2Affine(pk2[1])},
+			Sig: sw_bls12377.NewG1Affine(sigSig),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.Error(err)
+	}, "fast-aggregate-min-sig-invalid")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&aggregateMinPkCircuit[FR, G1El, G2El, GtEl]{Msg1: make([]uints.U8, len(msg1)), Msg2: make([]uints.U8, len(msg2))}, &aggregateMinPkCircuit[FR, G1El, G2El, GtEl]{
+			Pks:  [2]G1El{sw_bls12377.NewG1Affine(pk1[0]), sw_bls12377.NewG1Affine(pk1[1])},
+			Sig:  sw_bls12377.NewG2Affine(sigPkAgg),
+			Msg1: uints.NewU8Array(msg1),
+			Msg2: uints.NewU8Array(msg2),
+		}, field)
+		assert.NoError(err)
+	}, "aggregate-min-pk")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&aggregateMinSigCircuit[FR, G1El, G2El, GtEl]{Msg1: make([]uints.U8, len(msg1)), Msg2: make([]uints.U8, len(msg2))}, &aggregateMinSigCircuit[FR, G1El, G2El, GtEl]{
+			Pks:  [2]G2El{sw_bls12377.NewG2Affine(pk2[0]), sw_bls12377.NewG2Affine(pk2[1])},
+			Sig:  sw_bls12377.NewG1Affine(sigSigAgg),
+			Msg1: uints.NewU8Array(msg1),
+			Msg2: uints.NewU8Array(msg2),
+		}, field)
+		assert.NoError(err)
+	}, "aggregate-min-sig")
+	// the basic scheme rejects aggregated signatures over the same message,
+	// even when the pairing check holds.
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&aggregateMinPkCircuit[FR, G1El, G2El, GtEl]{Msg1: make([]uints.U8, len(msg1)), Msg2: make([]uints.U8, len(msg1))}, &aggregateMinPkCircuit[FR, G1El, G2El, GtEl]{
+			Pks:  [2]G1El{sw_bls12377.NewG1Affine(pk1[0]), sw_bls12377.NewG1Affine(pk1[1])},
+			Sig:  sw_bls12377.NewG2Affine(sigPkFast),
+			Msg1: uints.NewU8Array(msg1),
+			Msg2: uints.NewU8Array(msg1),
+		}, field)
+		assert.Error(err)
+	}, "aggregate-min-pk/same-message")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&aggregateMinSigCircuit[FR, G1El, G2El, GtEl]{Msg1: make([]uints.U8, len(msg1)), Msg2: make([]uints.U8, len(msg1))}, &aggregateMinSigCircuit[FR, G1El, G2El, GtEl]{
+			Pks:  [2]G2El{sw_bls12377.NewG2Affine(pk2[0]), sw_bls12377.NewG2Affine(pk2[1])},
+			Sig:  sw_bls12377.NewG1Affine(sigSigFast),
+			Msg1: uints.NewU8Array(msg1),
+			Msg2: uints.NewU8Array(msg1),
+		}, field)
+		assert.Error(err)
+	}, "aggregate-min-sig/same-message")
+}
+
+func TestVerifyBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, g2 := bn254.Generators()
+	sk := randomKey(assert, ecc.BN254.ScalarField())
+	var pk1 bn254.G1Affine
+	var pk2 bn254.G2Affine
+	pk1.ScalarMultiplication(&g1, sk)
+	pk2.ScalarMultiplication(&g2, sk)
+	h2, err := bn254.HashToG2(msg1, dstMinPk)
+	assert.NoError(err)
+	h1, err := bn254.HashToG1(msg1, dstMinSig)
+	assert.NoError(err)
+	var sig2 bn254.G2Affine
+	var sig1 bn254.G1Affine
+	sig2.ScalarMultiplication(&h2, sk)
+	sig1.ScalarMultiplication(&h1, sk)
+
+	type (
+		FR   = sw_bn254.ScalarField
+		G1El = sw_bn254.G1Affine
+		G2El = sw_bn254.G2Affine
+		GtEl = sw_bn254.GTEl
+	)
+	field := ecc.BN254.ScalarField()
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&minPkCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &minPkCircuit[FR, G1El, G2El, GtEl]{
+			Pk:  sw_bn254.NewG1Affine(pk1),
+			Sig: sw_bn254.NewG2Affine(sig2),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "min-pk")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&minSigCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &minSigCircuit[FR, G1El, G2El, GtEl]{
+			Pk:  sw_bn254.NewG2Affine(pk2),
+			Sig: sw_bn254.NewG1Affine(sig1),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "min-sig")
+}
+
+func TestVerifyBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, _ := bls12381.Generators()
+	var sk [2]*big.Int
+	var pk [2]bls12381.G1Affine
+	for i := range sk {
+		sk[i] = randomKey(assert, ecc.BLS12_381.ScalarField())
+		pk[i].ScalarMultiplication(&g1, sk[i])
+	}
+	h, err := bls12381.HashToG2(msg1, dstMinPk)
+	assert.NoError(err)
+	var sig, sigAgg bls12381.G2Affine
+	sig.ScalarMultiplication(&h, sk[0])
+	sigAgg.ScalarMultiplication(&h, sk[1])
+	sigAgg.Add(&sigAgg, &sig)
+
+	type (
+		FR   = sw_bls12381.ScalarField
+		G1El = sw_bls12381.G1Affine
+		G2El = sw_bls12381.G2Affine
+		GtEl = sw_bls12381.GTEl
+	)
+	field := ecc.BN254.ScalarField()
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&minPkCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &minPkCircuit[FR, G1El, G2El, GtEl]{
+			Pk:  sw_bls12381.NewG1Affine(pk[0]),
+			Sig: sw_bls12381.NewG2Affine(sig),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "min-pk")
+	assert.Run(func(assert *test.Assert) {
+		err := test.IsSolved(&fastAggregateMinPkCircuit[FR, G1El, G2El, GtEl]{Msg: make([]uints.U8, len(msg1))}, &fastAggregateMinPkCircuit[FR, G1El, G2El, GtEl]{
+			Pks: [2]G1El{sw_bls12381.NewG1Affine(pk[0]), sw_bls12381.NewG1Affine(pk[1])},
+			Sig: sw_bls12381.NewG2Affine(sigAgg),
+			Msg: uints.NewU8Array(msg1),
+		}, field)
+		assert.NoError(err)
+	}, "fast-aggregate-min-pk")
+}
@@ -0,0 +1,150 @@
+package eddsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/te_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Ed25519PublicKey stores an Ed25519 public key in its 32-byte encoded form as
+// defined in RFC 8032.
+type Ed25519PublicKey struct {
+	A [32]uints.U8
+}
+
+// Ed25519Signature stores an Ed25519 signature in its encoded form. R is the
+// 32-byte encoding of the nonce commitment and S the 32-byte little-endian
+// encoding of the response.
+type Ed25519Signature struct {
+	R [32]uints.U8
+	S [32]uints.U8
+}
+
+// Assign is a helper to assign a 32-byte encoded public key. It panics if the
+// input is not 32 bytes long.
+func (p *Ed25519PublicKey) Assign(buf []byte) {
+	if len(buf) != 32 {
+		panic(fmt.Sprintf("invalid public key length %d, expected 32", len(buf)))
+	}
+	copy(p.A[:], uints.NewU8Array(buf))
+}
+
+// Assign is a helper to assign a 64-byte encoded signature R || S. It panics
+// if the input is not 64 bytes long.
+func (s *Ed25519Signature) Assign(buf []byte) {
+	if len(buf) != 64 {
+		panic(fmt.Sprintf("invalid signature length %d, expected 64", len(buf)))
+	}
+	copy(s.R[:], uints.NewU8Array(buf[:32]))
+	copy(s.S[:], uints.NewU8Array(buf[32:]))
+}
+
+// VerifyEd25519 verifies an Ed25519 signature as defined in RFC 8032. The
+// message is not pre-hashed and can be of any length fixed at circuit compile
+// time. The curve arithmetic is emulated, so the method works over any native
+// field supported by the field emulation.
+//
+// The verification asserts that:
+//   - the public key A and the nonce commitment R are canonical encodings of
+//     points on the curve;
+//   - S is less than the group order L;
+//   - [8][S]B = [8]R + [8][k]A, where k = SHA-512(R || A || msg) interpreted
+//     as a little-endian integer.
+func VerifyEd25519(api frontend.API, sig Ed25519Signature, msg []uints.U8, pubKey Ed25519PublicKey) error {
+	curve, err := te_emulated.New[emulated.Ed25519Fp, emulated.Ed25519Fr](api, te_emulated.GetEd25519Params())
+	if err != nil {
+		return fmt.Errorf("new curve: %w", err)
+	}
+	baseApi, err := emulated.NewField[emulated.Ed25519Fp](api)
+	if err != nil {
+		return fmt.Errorf("new base api: %w", err)
+	}
+	scalarApi, err := emulated.NewField[emulated.Ed25519Fr](api)
+	if err != nil {
+		return fmt.Errorf("new scalar api: %w", err)
+	}
+	h, err := sha2.NewSHA512(api)
+	if err != nil {
+		return fmt.Errorf("new sha512: %w", err)
+	}
+
+	A, err := decodeEd25519Point(api, baseApi, pubKey.A)
+	if err != nil {
+		return fmt.Errorf("decode public key: %w", err)
+	}
+	R, err := decodeEd25519Point(api, baseApi, sig.R)
+	if err != nil {
+		return fmt.Errorf("decode R: %w", err)
+	}
+	S := scalarApi.FromBits(leBits(api, sig.S[:])...)
+	scalarApi.AssertIsInRange(S)
+
+	// k = SHA-512(R || A || msg) mod L
+	h.Write(sig.R[:])
+	h.Write(pubKey.A[:])
+	h.Write(msg)
+	digest := h.Sum()
+	kBits := leBits(api, digest)
+	kLo := scalarApi.FromBits(kBits[:256]...)
+	kHi := scalarApi.FromBits(kBits[256:]...)
+	// the digest is 512 bits which doesn't fit into the limbs of the scalar
+	// field, so we compute k = kLo + kHi * 2^256 mod L.
+	var fr emulated.Ed25519Fr
+	shift := new(big.Int).Lsh(big.NewInt(1), 256)
+	shift.Mod(shift, fr.Modulus())
+	k := scalarApi.Add(kLo, scalarApi.Mul(kHi, scalarApi.NewElement(shift)))
+
+	// [8]([S]B - [k]A - R) = O
+	Q := curve.JointScalarMulBase(curve.Neg(A), k, S)
+	Q = curve.Add(Q, curve.Neg(R))
+	Q = curve.MulByCofactor(Q)
+	curve.AssertIsEqual(Q, curve.Identity())
+	return nil
+}
+
+// decodeEd25519Point decodes the 32-byte encoding of a point as defined in
+// RFC 8032. It asserts that the y-coordinate is canonical and that the point
+// is on the curve. The x-coordinate is recovered using a hint and constrained
+// to have the parity given by the sign bit.
+func decodeEd25519Point(api frontend.API, baseApi *emulated.Field[emulated.Ed25519Fp], enc [32]uints.U8) (*te_emulated.AffinePoint[emulated.Ed25519Fp], error) {
+	params := te_emulated.GetEd25519Params()
+	bs := leBits(api, enc[:])
+	sign := bs[255]
+	y := baseApi.FromBits(bs[:255]...)
+	baseApi.AssertIsInRange(y)
+
+	// x² = (y² - 1) / (d y² + 1)
+	yy := baseApi.Mul(y, y)
+	u := baseApi.Sub(yy, baseApi.One())
+	v := baseApi.Add(baseApi.Mul(baseApi.NewElement(params.D), yy), baseApi.One())
+	hint, err := baseApi.NewHint(ed25519SqrtRatioHint, 1, u, v)
+	if err != nil {
+		return nil, fmt.Errorf("new hint: %w", err)
+	}
+	x := hint[0]
+	baseApi.AssertIsEqual(baseApi.Mul(baseApi.Mul(x, x), v), u)
+
+	// the hint returns the even root, so we negate it when the sign bit is set.
+	// The encoding with x = 0 and the sign bit set is invalid.
+	x = baseApi.Reduce(x)
+	baseApi.AssertIsInRange(x)
+	api.AssertIsEqual(baseApi.ToBits(x)[0], 0)
+	api.AssertIsEqual(api.Mul(sign, baseApi.IsZero(x)), 0)
+	x = baseApi.Select(sign, baseApi.Neg(x), x)
+	return &te_emulated.AffinePoint[emulated.Ed25519Fp]{X: *x, Y: *y}, nil
+}
+
+// leBits returns the little-endian bits of the little-endian bytes bs.
+func leBits(api frontend.API, bs []uints.U8) []frontend.Variable {
+	res := make([]frontend.Variable, 0, 8*len(bs))
+	for i := range bs {
+		res = append(res, bits.ToBinary(api, bs[i].Val, bits.WithNbDigits(8))...)
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+package eddsa
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type ed25519Circuit struct {
+	Sig Ed25519Signature
+	Msg []uints.U8
+	Pub Ed25519PublicKey
+}
+
+func (c *ed25519Circuit) Define(api frontend.API) error {
+	return VerifyEd25519(api, c.Sig, c.Msg, c.Pub)
+}
+
+func TestEd25519(t *testing.T) {
+	assert := test.NewAssert(t)
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing ed25519 signature verification")
+	sig := ed25519.Sign(sk, msg)
+
+	circuit := ed25519Circuit{Msg: make([]uints.U8, len(msg))}
+	witness := ed25519Circuit{Msg: uints.NewU8Array(msg)}
+	witness.Pub.Assign(pk)
+	witness.Sig.Assign(sig)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	msg[0] ^= 1
+	witness.Msg = uints.NewU8Array(msg)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestEd25519Vector(t *testing.T) {
+	// TEST 1 from RFC 8032, section 7.1
+	assert := test.NewAssert(t)
+	pk, err := hex.DecodeString("d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a")
+	assert.NoError(err)
+	sig, err := hex.DecodeString("e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b")
+	assert.NoError(err)
+
+	circuit := ed25519Circuit{}
+	witness := ed25519Circuit{}
+	witness.Pub.Assign(pk)
+	witness.Sig.Assign(sig)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// S + L is a non-canonical encoding of the same scalar and must be rejected.
+	var fr emulated.Ed25519Fr
+	s := new(big.Int).SetBytes(reverse(sig[32:]))
+	s.Add(s, fr.Modulus())
+	copy(sig[32:], reverse(s.FillBytes(make([]byte, 32))))
+	witness.Sig.Assign(sig)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func reverse(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i := range b {
+		res[len(b)-1-i] = b[i]
+	}
+	return res
+}
@@ -0,0 +1,46 @@
+package eddsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		ed25519SqrtRatioHint,
+	}
+}
+
+// ed25519SqrtRatioHint returns the even square root of u/v for the inputs u
+// and v. It returns an error if v is zero or u/v is not a quadratic residue.
+func ed25519SqrtRatioHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			if len(inputs) != 2 || len(outputs) != 1 {
+				return fmt.Errorf("expecting two inputs and one output")
+			}
+			vInv := new(big.Int).ModInverse(inputs[1], mod)
+			if vInv == nil {
+				return fmt.Errorf("v is not invertible")
+			}
+			r := new(big.Int).Mul(inputs[0], vInv)
+			r.Mod(r, mod)
+			x := new(big.Int).ModSqrt(r, mod)
+			if x == nil {
+				return fmt.Errorf("u/v is not a quadratic residue")
+			}
+			if x.Bit(0) == 1 {
+				x.Sub(mod, x)
+			}
+			outputs[0].Set(x)
+			return nil
+		})
+}
@@ -0,0 +1,291 @@
+/*
+Package rsa implements RSA signature verification.
+
+The package supports signatures with the PKCS #1 v1.5 and PSS encodings as
+defined in [RFC 8017]. In both cases the message digest is computed with
+SHA2-256 and for PSS the mask generation function is MGF1 with SHA2-256. The
+message digest is given as an input to the verification methods, which allows
+to compute it either in-circuit using [hash/sha2] or out-of-circuit.
+
+The modulus of the public key is a witness and the arithmetic is performed
+using the variable-modulus operations of the [emulated] package. The type
+parameter of the public key and signature defines the size of the modulus and
+the supported parametrisations are [emparams.Mod1e2048] and
+[emparams.Mod1e4096] for 2048-bit and 4096-bit moduli respectively. The
+modulus must be exactly of that size, which is asserted in-circuit. The public exponent is fixed at circuit
+compile time and can be any small integer greater than one, the common case
+being 65537.
+
+[RFC 8017]: https://datatracker.ietf.org/doc/html/rfc8017
+*/
+package rsa
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	mbits "math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// sha256Prefix is the DER encoding of the DigestInfo header for SHA2-256 used
+// in the PKCS #1 v1.5 encoding.
+var sha256Prefix = []byte{0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20}
+
+// PublicKey represents the RSA public key (n, e) to verify the signature for.
+type PublicKey[T emulated.FieldParams] struct {
+	// N is the modulus.
+	N emulated.Element[T]
+	// E is the public exponent. It is fixed at circuit compile time and is not
+	// a part of the witness, so it has to be set in the circuit definition.
+	E int `gnark:"-"`
+}
+
+// Signature represents the RSA signature.
+type Signature[T emulated.FieldParams] struct {
+	S emulated.Element[T]
+}
+
+// NewPublicKey returns a witness assignment of the public key with modulus n
+// and public exponent e. It returns an error if the bit length of the modulus
+// doesn't correspond to the type parameter or if the exponent is less than 2.
+func NewPublicKey[T emulated.FieldParams](n *big.Int, e int) (PublicKey[T], error) {
+	k := modulusLen[T]()
+	if n.BitLen() != 8*k {
+		return PublicKey[T]{}, fmt.Errorf("invalid modulus bit length %d, expected %d", n.BitLen(), 8*k)
+	}
+	if e < 2 {
+		return PublicKey[T]{}, fmt.Errorf("invalid public exponent %d", e)
+	}
+	return PublicKey[T]{
+		N: emulated.ValueOf[T](n),
+		E: e,
+	}, nil
+}
+
+// NewSignature returns a witness assignment of the byte-encoded signature. It
+// returns an error if the length of the signature doesn't correspond to the
+// modulus length defined by the type parameter.
+func NewSignature[T emulated.FieldParams](sig []byte) (Signature[T], error) {
+	k := modulusLen[T]()
+	if len(sig) != k {
+		return Signature[T]{}, fmt.Errorf("invalid signature length %d, expected %d", len(sig), k)
+	}
+	return Signature[T]{
+		S: emulated.ValueOf[T](new(big.Int).SetBytes(sig)),
+	}, nil
+}
+
+// modulusLen returns the length of the modulus in bytes.
+func modulusLen[T emulated.FieldParams]() int {
+	var fp T
+	return int(fp.NbLimbs()*fp.BitsPerLimb()) / 8
+}
+
+// VerifyPKCS1v15 asserts that sig is a valid signature of the SHA2-256 digest
+// hashed for the public key pk using the PKCS #1 v1.5 encoding. It returns an
+// error if the digest is not 32 bytes long or if the public exponent is
+// invalid.
+//
+// The verification asserts that the signature is less than the modulus and
+// that s^e mod n is 0x00 || 0x01 || 0xff...0xff || 0x00 || DigestInfo || hashed.
+func (pk PublicKey[T]) VerifyPKCS1v15(api frontend.API, hashed []uints.U8, sig *Signature[T]) error {
+	if len(hashed) != sha256.Size {
+		return fmt.Errorf("invalid digest length %d, expected %d", len(hashed), sha256.Size)
+	}
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return fmt.Errorf("new field: %w", err)
+	}
+	k := modulusLen[T]()
+	tLen := len(sha256Prefix) + len(hashed)
+	if k < tLen+11 {
+		return fmt.Errorf("modulus too short")
+	}
+	m, err := pk.rsavp1(api, f, sig)
+	if err != nil {
+		return err
+	}
+
+	// we construct the little-endian bits of the expected encoded message and
+	// compare it against m. Only the digest bits are variable.
+	em := make([]frontend.Variable, 8*k)
+	setByte := func(j int, bs []frontend.Variable) {
+		copy(em[8*(k-1-j):8*(k-j)], bs)
+	}
+	setByte(0, constBits(0x00))
+	setByte(1, constBits(0x01))
+	for j := 2; j < k-tLen-1; j++ {
+		setByte(j, constBits(0xff))
+	}
+	setByte(k-tLen-1, constBits(0x00))
+	for i := range sha256Prefix {
+		setByte(k-tLen+i, constBits(sha256Prefix[i]))
+	}
+	for i := range hashed {
+		setByte(k-len(hashed)+i, bits.ToBinary(api, hashed[i].Val, bits.WithNbDigits(8)))
+	}
+	f.AssertLimbsEquality(m, f.FromBits(em...))
+	return nil
+}
+
+// VerifyPSS asserts that sig is a valid signature of the SHA2-256 digest
+// hashed for the public key pk using the PSS encoding with MGF1-SHA2-256 mask
+// generation function and salt length saltLen. It returns an error if the
+// digest is not 32 bytes long, if the salt is too long or if the public
+// exponent is invalid.
+//
+// The verification follows EMSA-PSS-VERIFY with the encoded message length
+// emBits = 8k-1, where k is the modulus length in bytes.
+func (pk PublicKey[T]) VerifyPSS(api frontend.API, hashed []uints.U8, sig *Signature[T], saltLen int) error {
+	hLen := sha256.Size
+	if len(hashed) != hLen {
+		return fmt.Errorf("invalid digest length %d, expected %d", len(hashed), hLen)
+	}
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return fmt.Errorf("new field: %w", err)
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return fmt.Errorf("new uints: %w", err)
+	}
+	emLen := modulusLen[T]()
+	if saltLen < 0 || emLen < hLen+saltLen+2 {
+		return fmt.Errorf("invalid salt length %d", saltLen)
+	}
+	m, err := pk.rsavp1(api, f, sig)
+	if err != nil {
+		return err
+	}
+	mBits := f.ToBits(m)[:8*emLen]
+	// emByte returns the little-endian bits of the j-th byte of the big-endian
+	// encoded message.
+	emByte := func(j int) []frontend.Variable {
+		return mBits[8*(emLen-1-j) : 8*(emLen-j)]
+	}
+
+	// EM = maskedDB || H || 0xbc
+	for i, b := range constBits(0xbc) {
+		api.AssertIsEqual(emByte(emLen - 1)[i], b)
+	}
+	h := make([]uints.U8, hLen)
+	for i := range h {
+		h[i] = uapi.ByteValueOf(bits.FromBinary(api, emByte(emLen-hLen-1+i)))
+	}
+	// as emBits = 8emLen-1, the leftmost bit of maskedDB must be zero.
+	api.AssertIsEqual(mBits[8*emLen-1], 0)
+
+	// DB = maskedDB XOR MGF1(H) = PS || 0x01 || salt, where PS is zeros. The
+	// leftmost bit of DB is ignored.
+	dbLen := emLen - hLen - 1
+	psLen := dbLen - saltLen - 1
+	dbMask, err := mgf1(api, h, dbLen)
+	if err != nil {
+		return err
+	}
+	salt := make([]uints.U8, saltLen)
+	for j := 0; j < dbLen; j++ {
+		masked := emByte(j)
+		mask := bits.ToBinary(api, dbMask[j].Val, bits.WithNbDigits(8))
+		switch {
+		case j < psLen:
+			for i := range mask {
+				if j == 0 && i == 7 {
+					continue
+				}
+				api.AssertIsEqual(masked[i], mask[i])
+			}
+		case j == psLen:
+			for i := range mask {
+				if j == 0 && i == 7 {
+					continue
+				}
+				if i == 0 {
+					api.AssertIsEqual(api.Add(masked[i], mask[i]), 1)
+				} else {
+					api.AssertIsEqual(masked[i], mask[i])
+				}
+			}
+		default:
+			db := make([]frontend.Variable, 8)
+			for i := range db {
+				db[i] = api.Xor(masked[i], mask[i])
+			}
+			salt[j-psLen-1] = uapi.ByteValueOf(bits.FromBinary(api, db))
+		}
+	}
+
+	// H = SHA2-256(0x00...0x00 || hashed || salt)
+	hasher, err := sha2.New(api)
+	if err != nil {
+		return fmt.Errorf("new hasher: %w", err)
+	}
+	hasher.Write(uints.NewU8Array(make([]byte, 8)))
+	hasher.Write(hashed)
+	hasher.Write(salt)
+	expected := hasher.Sum()
+	for i := range h {
+		uapi.ByteAssertEq(h[i], expected[i])
+	}
+	return nil
+}
+
+// rsavp1 asserts that the modulus is exactly 8k bits long and that the
+// signature is less than the modulus and returns s^e mod n. The result is
+// strictly less than the modulus.
+func (pk PublicKey[T]) rsavp1(api frontend.API, f *emulated.Field[T], sig *Signature[T]) (*emulated.Element[T], error) {
+	if pk.E < 2 {
+		return nil, fmt.Errorf("invalid public exponent %d", pk.E)
+	}
+	// the modulus is a witness, so we check the width also in-circuit. The
+	// encodings assume that the encoded message is k bytes long and PSS that
+	// emBits = 8k-1.
+	nBits := f.ToBits(&pk.N)
+	for i := 8 * modulusLen[T](); i < len(nBits); i++ {
+		api.AssertIsEqual(nBits[i], 0)
+	}
+	api.AssertIsEqual(nBits[8*modulusLen[T]()-1], 1)
+	f.ModAssertIsInRange(&sig.S, &pk.N)
+	res := &sig.S
+	for i := mbits.Len(uint(pk.E)) - 2; i >= 0; i-- {
+		res = f.ModMul(res, res, &pk.N)
+		if (pk.E>>i)&1 == 1 {
+			res = f.ModMul(res, &sig.S, &pk.N)
+		}
+	}
+	f.ModAssertIsInRange(res, &pk.N)
+	return res, nil
+}
+
+// mgf1 computes the MGF1 mask of length bytes for the seed using SHA2-256.
+func mgf1(api frontend.API, seed []uints.U8, length int) ([]uints.U8, error) {
+	var res []uints.U8
+	var counter [4]byte
+	for c := uint32(0); len(res) < length; c++ {
+		h, err := sha2.New(api)
+		if err != nil {
+			return nil, fmt.Errorf("new hasher: %w", err)
+		}
+		binary.BigEndian.PutUint32(counter[:], c)
+		h.Write(seed)
+		h.Write(uints.NewU8Array(counter[:]))
+		res = append(res, h.Sum()...)
+	}
+	return res[:length], nil
+}
+
+// constBits returns the little-endian bits of the constant byte b.
+func constBits(b byte) []frontend.Variable {
+	res := make([]frontend.Variable, 8)
+	for i := range res {
+		res[i] = (b >> i) & 1
+	}
+	return res
+}
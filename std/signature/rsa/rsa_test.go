@@ -0,0 +1,216 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type pkcs1v15Circuit[T emulated.FieldParams] struct {
+	Pub    PublicKey[T]
+	Sig    Signature[T]
+	Hashed [32]uints.U8
+}
+
+func (c *pkcs1v15Circuit[T]) Define(api frontend.API) error {
+	return c.Pub.VerifyPKCS1v15(api, c.Hashed[:], &c.Sig)
+}
+
+type pssCircuit[T emulated.FieldParams] struct {
+	Pub    PublicKey[T]
+	Sig    Signature[T]
+	Hashed [32]uints.U8
+
+	saltLen int
+}
+
+func (c *pssCircuit[T]) Define(api frontend.API) error {
+	return c.Pub.VerifyPSS(api, c.Hashed[:], &c.Sig, c.saltLen)
+}
+
+// generateKey returns an RSA private key with the given public exponent. The
+// standard library only generates keys with exponent 65537.
+func generateKey(t *testing.T, bits, e int) *rsa.PrivateKey {
+	if e == 65537 {
+		sk, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sk
+	}
+	one := big.NewInt(1)
+	E := big.NewInt(int64(e))
+	for {
+		p, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := new(big.Int).Mul(p, q)
+		phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+		d := new(big.Int).ModInverse(E, phi)
+		if p.Cmp(q) == 0 || n.BitLen() != bits || d == nil {
+			continue
+		}
+		sk := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: n, E: e},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		sk.Precompute()
+		if err := sk.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		return sk
+	}
+}
+
+func testPKCS1v15[T emulated.FieldParams](t *testing.T, e int) {
+	assert := test.NewAssert(t)
+	sk := generateKey(t, 8*modulusLen[T](), e)
+	msg := []byte("testing RSA PKCS #1 v1.5 signature")
+	hashed := sha256.Sum256(msg)
+	sigBytes, err := rsa.SignPKCS1v15(rand.Reader, sk, crypto.SHA256, hashed[:])
+	assert.NoError(err)
+	pk, err := NewPublicKey[T](sk.N, sk.E)
+	assert.NoError(err)
+	sig, err := NewSignature[T](sigBytes)
+	assert.NoError(err)
+
+	circuit := pkcs1v15Circuit[T]{Pub: PublicKey[T]{E: sk.E}}
+	witness := pkcs1v15Circuit[T]{Pub: pk, Sig: sig}
+	copy(witness.Hashed[:], uints.NewU8Array(hashed[:]))
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	hashed[0] ^= 1
+	copy(witness.Hashed[:], uints.NewU8Array(hashed[:]))
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestPKCS1v15(t *testing.T) {
+	testPKCS1v15[emparams.Mod1e2048](t, 65537)
+}
+
+func TestPKCS1v15SmallExponent(t *testing.T) {
+	testPKCS1v15[emparams.Mod1e2048](t, 3)
+}
+
+func TestPKCS1v15Mod4096(t *testing.T) {
+	testPKCS1v15[emparams.Mod1e4096](t, 65537)
+}
+
+func TestPSS(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk := generateKey(t, 2048, 65537)
+	msg := []byte("testing RSA PSS signature")
+	hashed := sha256.Sum256(msg)
+	for _, saltLen := range []int{0, 32} {
+		var sigBytes []byte
+		var err error
+		if saltLen == 0 {
+			// the standard library interprets zero salt length as automatic.
+			sigBytes = signPSSNoSalt(sk, hashed[:])
+		} else {
+			sigBytes, err = rsa.SignPSS(rand.Reader, sk, crypto.SHA256, hashed[:], &rsa.PSSOptions{SaltLength: saltLen})
+			assert.NoError(err)
+		}
+		err = rsa.VerifyPSS(&sk.PublicKey, crypto.SHA256, hashed[:], sigBytes, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		assert.NoError(err)
+		pk, err := NewPublicKey[emparams.Mod1e2048](sk.N, sk.E)
+		assert.NoError(err)
+		sig, err := NewSignature[emparams.Mod1e2048](sigBytes)
+		assert.NoError(err)
+
+		circuit := pssCircuit[emparams.Mod1e2048]{Pub: PublicKey[emparams.Mod1e2048]{E: sk.E}, saltLen: saltLen}
+		witness := pssCircuit[emparams.Mod1e2048]{Pub: pk, Sig: sig}
+		copy(witness.Hashed[:], uints.NewU8Array(hashed[:]))
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+
+		wrong := hashed
+		wrong[0] ^= 1
+		copy(witness.Hashed[:], uints.NewU8Array(wrong[:]))
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}
+}
+
+// signPSSNoSalt computes the PSS signature with empty salt.
+func signPSSNoSalt(sk *rsa.PrivateKey, hashed []byte) []byte {
+	k := (sk.N.BitLen() + 7) / 8
+	hLen := sha256.Size
+	h := sha256.New()
+	h.Write(make([]byte, 8))
+	h.Write(hashed)
+	H := h.Sum(nil)
+	db := make([]byte, k-hLen-1)
+	db[len(db)-1] = 0x01
+	var mask []byte
+	for c := byte(0); len(mask) < len(db); c++ {
+		mh := sha256.New()
+		mh.Write(H)
+		mh.Write([]byte{0, 0, 0, c})
+		mask = mh.Sum(mask)
+	}
+	for i := range db {
+		db[i] ^= mask[i]
+	}
+	db[0] &= 0x7f
+	em := append(append(db, H...), 0xbc)
+	s := new(big.Int).Exp(new(big.Int).SetBytes(em), sk.D, sk.N)
+	return s.FillBytes(make([]byte, k))
+}
+
+func TestNewPublicKeyInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk := generateKey(t, 2048, 65537)
+	_, err := NewPublicKey[emparams.Mod1e4096](sk.N, sk.E)
+	assert.Error(err)
+	_, err = NewPublicKey[emparams.Mod1e2048](sk.N, 1)
+	assert.Error(err)
+	_, err = NewSignature[emparams.Mod1e2048](make([]byte, 255))
+	assert.Error(err)
+}
+
+func TestPKCS1v15ShortModulus(t *testing.T) {
+	// NewPublicKey rejects a modulus shorter than the type parameter, but it
+	// could be assigned directly. The 256-byte encoded message is less than a
+	// 2044-bit modulus, so the signature is rejected only by the width check.
+	assert := test.NewAssert(t)
+	sk := generateKey(t, 2044, 3)
+	hashed := sha256.Sum256([]byte("testing RSA short modulus"))
+	k := modulusLen[emparams.Mod1e2048]()
+	tLen := len(sha256Prefix) + len(hashed)
+	em := make([]byte, k)
+	em[1] = 0x01
+	for j := 2; j < k-tLen-1; j++ {
+		em[j] = 0xff
+	}
+	copy(em[k-tLen:], sha256Prefix)
+	copy(em[k-len(hashed):], hashed[:])
+	s := new(big.Int).Exp(new(big.Int).SetBytes(em), sk.D, sk.N)
+
+	circuit := pkcs1v15Circuit[emparams.Mod1e2048]{Pub: PublicKey[emparams.Mod1e2048]{E: sk.E}}
+	witness := pkcs1v15Circuit[emparams.Mod1e2048]{
+		Pub: PublicKey[emparams.Mod1e2048]{N: emulated.ValueOf[emparams.Mod1e2048](sk.N), E: sk.E},
+		Sig: Signature[emparams.Mod1e2048]{S: emulated.ValueOf[emparams.Mod1e2048](s)},
+	}
+	copy(witness.Hashed[:], uints.NewU8Array(hashed[:]))
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,40 @@
+package schnorr
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		evenSqrtHint,
+	}
+}
+
+// evenSqrtHint returns the square root of the input with even parity. It
+// returns an error if the input is not a quadratic residue.
+func evenSqrtHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			if len(inputs) != 1 || len(outputs) != 1 {
+				return fmt.Errorf("expecting one input and one output")
+			}
+			y := new(big.Int).ModSqrt(inputs[0], mod)
+			if y == nil {
+				return fmt.Errorf("input is not a quadratic residue")
+			}
+			if y.Bit(0) == 1 {
+				y.Sub(mod, y)
+			}
+			outputs[0].Set(y)
+			return nil
+		})
+}
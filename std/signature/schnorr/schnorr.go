@@ -0,0 +1,182 @@
+/*
+Package schnorr implements BIP-340 Schnorr signature verification over
+secp256k1.
+
+The package depends on the [emulated/sw_emulated] package for elliptic curve
+group operations using non-native arithmetic and on the [hash/sha2] package
+for computing the tagged challenge hash. Public keys are x-only, i.e. only the
+x-coordinate of the public key is given and the point with even y-coordinate is
+used.
+
+Signatures and public keys in their byte-encoded form can be assigned to the
+circuit using [NewSignature] and [NewPublicKey].
+
+See [BIP-340] for the signature verification algorithm.
+
+[BIP-340]: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+*/
+package schnorr
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// challengeTag is the tag used for computing the challenge hash.
+const challengeTag = "BIP0340/challenge"
+
+// Signature represents the BIP-340 signature (r, s) for some message, where r
+// is the x-coordinate of the nonce commitment and s the response.
+type Signature struct {
+	R emulated.Element[emulated.Secp256k1Fp]
+	S emulated.Element[emulated.Secp256k1Fr]
+}
+
+// PublicKey represents the x-only public key to verify the signature for.
+type PublicKey struct {
+	X emulated.Element[emulated.Secp256k1Fp]
+}
+
+// NewSignature parses the 64-byte encoded signature r || s into a witness
+// assignment. It returns an error if the input is not 64 bytes long, if r is
+// not less than the base field modulus or s is not less than the scalar field
+// modulus.
+func NewSignature(sig []byte) (Signature, error) {
+	if len(sig) != 64 {
+		return Signature{}, fmt.Errorf("invalid signature length %d, expected 64", len(sig))
+	}
+	var fp emulated.Secp256k1Fp
+	var fr emulated.Secp256k1Fr
+	r := new(big.Int).SetBytes(sig[:32])
+	if r.Cmp(fp.Modulus()) >= 0 {
+		return Signature{}, fmt.Errorf("r is not less than the base field modulus")
+	}
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(fr.Modulus()) >= 0 {
+		return Signature{}, fmt.Errorf("s is not less than the scalar field modulus")
+	}
+	return Signature{
+		R: emulated.ValueOf[emulated.Secp256k1Fp](r),
+		S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+	}, nil
+}
+
+// NewPublicKey parses the 32-byte x-only public key into a witness assignment.
+// It returns an error if the input is not 32 bytes long or if it is not less
+// than the base field modulus.
+func NewPublicKey(pk []byte) (PublicKey, error) {
+	if len(pk) != 32 {
+		return PublicKey{}, fmt.Errorf("invalid public key length %d, expected 32", len(pk))
+	}
+	var fp emulated.Secp256k1Fp
+	x := new(big.Int).SetBytes(pk)
+	if x.Cmp(fp.Modulus()) >= 0 {
+		return PublicKey{}, fmt.Errorf("public key is not less than the base field modulus")
+	}
+	return PublicKey{
+		X: emulated.ValueOf[emulated.Secp256k1Fp](x),
+	}, nil
+}
+
+// Verify asserts that the signature sig verifies for the message msg and the
+// x-only public key pk as defined in BIP-340. The message is not pre-hashed
+// and can be of any length fixed at circuit compile time.
+//
+// The verification asserts that:
+//   - the public key is less than the base field modulus and is the
+//     x-coordinate of a point P on the curve (the point with even y-coordinate
+//     is used);
+//   - r is less than the base field modulus and s is less than the scalar
+//     field modulus;
+//   - R = [s]G - [e]P, where e = int(hash_BIP0340/challenge(r || P.x || msg))
+//     mod n, has even y-coordinate and x-coordinate r.
+//
+// The method uses incomplete arithmetic, so the circuit has no solution in
+// the negligible probability cases where s or e is zero. It returns an error
+// if the gadgets used for the verification cannot be initialized.
+func (pk PublicKey) Verify(api frontend.API, msg []uints.U8, sig *Signature) error {
+	cr, err := sw_emulated.New[emulated.Secp256k1Fp, emulated.Secp256k1Fr](api, sw_emulated.GetSecp256k1Params())
+	if err != nil {
+		return fmt.Errorf("new curve: %w", err)
+	}
+	scalarApi, err := emulated.NewField[emulated.Secp256k1Fr](api)
+	if err != nil {
+		return fmt.Errorf("new scalar field: %w", err)
+	}
+	baseApi, err := emulated.NewField[emulated.Secp256k1Fp](api)
+	if err != nil {
+		return fmt.Errorf("new base field: %w", err)
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return fmt.Errorf("new uints api: %w", err)
+	}
+	h, err := sha2.New(api)
+	if err != nil {
+		return fmt.Errorf("new sha2: %w", err)
+	}
+
+	// lift x: P = (x, y) with y even and y² = x³ + 7
+	baseApi.AssertIsInRange(&pk.X)
+	y2 := baseApi.Mul(&pk.X, &pk.X)
+	y2 = baseApi.Mul(y2, &pk.X)
+	y2 = baseApi.Add(y2, baseApi.NewElement(7))
+	hint, err := baseApi.NewHint(evenSqrtHint, 1, y2)
+	if err != nil {
+		return fmt.Errorf("even sqrt hint: %w", err)
+	}
+	y := hint[0]
+	baseApi.AssertIsEqual(baseApi.Mul(y, y), y2)
+	assertIsEven(api, baseApi, y)
+	P := sw_emulated.AffinePoint[emulated.Secp256k1Fp]{X: pk.X, Y: *y}
+
+	baseApi.AssertIsInRange(&sig.R)
+	scalarApi.AssertIsInRange(&sig.S)
+
+	// e = int(hash_BIP0340/challenge(r || P.x || msg)) mod n where the tagged
+	// hash is SHA256(SHA256(tag) || SHA256(tag) || data).
+	tagHash := sha256.Sum256([]byte(challengeTag))
+	h.Write(uints.NewU8Array(tagHash[:]))
+	h.Write(uints.NewU8Array(tagHash[:]))
+	h.Write(toBytes(api, uapi, baseApi.ToBits(&sig.R)))
+	h.Write(toBytes(api, uapi, baseApi.ToBits(&pk.X)))
+	h.Write(msg)
+	digest := h.Sum()
+	eBits := make([]frontend.Variable, 0, 8*len(digest))
+	for i := len(digest) - 1; i >= 0; i-- {
+		eBits = append(eBits, bits.ToBinary(api, digest[i].Val, bits.WithNbDigits(8))...)
+	}
+	e := scalarApi.FromBits(eBits...)
+
+	// R = [s]G + [-e]P
+	R := cr.JointScalarMulBase(&P, scalarApi.Neg(e), &sig.S)
+	baseApi.AssertIsEqual(&R.X, &sig.R)
+	assertIsEven(api, baseApi, &R.Y)
+	return nil
+}
+
+// assertIsEven asserts that the canonical representation of x is even.
+func assertIsEven(api frontend.API, f *emulated.Field[emulated.Secp256k1Fp], x *emulated.Element[emulated.Secp256k1Fp]) {
+	x = f.Reduce(x)
+	f.AssertIsInRange(x)
+	api.AssertIsEqual(f.ToBits(x)[0], 0)
+}
+
+// toBytes returns the big-endian bytes of the little-endian bits bs. The
+// number of bits must be a multiple of 8.
+func toBytes(api frontend.API, uapi *uints.BinaryField[uints.U32], bs []frontend.Variable) []uints.U8 {
+	nbBytes := len(bs) / 8
+	res := make([]uints.U8, nbBytes)
+	for i := 0; i < nbBytes; i++ {
+		res[nbBytes-1-i] = uapi.ByteValueOf(bits.FromBinary(api, bs[8*i:8*i+8]))
+	}
+	return res
+}
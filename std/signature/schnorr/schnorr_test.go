@@ -0,0 +1,170 @@
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type schnorrCircuit struct {
+	Sig Signature
+	Msg []uints.U8
+	Pub PublicKey
+}
+
+func (c *schnorrCircuit) Define(api frontend.API) error {
+	return c.Pub.Verify(api, c.Msg, &c.Sig)
+}
+
+func taggedHash(tag string, data ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for i := range data {
+		h.Write(data[i])
+	}
+	return h.Sum(nil)
+}
+
+// sign returns the x-only public key and BIP-340 signature of msg for the
+// secret key sk. The nonce is sampled at random instead of being derived as in
+// the default signing algorithm as it doesn't affect the verification.
+func sign(t *testing.T, sk *big.Int, msg []byte) (pk, sig []byte) {
+	_, g := secp256k1.Generators()
+	n := fr.Modulus()
+	var P, R secp256k1.G1Affine
+	P.ScalarMultiplication(&g, sk)
+	d := new(big.Int).Set(sk)
+	if P.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+		d.Sub(n, d)
+	}
+	k, err := rand.Int(rand.Reader, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	R.ScalarMultiplication(&g, k)
+	if R.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+		k.Sub(n, k)
+	}
+	px, rx := P.X.Bytes(), R.X.Bytes()
+	e := new(big.Int).SetBytes(taggedHash(challengeTag, rx[:], px[:], msg))
+	s := new(big.Int).Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, n)
+	sig = make([]byte, 64)
+	copy(sig[:32], rx[:])
+	s.FillBytes(sig[32:])
+	return px[:], sig
+}
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestSchnorr(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := rand.Int(rand.Reader, fr.Modulus())
+	assert.NoError(err)
+	msg := []byte("testing BIP-340 schnorr")
+	pkBytes, sigBytes := sign(t, sk, msg)
+	pk, err := NewPublicKey(pkBytes)
+	assert.NoError(err)
+	sig, err := NewSignature(sigBytes)
+	assert.NoError(err)
+
+	circuit := schnorrCircuit{Msg: make([]uints.U8, len(msg))}
+	witness := schnorrCircuit{
+		Sig: sig,
+		Msg: uints.NewU8Array(msg),
+		Pub: pk,
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	msg[0] ^= 1
+	witness.Msg = uints.NewU8Array(msg)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestSchnorrVectors(t *testing.T) {
+	// test vectors from https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+	vectors := []struct {
+		pk, msg, sig string
+		valid        bool
+	}{
+		{
+			pk:    "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			msg:   "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:   "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			valid: true,
+		},
+		{
+			pk:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			msg:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			sig:   "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			valid: true,
+		},
+		{
+			// vector 1 with a modified message
+			pk:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			msg:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C88",
+			sig:   "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			valid: false,
+		},
+	}
+	assert := test.NewAssert(t)
+	for i, v := range vectors {
+		v := v
+		assert.Run(func(assert *test.Assert) {
+			msg := mustDecode(v.msg)
+			pk, err := NewPublicKey(mustDecode(v.pk))
+			assert.NoError(err)
+			sig, err := NewSignature(mustDecode(v.sig))
+			assert.NoError(err)
+			circuit := schnorrCircuit{Msg: make([]uints.U8, len(msg))}
+			witness := schnorrCircuit{
+				Sig: sig,
+				Msg: uints.NewU8Array(msg),
+				Pub: pk,
+			}
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			if v.valid {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		}, fmt.Sprintf("vector-%d", i))
+	}
+}
+
+func TestNewSignatureInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, err := NewSignature(make([]byte, 63))
+	assert.Error(err)
+	_, err = NewPublicKey(make([]byte, 33))
+	assert.Error(err)
+	// r = p
+	sig := mustDecode("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B")
+	_, err = NewSignature(sig)
+	assert.Error(err)
+	// s = n
+	sig = mustDecode("6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
+	_, err = NewSignature(sig)
+	assert.Error(err)
+}